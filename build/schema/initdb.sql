@@ -1,10 +1,119 @@
 CREATE TABLE IF NOT EXISTS link (
-    id            BIGSERIAL,
-    original_link TEXT UNIQUE,
-    token         TEXT UNIQUE,
-    expires_at    TIMESTAMPTZ,
-    PRIMARY KEY (id)
+    id                 BIGSERIAL,
+    original_link      TEXT NOT NULL,
+    canonical_link     TEXT NOT NULL DEFAULT '',
+    token              TEXT NOT NULL,
+    domain             TEXT NOT NULL DEFAULT '',
+    expires_at         TIMESTAMPTZ,
+    active_from        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    fallback_link      TEXT NOT NULL DEFAULT '',
+    password_hash      TEXT NOT NULL DEFAULT '',
+    max_clicks         BIGINT NOT NULL DEFAULT 0,
+    clicks_left        BIGINT NOT NULL DEFAULT 0,
+    clicks             BIGINT NOT NULL DEFAULT 0,
+    rules              JSONB NOT NULL DEFAULT '[]',
+    variants           JSONB NOT NULL DEFAULT '[]',
+    forward_path       BOOLEAN NOT NULL DEFAULT false,
+    forward_query      TEXT NOT NULL DEFAULT '',
+    redirect_code      INTEGER NOT NULL DEFAULT 0,
+    interstitial       BOOLEAN NOT NULL DEFAULT false,
+    interstitial_delay INTEGER NOT NULL DEFAULT 0,
+    title              TEXT NOT NULL DEFAULT '',
+    description        TEXT NOT NULL DEFAULT '',
+    tags               TEXT[] NOT NULL DEFAULT '{}',
+    notes              TEXT NOT NULL DEFAULT '',
+    utm                JSONB NOT NULL DEFAULT '{}',
+    page               JSONB NOT NULL DEFAULT '{}',
+    open_graph         JSONB NOT NULL DEFAULT '{}',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (id),
+    UNIQUE (domain, token)
 );
 
+-- Databases created from an earlier version of this file get the columns and
+-- constraints added since. On a fresh database every statement is a no-op, so
+-- the file may be run again as is: psql -f build/schema/initdb.sql
+ALTER TABLE link ADD COLUMN IF NOT EXISTS canonical_link     TEXT NOT NULL DEFAULT '';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS domain             TEXT NOT NULL DEFAULT '';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS active_from        TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE link ADD COLUMN IF NOT EXISTS fallback_link      TEXT NOT NULL DEFAULT '';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS password_hash      TEXT NOT NULL DEFAULT '';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS max_clicks         BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE link ADD COLUMN IF NOT EXISTS clicks_left        BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE link ADD COLUMN IF NOT EXISTS clicks             BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE link ADD COLUMN IF NOT EXISTS rules              JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS variants           JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS forward_path       BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE link ADD COLUMN IF NOT EXISTS forward_query      TEXT NOT NULL DEFAULT '';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS redirect_code      INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE link ADD COLUMN IF NOT EXISTS interstitial       BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE link ADD COLUMN IF NOT EXISTS interstitial_delay INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE link ADD COLUMN IF NOT EXISTS title              TEXT NOT NULL DEFAULT '';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS description        TEXT NOT NULL DEFAULT '';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS tags               TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS notes              TEXT NOT NULL DEFAULT '';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS utm                JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS page               JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS open_graph         JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE link ADD COLUMN IF NOT EXISTS created_at         TIMESTAMPTZ NOT NULL DEFAULT now();
+
+ALTER TABLE link ALTER COLUMN original_link SET NOT NULL;
+ALTER TABLE link ALTER COLUMN token SET NOT NULL;
+
+-- Links to the same destination may have several tokens, and tokens are
+-- only unique within their domain.
+ALTER TABLE link DROP CONSTRAINT IF EXISTS link_original_link_key;
+ALTER TABLE link DROP CONSTRAINT IF EXISTS link_token_key;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'link_domain_token_key') THEN
+        ALTER TABLE link ADD CONSTRAINT link_domain_token_key UNIQUE (domain, token);
+    END IF;
+END
+$$;
+
 CREATE INDEX IF NOT EXISTS token_idx
-    ON link (token)
\ No newline at end of file
+    ON link (token);
+
+CREATE INDEX IF NOT EXISTS tags_idx
+    ON link USING GIN (tags);
+
+CREATE TABLE IF NOT EXISTS link_variant (
+    domain  TEXT NOT NULL,
+    token   TEXT NOT NULL,
+    variant INTEGER NOT NULL,
+    hits    BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (domain, token, variant),
+    FOREIGN KEY (domain, token) REFERENCES link (domain, token) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS webhook (
+    id               TEXT NOT NULL,
+    url              TEXT NOT NULL,
+    secret           TEXT NOT NULL,
+    events           TEXT[] NOT NULL DEFAULT '{}',
+    click_thresholds BIGINT[] NOT NULL DEFAULT '{}',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id           TEXT NOT NULL,
+    webhook_id   TEXT NOT NULL,
+    event        TEXT NOT NULL,
+    payload      TEXT NOT NULL,
+    attempts     INTEGER NOT NULL DEFAULT 0,
+    next_attempt TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error   TEXT NOT NULL DEFAULT '',
+    dead         BOOLEAN NOT NULL DEFAULT false,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (id),
+    FOREIGN KEY (webhook_id) REFERENCES webhook (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_due_idx
+    ON webhook_delivery (next_attempt) WHERE NOT dead;
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_dead_idx
+    ON webhook_delivery (webhook_id, created_at) WHERE dead;
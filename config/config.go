@@ -9,15 +9,23 @@ import (
 
 type (
 	Config struct {
-		App      `yaml:"app"`
-		HTTP     `yaml:"http"`
-		GRPC     `yaml:"grpc"`
-		Log      `yaml:"log"`
-		PG       `yaml:"postgres"`
-		Service  `yaml:"service"`
-		LinkGen  `yaml:"generator"`
-		Redis    `yaml:"redis"`
-		UseRedis bool
+		App              `yaml:"app"`
+		HTTP             `yaml:"http"`
+		GRPC             `yaml:"grpc"`
+		Log              `yaml:"log"`
+		PG               `yaml:"postgres"`
+		Service          `yaml:"service"`
+		LinkGen          `yaml:"generator"`
+		Redis            `yaml:"redis"`
+		Validation       `yaml:"validation"`
+		Canonicalization `yaml:"canonicalization"`
+		Password         `yaml:"password"`
+		GeoIP            `yaml:"geoip"`
+		QRCode           `yaml:"qr_code"`
+		UTM              `yaml:"utm"`
+		Unfurl           `yaml:"unfurl"`
+		Webhooks         `yaml:"webhooks"`
+		UseRedis         bool
 	}
 
 	App struct {
@@ -26,13 +34,44 @@ type (
 	}
 
 	HTTP struct {
-		Port         string        `env-required:"true" yaml:"port" env:"HTTP_PORT"`
-		WriteTimeout time.Duration `env-required:"true" yaml:"write_timeout" env:"WRITE_TIMEOUT"`
-		ReadTimeout  time.Duration `env-required:"true" yaml:"read_timeout" env:"READ_TIMEOUT"`
+		Port           string        `env-required:"true" yaml:"port" env:"HTTP_PORT"`
+		WriteTimeout   time.Duration `env-required:"true" yaml:"write_timeout" env:"WRITE_TIMEOUT"`
+		ReadTimeout    time.Duration `env-required:"true" yaml:"read_timeout" env:"READ_TIMEOUT"`
+		TLS            TLS           `yaml:"tls"`
+		RedirectPort   string        `yaml:"redirect_port" env:"HTTP_REDIRECT_PORT"`
+		CORS           CORS          `yaml:"cors"`
+		TrustedProxies []string      `yaml:"trusted_proxies" env:"HTTP_TRUSTED_PROXIES"`
+	}
+
+	CORS struct {
+		AllowOrigins []string      `yaml:"allow_origins" env:"HTTP_CORS_ALLOW_ORIGINS"`
+		MaxAge       time.Duration `yaml:"max_age" env-default:"12h"`
+	}
+
+	TLS struct {
+		CertFile       string        `yaml:"cert_file"`
+		KeyFile        string        `yaml:"key_file"`
+		ClientCA       string        `yaml:"client_ca"`
+		ReloadInterval time.Duration `yaml:"reload_interval"`
 	}
 
 	GRPC struct {
-		Port string `env-required:"true" yaml:"port" env:"GRPC_PORT"`
+		Host           string        `yaml:"host" env:"GRPC_HOST"`
+		Port           string        `env-required:"true" yaml:"port" env:"GRPC_PORT"`
+		MaxRecvMsgSize int           `yaml:"max_recv_msg_size" env-default:"4194304"`
+		MaxSendMsgSize int           `yaml:"max_send_msg_size" env-default:"4194304"`
+		Timeout        time.Duration `yaml:"timeout" env-default:"10s"`
+		Reflection     bool          `yaml:"reflection" env:"GRPC_REFLECTION"`
+		Keepalive      GRPCKeepalive `yaml:"keepalive"`
+		TLS            TLS           `yaml:"tls"`
+	}
+
+	GRPCKeepalive struct {
+		Time                time.Duration `yaml:"time" env-default:"2h"`
+		Timeout             time.Duration `yaml:"timeout" env-default:"20s"`
+		MaxConnectionIdle   time.Duration `yaml:"max_connection_idle"`
+		MinTime             time.Duration `yaml:"min_time" env-default:"5m"`
+		PermitWithoutStream bool          `yaml:"permit_without_stream"`
 	}
 
 	Log struct {
@@ -54,9 +93,69 @@ type (
 	}
 
 	Service struct {
-		Host                  string `yaml:"host"`
-		Port                  int    `yaml:"port"`
-		RecalculationInterval int    `yaml:"interval"`
+		Host                  string   `yaml:"host"`
+		Port                  int      `yaml:"port"`
+		BaseURL               string   `yaml:"base_url" env:"PUBLIC_BASE_URL"`
+		Domains               []string `yaml:"domains"`
+		RecalculationInterval int      `yaml:"interval"`
+		EventHistory          int      `yaml:"event_history" env-default:"1024"`
+	}
+
+	Validation struct {
+		Schemes        []string      `yaml:"schemes"`
+		BlockPrivate   bool          `yaml:"block_private"`
+		ResolveHosts   bool          `yaml:"resolve_hosts"`
+		DenyList       string        `yaml:"deny_list"`
+		ReloadInterval time.Duration `yaml:"reload_interval"`
+	}
+
+	Canonicalization struct {
+		Enabled           bool     `yaml:"enabled"`
+		StripParams       []string `yaml:"strip_params"`
+		TrimTrailingSlash bool     `yaml:"trim_trailing_slash"`
+	}
+
+	Password struct {
+		MaxAttempts int           `yaml:"max_attempts"`
+		Window      time.Duration `yaml:"window"`
+	}
+
+	GeoIP struct {
+		Database string `yaml:"database" env:"GEOIP_DATABASE"`
+	}
+
+	QRCode struct {
+		CacheSize int `yaml:"cache_size"`
+	}
+
+	UTM struct {
+		Presets map[string]UTMPreset `yaml:"presets"`
+	}
+
+	UTMPreset struct {
+		Source   string `yaml:"source"`
+		Medium   string `yaml:"medium"`
+		Campaign string `yaml:"campaign"`
+		Term     string `yaml:"term"`
+		Content  string `yaml:"content"`
+	}
+
+	Unfurl struct {
+		Enabled     bool          `yaml:"enabled"`
+		Workers     int           `yaml:"workers"`
+		QueueSize   int           `yaml:"queue_size"`
+		Timeout     time.Duration `yaml:"timeout"`
+		MaxBodySize int64         `yaml:"max_body_size"`
+	}
+
+	Webhooks struct {
+		Enabled      bool          `yaml:"enabled"`
+		MaxAttempts  int           `yaml:"max_attempts" env-default:"8"`
+		Backoff      time.Duration `yaml:"backoff" env-default:"30s"`
+		MaxBackoff   time.Duration `yaml:"max_backoff" env-default:"6h"`
+		Timeout      time.Duration `yaml:"timeout" env-default:"10s"`
+		PollInterval time.Duration `yaml:"poll_interval" env-default:"5s"`
+		BatchSize    int           `yaml:"batch_size" env-default:"32"`
 	}
 
 	LinkGen struct {
@@ -3,9 +3,12 @@ package app
 import (
 	"context"
 	"crypto"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,6 +16,8 @@ import (
 
 	linkGrpcHandler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc"
 	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated/generatedconnect"
+	linkv1 "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated/link/v1"
 	linkHandler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/handler"
 	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/middleware"
 	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
@@ -21,20 +26,139 @@ import (
 	linkUsecase "github.com/CodeMaster482/ShortLinkAPI/internal/usecase"
 	"github.com/go-redis/redis/v8"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 
 	"github.com/CodeMaster482/ShortLinkAPI/config"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/eventbus"
 	"github.com/CodeMaster482/ShortLinkAPI/pkg/generator"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/geoip"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/grpcserver"
 	"github.com/CodeMaster482/ShortLinkAPI/pkg/httpserver"
 	"github.com/CodeMaster482/ShortLinkAPI/pkg/logger"
 	"github.com/CodeMaster482/ShortLinkAPI/pkg/postgres"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/qr"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/throttle"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/tlsconfig"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/unfurl"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/urlcanon"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/urlcheck"
 
+	"connectrpc.com/connect"
 	"github.com/gin-gonic/gin"
 )
 
 type LinkRepository interface {
-	GetLink(ctx context.Context, token string) (*model.Link, error)
+	GetLink(ctx context.Context, domain, token string) (*model.Link, error)
 	StoreLink(ctx context.Context, link *model.Link) error
-	StartRecalculation(interval time.Duration, deleted chan []string)
+	ConsumeClick(ctx context.Context, domain, token string) (int64, error)
+	ClicksLeft(ctx context.Context, domain, token string) (int64, error)
+	CountClick(ctx context.Context, domain, token string) (int64, error)
+	RecordVariant(ctx context.Context, domain, token string, variant int) error
+	VariantHits(ctx context.Context, domain, token string) (map[int]int64, error)
+	UpdateMetadata(ctx context.Context, link *model.Link) error
+	DeleteLink(ctx context.Context, domain, token string) error
+	StorePage(ctx context.Context, domain, token string, page model.Page) error
+	ListLinksByTag(ctx context.Context, tag string, limit int) ([]*model.Link, error)
+	StartRecalculation(interval time.Duration, deleted chan []*model.Link)
+}
+
+// publicHosts returns the hosts short links are handed out under,
+// checking the configured public and branded base URLs on the way.
+func publicHosts(cfg *config.Config) ([]string, error) {
+	hosts := make([]string, 0, len(cfg.Service.Domains)+1)
+
+	if cfg.Service.BaseURL == "" {
+		hosts = append(hosts, cfg.Service.Host)
+	}
+
+	baseURLs := cfg.Service.Domains
+	if cfg.Service.BaseURL != "" {
+		baseURLs = append([]string{cfg.Service.BaseURL}, baseURLs...)
+	}
+
+	for _, baseURL := range baseURLs {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, err
+		}
+
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+			return nil, fmt.Errorf("base url %q must be an http(s) URL with a host and no query", baseURL)
+		}
+
+		hosts = append(hosts, u.Hostname())
+	}
+
+	return hosts, nil
+}
+
+func newURLChecker(cfg *config.Config, l logger.Interface) (*urlcheck.Pipeline, func(), error) {
+	hosts, err := publicHosts(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	checker := urlcheck.NewPipeline(
+		urlcheck.Schemes(cfg.Validation.Schemes...),
+		urlcheck.SelfDomain(hosts...),
+	)
+
+	if cfg.Validation.BlockPrivate {
+		var resolver urlcheck.Resolver
+		if cfg.Validation.ResolveHosts {
+			resolver = net.DefaultResolver
+		}
+
+		checker.Use(urlcheck.PrivateNetwork(resolver))
+	}
+
+	if cfg.Validation.DenyList == "" {
+		return checker, func() {}, nil
+	}
+
+	denyList, err := urlcheck.NewDenyList(cfg.Validation.DenyList)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.Validation.ReloadInterval > 0 {
+		denyList.Watch(cfg.Validation.ReloadInterval, func(err error) {
+			l.Error(fmt.Errorf("app - Run - denyList.Watch: %w", err))
+		})
+	}
+
+	checker.Use(denyList)
+
+	return checker, denyList.Close, nil
+}
+
+// newTLSConfig loads the certificate of a listener. Listeners without
+// a certificate configured serve plaintext and get a nil config.
+func newTLSConfig(cfg config.TLS, auth tlsconfig.ClientAuth, l logger.Interface) (*tls.Config, func(), error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, func() {}, nil
+	}
+
+	cert, err := tlsconfig.NewCertificate(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var clientCAs *x509.CertPool
+	if cfg.ClientCA != "" {
+		clientCAs, err = tlsconfig.LoadCertPool(cfg.ClientCA)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if cfg.ReloadInterval > 0 {
+		cert.Watch(cfg.ReloadInterval, func(err error) {
+			l.Error(fmt.Errorf("app - Run - certificate.Watch: %w", err))
+		})
+	}
+
+	return tlsconfig.Server(cert, clientCAs, auth), cert.Close, nil
 }
 
 func addPingRoutes(rg *gin.RouterGroup) {
@@ -56,7 +180,10 @@ func Run(cfg *config.Config) {
 	l := logger.New(cfg.Log.Level)
 
 	// Repository
-	var lr LinkRepository
+	var (
+		lr LinkRepository
+		wr linkUsecase.WebhookRepository
+	)
 
 	if cfg.UseRedis {
 		cli := redis.NewClient(&redis.Options{
@@ -69,6 +196,7 @@ func Run(cfg *config.Config) {
 		}
 
 		lr = linkRedisRepo.NewLinkStorage(cli)
+		wr = linkRedisRepo.NewWebhookStorage(cli)
 	} else {
 		pg, err := postgres.New(
 			cfg.PG.Host,
@@ -84,6 +212,7 @@ func Run(cfg *config.Config) {
 		defer pg.Close()
 
 		lr = linkSQLRepo.NewLinkStorage(pg.Pool)
+		wr = linkSQLRepo.NewWebhookStorage(pg.Pool)
 	}
 
 	g := generator.NewGenerator(
@@ -92,45 +221,294 @@ func Run(cfg *config.Config) {
 		generator.WithLength(cfg.LinkGen.Length),
 	)
 
+	checker, closeChecker, err := newURLChecker(cfg, l)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newURLChecker: %w", err))
+	}
+	defer closeChecker()
+
+	var canonicalizer linkUsecase.Canonicalizer
+	if cfg.Canonicalization.Enabled {
+		canonicalizer = urlcanon.New(
+			urlcanon.StripParams(cfg.Canonicalization.StripParams...),
+			urlcanon.TrimTrailingSlash(cfg.Canonicalization.TrimTrailingSlash),
+		)
+	}
+
+	var geo linkUsecase.GeoLocator
+	if cfg.GeoIP.Database != "" {
+		locator, err := geoip.Open(cfg.GeoIP.Database)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - geoip.Open: %w", err))
+		}
+		defer locator.Close()
+
+		geo = locator
+	}
+
+	var pages linkUsecase.PageQueue
+	if cfg.Unfurl.Enabled {
+		fetcher := unfurl.New(
+			unfurl.Timeout(cfg.Unfurl.Timeout),
+			unfurl.MaxBodySize(cfg.Unfurl.MaxBodySize),
+		)
+
+		pool := linkUsecase.NewPagePool(fetcher, lr, cfg.Unfurl.Workers, cfg.Unfurl.QueueSize, cfg.Unfurl.Timeout)
+		defer pool.Close()
+
+		pages = pool
+	}
+
+	var (
+		webhooks *linkUsecase.WebhookService
+		notifier linkUsecase.WebhookNotifier
+	)
+
+	if cfg.Webhooks.Enabled {
+		webhooks = linkUsecase.NewWebhookService(wr,
+			linkUsecase.WebhookURLChecker(checker),
+			linkUsecase.WebhookAttempts(cfg.Webhooks.MaxAttempts),
+			linkUsecase.WebhookBackoff(cfg.Webhooks.Backoff, cfg.Webhooks.MaxBackoff),
+			linkUsecase.WebhookTimeout(cfg.Webhooks.Timeout),
+			linkUsecase.WebhookPollInterval(cfg.Webhooks.PollInterval),
+			linkUsecase.WebhookBatchSize(cfg.Webhooks.BatchSize),
+		)
+		webhooks.Start()
+		defer webhooks.Close()
+
+		notifier = webhooks
+	}
+
 	// Use case
-	lu := linkUsecase.NewLinkService(cfg, lr, g)
+	lu := linkUsecase.NewLinkService(cfg, lr, g,
+		linkUsecase.WithURLChecker(checker),
+		linkUsecase.WithCanonicalizer(canonicalizer),
+		linkUsecase.WithAttemptThrottle(throttle.New(
+			throttle.MaxFailures(cfg.Password.MaxAttempts),
+			throttle.Window(cfg.Password.Window),
+		)),
+		linkUsecase.WithGeoLocator(geo),
+		linkUsecase.WithQRCache(qr.NewCache(cfg.QRCode.CacheSize)),
+		linkUsecase.WithPageQueue(pages),
+		linkUsecase.WithEventBus(eventbus.New[*model.LinkEvent](
+			eventbus.History(cfg.Service.EventHistory),
+		)),
+		linkUsecase.WithWebhooks(notifier),
+	)
 	lh := linkHandler.NewLinkHandler(lu)
 
+	httpTLS, closeHTTPCert, err := newTLSConfig(cfg.HTTP.TLS, tlsconfig.VerifyClientCertIfGiven, l)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newTLSConfig http: %w", err))
+	}
+	defer closeHTTPCert()
+
+	grpcTLS, closeGRPCCert, err := newTLSConfig(cfg.GRPC.TLS, tlsconfig.RequireClientCert, l)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newTLSConfig grpc: %w", err))
+	}
+	defer closeGRPCCert()
+
 	// HTTP Server
 	r := gin.New()
+
+	// Redirect rules and split links go by the client IP, so forwarded
+	// headers are only believed when a configured proxy sent them.
+	if err := r.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		l.Fatal(fmt.Errorf("app - Run - SetTrustedProxies: %w", err))
+	}
+
+	r.Use(middleware.RequestID())
 	base := r.Group("/")
 	addPingRoutes(base)
+
+	// Short links resolve at the root; token+ previews the destination instead.
+	redirects := r.Group("/")
+	redirects.Use(middleware.ErrorMiddleware())
+	redirects.Use(middleware.RequestTimeout(500 * time.Millisecond))
+	redirects.Use(gin.Logger(), gin.Recovery())
+
+	redirects.GET("/:key", lh.GetLink)
+	redirects.GET("/:key/*path", lh.GetLink)
+	redirects.POST("/:key", lh.UnlockLink)
+	redirects.POST("/:key/*path", lh.UnlockLink)
+
 	api := r.Group("/api/v1")
 
 	api.Use(middleware.ErrorMiddleware())
 	api.Use(middleware.RequestTimeout(500 * time.Millisecond))
 	api.Use(gin.Logger(), gin.Recovery())
 
-	api.POST("/url", lh.CreateLink)
+	// Managing links may be limited to clients with a certificate.
+	manage := api.Group("")
+	if httpTLS != nil && cfg.HTTP.TLS.ClientCA != "" {
+		manage.Use(middleware.RequireClientCert())
+	}
+
+	manage.POST("/url", lh.CreateLink)
+	manage.GET("/url", lh.ListLinks)
+	manage.PATCH("/url/:key", lh.UpdateLink)
+	manage.DELETE("/url/:key", lh.DeleteLink)
+
+	if webhooks != nil {
+		wh := linkHandler.NewWebhookHandler(webhooks)
+
+		manage.POST("/webhooks", wh.CreateWebhook)
+		manage.GET("/webhooks", wh.ListWebhooks)
+		manage.DELETE("/webhooks/:id", wh.DeleteWebhook)
+		manage.GET("/webhooks/:id/dead-letters", wh.ListDeadDeliveries)
+	}
+
 	api.GET("/url/:key", lh.GetLink)
+	api.GET("/url/:key/*path", lh.GetLinkOrQRCode)
+	api.POST("/url/:key", lh.UnlockLink)
+	api.POST("/url/:key/*path", lh.UnlockLink)
+
+	// Event streams stay open, so they are not subject to the request timeout.
+	events := r.Group("/api/v1")
+
+	events.Use(middleware.ErrorMiddleware())
+	events.Use(gin.Logger(), gin.Recovery())
+
+	if httpTLS != nil && cfg.HTTP.TLS.ClientCA != "" {
+		events.Use(middleware.RequireClientCert())
+	}
+
+	events.GET("/events", lh.WatchLinks)
+
+	// The v2 JSON API is generated from the HTTP bindings in link/v1/link.proto
+	// and shares its handler with the gRPC server; /api/v1 stays for existing
+	// clients.
+	grpcV1Handler := linkGrpcHandler.NewLinkV1Handler(lu)
+
+	gatewayMux, err := linkGrpcHandler.NewGateway(context.Background(), grpcV1Handler, l)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - NewGateway: %w", err))
+	}
+
+	gateway := linkHandler.Gateway(gatewayMux)
+
+	apiV2 := r.Group("/api/v2")
 
-	httpServer := httpserver.New(
-		r,
+	apiV2.Use(middleware.ErrorMiddleware())
+	apiV2.Use(middleware.RequestTimeout(500 * time.Millisecond))
+	apiV2.Use(gin.Logger(), gin.Recovery())
+
+	manageV2 := apiV2.Group("")
+	if httpTLS != nil && cfg.HTTP.TLS.ClientCA != "" {
+		manageV2.Use(middleware.RequireClientCert())
+	}
+
+	manageV2.POST("/links", gateway)
+	manageV2.GET("/links", gateway)
+	manageV2.PATCH("/links/:token", gateway)
+	manageV2.DELETE("/links/:token", gateway)
+	// Links come with their notes, tags and click budget, which only their owner may see.
+	manageV2.GET("/links/:token", gateway)
+	apiV2.GET("/links/:token/qr", gateway)
+	apiV2.POST("/links/:token", gateway) // :resolve
+
+	// Browser clients reach ShortLinkService over Connect or gRPC-Web on the
+	// HTTP port.
+	connectPath, connectHandler := generatedconnect.NewShortLinkServiceHandler(
+		linkGrpcHandler.NewLinkConnectHandler(lu),
+		connect.WithInterceptors(linkGrpcHandler.ConnectErrorInterceptor(l)),
+	)
+	connectService := linkHandler.Gateway(connectHandler)
+
+	web := r.Group("/")
+	if len(cfg.HTTP.CORS.AllowOrigins) > 0 {
+		corsMiddleware, err := middleware.CORS(cfg.HTTP.CORS.AllowOrigins, cfg.HTTP.CORS.MaxAge)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - CORS: %w", err))
+		}
+
+		web.Use(corsMiddleware)
+	}
+
+	web.Use(middleware.RequestTimeout(500 * time.Millisecond))
+	web.Use(gin.Logger(), gin.Recovery())
+
+	// Preflight requests are answered by the CORS middleware.
+	web.OPTIONS(connectPath+":procedure", func(*gin.Context) {})
+
+	manageWeb := web.Group("")
+	if httpTLS != nil && cfg.HTTP.TLS.ClientCA != "" {
+		manageWeb.Use(middleware.RequireClientCert())
+	}
+
+	manageWeb.POST(generatedconnect.ShortLinkServiceCreateShortLinkProcedure, connectService)
+	manageWeb.POST(generatedconnect.ShortLinkServiceListLinksProcedure, connectService)
+	manageWeb.POST(generatedconnect.ShortLinkServiceUpdateLinkProcedure, connectService)
+	web.POST(generatedconnect.ShortLinkServiceGetFullLinkProcedure, connectService)
+	web.POST(generatedconnect.ShortLinkServiceGetQRCodeProcedure, connectService)
+
+	httpOptions := []httpserver.Option{
 		httpserver.Port(cfg.HTTP.Port),
 		httpserver.ReadTimeout(cfg.HTTP.ReadTimeout),
 		httpserver.WriteTimeout(cfg.HTTP.WriteTimeout),
-	)
+	}
+
+	if httpTLS != nil {
+		httpOptions = append(httpOptions, httpserver.TLS(httpTLS))
+	}
+
+	httpServer := httpserver.New(r, httpOptions...)
+
+	var redirectNotify <-chan error
+
+	if httpTLS != nil && cfg.HTTP.RedirectPort != "" {
+		redirectServer := httpserver.New(
+			httpserver.RedirectHTTPS(cfg.HTTP.Port),
+			httpserver.Port(cfg.HTTP.RedirectPort),
+		)
+		defer redirectServer.Shutdown() //nolint:errcheck // the process is exiting
+
+		redirectNotify = redirectServer.Notify()
+	}
 
 	grpcHandler := linkGrpcHandler.NewLinkHandler(lu)
-	grpcServer := grpc.NewServer()
-	generated.RegisterShortLinkServiceServer(grpcServer, grpcHandler)
 
-	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPC.Port))
-	if err != nil {
-		l.Fatal(err)
+	grpcOptions := []grpcserver.Option{
+		grpcserver.Address(cfg.GRPC.Host, cfg.GRPC.Port),
+		grpcserver.MaxRecvMsgSize(cfg.GRPC.MaxRecvMsgSize),
+		grpcserver.MaxSendMsgSize(cfg.GRPC.MaxSendMsgSize),
+		grpcserver.Keepalive(
+			keepalive.ServerParameters{
+				Time:              cfg.GRPC.Keepalive.Time,
+				Timeout:           cfg.GRPC.Keepalive.Timeout,
+				MaxConnectionIdle: cfg.GRPC.Keepalive.MaxConnectionIdle,
+			},
+			keepalive.EnforcementPolicy{
+				MinTime:             cfg.GRPC.Keepalive.MinTime,
+				PermitWithoutStream: cfg.GRPC.Keepalive.PermitWithoutStream,
+			},
+		),
+		grpcserver.UnaryInterceptors(
+			grpcserver.UnaryRecovery(l),
+			grpcserver.UnaryLogger(l),
+			linkGrpcHandler.ErrorInterceptor(l),
+			grpcserver.UnaryTimeout(cfg.GRPC.Timeout),
+		),
+		grpcserver.StreamInterceptors(
+			grpcserver.StreamRecovery(l),
+			grpcserver.StreamLogger(l),
+			linkGrpcHandler.StreamErrorInterceptor(l),
+		),
+		grpcserver.Reflection(cfg.GRPC.Reflection),
 	}
-	defer grpcListener.Close()
 
-	go func() {
-		if err := grpcServer.Serve(grpcListener); err != nil {
-			l.Fatal(err)
-		}
-	}()
+	if grpcTLS != nil {
+		grpcOptions = append(grpcOptions, grpcserver.TLS(grpcTLS))
+	}
+
+	grpcServer := grpcserver.New(
+		func(s grpc.ServiceRegistrar) {
+			generated.RegisterShortLinkServiceServer(s, grpcHandler)
+			linkv1.RegisterLinkServiceServer(s, grpcV1Handler)
+		},
+		grpcOptions...,
+	)
 
 	// Waiting signal
 	interrupt := make(chan os.Signal, 1)
@@ -141,9 +519,16 @@ func Run(cfg *config.Config) {
 		l.Info("app - Run - signal: " + s.String())
 	case err := <-httpServer.Notify():
 		l.Error(fmt.Errorf("app - Run - httpServer.Notify: %w", err))
+	case err := <-grpcServer.Notify():
+		l.Error(fmt.Errorf("app - Run - grpcServer.Notify: %w", err))
+	case err := <-redirectNotify:
+		l.Error(fmt.Errorf("app - Run - redirectServer.Notify: %w", err))
 	}
 
-	grpcServer.GracefulStop()
+	err = grpcServer.Shutdown()
+	if err != nil {
+		l.Error(fmt.Errorf("app - Run - grpcServer.Shutdown: %w", err))
+	}
 
 	// Shutdown
 	err = httpServer.Shutdown()
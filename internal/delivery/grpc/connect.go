@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/logger"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/metadata"
+)
+
+// LinkConnectHandler serves link.ShortLinkService over the Connect, gRPC-Web
+// and gRPC protocols on the HTTP server, for browser clients that cannot
+// reach the gRPC port. Calls are handled by LinkGrpcHandler.
+type LinkConnectHandler struct {
+	server *LinkGrpcHandler
+}
+
+func NewLinkConnectHandler(usecase LinkUsecase) *LinkConnectHandler {
+	return &LinkConnectHandler{
+		server: NewLinkHandler(usecase),
+	}
+}
+
+func (h *LinkConnectHandler) GetFullLink(ctx context.Context, request *connect.Request[generated.ShortLinkRequest]) (*connect.Response[generated.ShortLinkResponse], error) {
+	return connectResponse(h.server.GetFullLink(connectContext(ctx, request), request.Msg))
+}
+
+func (h *LinkConnectHandler) CreateShortLink(ctx context.Context, request *connect.Request[generated.CreateShortLinkRequest]) (*connect.Response[generated.CreateShortLinkResponse], error) {
+	return connectResponse(h.server.CreateShortLink(connectContext(ctx, request), request.Msg))
+}
+
+func (h *LinkConnectHandler) GetQRCode(ctx context.Context, request *connect.Request[generated.QRCodeRequest]) (*connect.Response[generated.QRCodeResponse], error) {
+	return connectResponse(h.server.GetQRCode(connectContext(ctx, request), request.Msg))
+}
+
+func (h *LinkConnectHandler) UpdateLink(ctx context.Context, request *connect.Request[generated.UpdateLinkRequest]) (*connect.Response[generated.LinkInfo], error) {
+	return connectResponse(h.server.UpdateLink(connectContext(ctx, request), request.Msg))
+}
+
+func (h *LinkConnectHandler) ListLinks(ctx context.Context, request *connect.Request[generated.ListLinksRequest]) (*connect.Response[generated.ListLinksResponse], error) {
+	return connectResponse(h.server.ListLinks(connectContext(ctx, request), request.Msg))
+}
+
+// ConnectErrorInterceptor is ErrorInterceptor for LinkConnectHandler. Errors
+// carry the same codes and details as on the gRPC server.
+func ConnectErrorInterceptor(l logger.Interface) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, request connect.AnyRequest) (connect.AnyResponse, error) {
+			response, err := next(ctx, request)
+			if err == nil {
+				return response, nil
+			}
+
+			st := Status(statusErr(l, request.Spec().Procedure, err))
+
+			connectErr := connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+			for _, detail := range st.Proto().Details {
+				if errDetail, err := connect.NewErrorDetail(detail); err == nil {
+					connectErr.AddDetail(errDetail)
+				}
+			}
+
+			return response, connectErr
+		}
+	}
+}
+
+// connectContext passes the User-Agent of request on the way gRPC clients
+// send it, so withCaller finds it.
+func connectContext(ctx context.Context, request connect.AnyRequest) context.Context {
+	if ua := request.Header().Get("User-Agent"); ua != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("user-agent", ua))
+	}
+
+	return ctx
+}
+
+func connectResponse[T any](message *T, err error) (*connect.Response[T], error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(message), nil
+}
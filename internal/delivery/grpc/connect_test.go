@@ -0,0 +1,125 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated/generatedconnect"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	mock_handler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/handler/mocks"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/logger"
+
+	"connectrpc.com/connect"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func newConnectClient(t *testing.T, usecase grpc.LinkUsecase, opts ...connect.ClientOption) generatedconnect.ShortLinkServiceClient {
+	t.Helper()
+
+	path, handler := generatedconnect.NewShortLinkServiceHandler(
+		grpc.NewLinkConnectHandler(usecase),
+		connect.WithInterceptors(grpc.ConnectErrorInterceptor(logger.New("error"))),
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return generatedconnect.NewShortLinkServiceClient(server.Client(), server.URL, opts...)
+}
+
+func TestConnect_CreateShortLink(t *testing.T) {
+	t.Parallel()
+
+	protocols := map[string][]connect.ClientOption{
+		"Connect":  nil,
+		"gRPC-Web": {connect.WithGRPCWeb()},
+	}
+
+	for name, opts := range protocols {
+		opts := opts
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecase := mock_handler.NewMockLinkUsecase(ctrl)
+			client := newConnectClient(t, usecase, opts...)
+
+			usecase.EXPECT().
+				CreateShortLink(gomock.Any(), &dto.CreateLinkRequest{Link: "https://example.com"}).
+				Return(&model.Link{ShortLink: "https://sho.rt/abc123"}, nil)
+
+			response, err := client.CreateShortLink(context.Background(), connect.NewRequest(&generated.CreateShortLinkRequest{
+				OriginalLink: "https://example.com",
+			}))
+			require.NoError(t, err)
+			require.Equal(t, "https://sho.rt/abc123", response.Msg.ShortLink)
+
+			_, err = client.CreateShortLink(context.Background(), connect.NewRequest(&generated.CreateShortLinkRequest{}))
+			require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+			var connectErr *connect.Error
+			require.True(t, errors.As(err, &connectErr))
+			require.Equal(t, "bad request", connectErr.Message())
+
+			var fields []string
+			for _, detail := range connectErr.Details() {
+				value, err := detail.Value()
+				require.NoError(t, err)
+
+				if badRequest, ok := value.(*errdetails.BadRequest); ok {
+					for _, violation := range badRequest.FieldViolations {
+						fields = append(fields, violation.Field)
+					}
+				}
+			}
+			require.Equal(t, []string{"originalLink"}, fields)
+		})
+	}
+}
+
+func TestConnect_GetFullLink(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mock_handler.NewMockLinkUsecase(ctrl)
+	client := newConnectClient(t, usecase, connect.WithGRPCWeb())
+
+	const userAgent = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)"
+
+	usecase.EXPECT().
+		GetFullLink(gomock.Any(), "", "abc123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, visit *model.Visit) (*model.Redirect, error) {
+			require.Equal(t, userAgent, visit.UserAgent)
+
+			return &model.Redirect{Target: "https://example.com", Code: http.StatusFound}, nil
+		})
+	usecase.EXPECT().
+		GetFullLink(gomock.Any(), "", "missing", gomock.Any()).
+		Return(nil, apierror.NotFoundError())
+
+	request := connect.NewRequest(&generated.ShortLinkRequest{ShortLink: "abc123"})
+	request.Header().Set("User-Agent", userAgent)
+
+	response, err := client.GetFullLink(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com", response.Msg.OriginalLink)
+
+	_, err = client.GetFullLink(context.Background(), connect.NewRequest(&generated.ShortLinkRequest{ShortLink: "missing"}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
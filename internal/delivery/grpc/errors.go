@@ -0,0 +1,97 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/logger"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain names the service in the ErrorInfo details of its errors.
+const ErrorDomain = "shortlink"
+
+// ErrorInterceptor turns the errors returned by handlers into gRPC statuses.
+// Clients only see the public message of an error; internal causes are logged.
+func ErrorInterceptor(l logger.Interface) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		return resp, statusErr(l, info.FullMethod, err)
+	}
+}
+
+// StreamErrorInterceptor is ErrorInterceptor for streaming calls.
+func StreamErrorInterceptor(l logger.Interface) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err == nil {
+			return nil
+		}
+
+		return statusErr(l, info.FullMethod, err)
+	}
+}
+
+func statusErr(l logger.Interface, method string, err error) error {
+	st := Status(err)
+	if st.Code() == codes.Internal {
+		l.Error(fmt.Errorf("grpc - %s: %w", method, err))
+	}
+
+	return st.Err()
+}
+
+// Status converts err to the gRPC status sent to clients.
+func Status(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, err.Error())
+	}
+
+	sentinel := apierror.Sentinel(err)
+	definition := apierror.Errors[sentinel]
+
+	st := status.New(definition.GRPCCode, definition.Message)
+
+	info := &errdetails.ErrorInfo{Reason: definition.Reason, Domain: ErrorDomain}
+	details := []protoadapt.MessageV1{info}
+
+	// Metadata maps every invalid field to the code of the rule it broke.
+	if fieldErrs := apierror.FieldErrorsOf(err); len(fieldErrs) > 0 {
+		info.Metadata = make(map[string]string, len(fieldErrs))
+		badRequest := &errdetails.BadRequest{}
+
+		for _, fieldErr := range fieldErrs {
+			info.Metadata[fieldErr.Field] = fieldErr.Code
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fieldErr.Field,
+				Description: fieldErr.Err.Error(),
+			})
+		}
+
+		details = append(details, badRequest)
+	}
+
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+
+	return st
+}
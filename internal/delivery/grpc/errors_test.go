@@ -0,0 +1,190 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated"
+	mock_handler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/handler/mocks"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/logger"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		err            error
+		expectedCode   codes.Code
+		expectedMsg    string
+		expectedReason string
+	}{
+		{
+			name:           "Not found",
+			err:            apierror.NotFoundError(),
+			expectedCode:   codes.NotFound,
+			expectedMsg:    "link not found",
+			expectedReason: "LINK_NOT_FOUND",
+		},
+		{
+			name:           "Bare sentinel",
+			err:            apierror.ErrLinkNotFound,
+			expectedCode:   codes.NotFound,
+			expectedMsg:    "link not found",
+			expectedReason: "LINK_NOT_FOUND",
+		},
+		{
+			name:           "Wrapped sentinel",
+			err:            fmt.Errorf("get link: %w", apierror.ErrLinkExhausted),
+			expectedCode:   codes.NotFound,
+			expectedMsg:    "link is no longer available",
+			expectedReason: "LINK_EXHAUSTED",
+		},
+		{
+			name:           "Conflict",
+			err:            apierror.NewAPIError(apierror.ErrUnableToCreateLink, nil),
+			expectedCode:   codes.AlreadyExists,
+			expectedMsg:    "unable to create link",
+			expectedReason: "UNABLE_TO_CREATE_LINK",
+		},
+		{
+			name:           "Password required",
+			err:            apierror.NewAPIError(apierror.ErrPasswordRequired, nil),
+			expectedCode:   codes.Unauthenticated,
+			expectedMsg:    "password required",
+			expectedReason: "PASSWORD_REQUIRED",
+		},
+		{
+			name:           "Throttled",
+			err:            apierror.NewAPIError(apierror.ErrTooManyAttempts, nil),
+			expectedCode:   codes.ResourceExhausted,
+			expectedMsg:    "too many attempts",
+			expectedReason: "TOO_MANY_ATTEMPTS",
+		},
+		{
+			name:           "Internal cause hidden",
+			err:            apierror.InternalError(errors.New("dial tcp 10.0.0.5:5432: connection refused")),
+			expectedCode:   codes.Internal,
+			expectedMsg:    "internal server error",
+			expectedReason: "INTERNAL",
+		},
+		{
+			name:           "Unknown error",
+			err:            fmt.Errorf("pq: relation %q does not exist", "link"),
+			expectedCode:   codes.Internal,
+			expectedMsg:    "internal server error",
+			expectedReason: "INTERNAL",
+		},
+		{
+			name:         "Deadline",
+			err:          fmt.Errorf("query: %w", context.DeadlineExceeded),
+			expectedCode: codes.DeadlineExceeded,
+			expectedMsg:  "query: context deadline exceeded",
+		},
+		{
+			name:         "Status",
+			err:          status.Error(codes.Unavailable, "draining"),
+			expectedCode: codes.Unavailable,
+			expectedMsg:  "draining",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			st := grpc.Status(tc.err)
+			require.Equal(t, tc.expectedCode, st.Code())
+			require.Equal(t, tc.expectedMsg, st.Message())
+
+			if tc.expectedReason == "" {
+				require.Empty(t, st.Details())
+				return
+			}
+
+			require.NotEmpty(t, st.Details())
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			require.Equal(t, tc.expectedReason, info.Reason)
+			require.Equal(t, grpc.ErrorDomain, info.Domain)
+		})
+	}
+}
+
+func TestStatus_FieldViolation(t *testing.T) {
+	t.Parallel()
+
+	st := grpc.Status(apierror.InvalidFieldError("title", errors.New("is longer than 200 characters")))
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Equal(t, "bad request", st.Message())
+	require.Len(t, st.Details(), 2)
+
+	badRequest, ok := st.Details()[1].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.FieldViolations, 1)
+	require.Equal(t, "title", badRequest.FieldViolations[0].Field)
+	require.Equal(t, "is longer than 200 characters", badRequest.FieldViolations[0].Description)
+}
+
+func TestStatus_FieldViolations(t *testing.T) {
+	t.Parallel()
+
+	st := grpc.Status(apierror.InvalidFieldsError(apierror.FieldErrors{
+		{Field: "original_url", Code: "required", Err: errors.New("is required")},
+		{Field: "max_clicks", Code: "too_small", Err: errors.New("must be at least 0")},
+	}))
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 2)
+
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	require.Equal(t, map[string]string{"original_url": "required", "max_clicks": "too_small"}, info.Metadata)
+
+	badRequest, ok := st.Details()[1].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.FieldViolations, 2)
+	require.Equal(t, "max_clicks", badRequest.FieldViolations[1].Field)
+	require.Equal(t, "must be at least 0", badRequest.FieldViolations[1].Description)
+}
+
+func TestErrorInterceptor(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := grpc.NewLinkHandler(mock_handler.NewMockLinkUsecase(ctrl))
+	interceptor := grpc.ErrorInterceptor(logger.New("error"))
+	info := &grpclib.UnaryServerInfo{FullMethod: "/ShortLinkService/GetFullLink"}
+
+	_, err := interceptor(context.Background(), &generated.ShortLinkRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return handler.GetFullLink(ctx, req.(*generated.ShortLinkRequest))
+	})
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.NotContains(t, err.Error(), "[error]")
+
+	badRequest, ok := st.Details()[1].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Equal(t, "shortLink", badRequest.FieldViolations[0].Field)
+
+	resp, err := interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp)
+}
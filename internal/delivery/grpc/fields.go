@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"strings"
+
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+)
+
+// v1Fields names the fields of dto requests that link.v1 messages call otherwise.
+var v1Fields = map[string]string{
+	"link":              "original_url",
+	"fallback_link":     "fallback_url",
+	"rules[].target":    "target_url",
+	"variants[].target": "target_url",
+}
+
+// qrFields names the fields of dto.QRCodeRequest that QR code messages call otherwise.
+var qrFields = map[string]string{
+	"fg": "foreground",
+	"bg": "background",
+}
+
+// renameFields reports the invalid fields of a validated dto request by the
+// names rename gives them in the messages of an API.
+func renameFields(err error, rename func(field string) string) error {
+	for _, fieldErr := range apierror.FieldErrorsOf(err) {
+		fieldErr.Field = rename(fieldErr.Field)
+	}
+
+	return err
+}
+
+// v1Field names a dto field as link.v1 messages do.
+func v1Field(field string) string {
+	segments := strings.Split(field, ".")
+	path := ""
+
+	for i, segment := range segments {
+		name, index, indexed := strings.Cut(segment, "[")
+		path += name
+		if indexed {
+			path += "[]"
+		}
+
+		if renamed, ok := v1Fields[path]; ok {
+			name = renamed
+		}
+
+		if indexed {
+			name += "[" + index
+		}
+
+		segments[i] = name
+		path += "."
+	}
+
+	return strings.Join(segments, ".")
+}
+
+// legacyField names a dto field as the messages of ShortLinkService do.
+func legacyField(field string) string {
+	if field == "link" {
+		return "originalLink"
+	}
+
+	segments := strings.Split(field, ".")
+	for i, segment := range segments {
+		words := strings.Split(segment, "_")
+		for j := 1; j < len(words); j++ {
+			if words[j] != "" {
+				words[j] = strings.ToUpper(words[j][:1]) + words[j][1:]
+			}
+		}
+
+		segments[i] = strings.Join(words, "")
+	}
+
+	return strings.Join(segments, ".")
+}
+
+// qrField names a field of dto.QRCodeRequest as QR code messages do.
+func qrField(field string) string {
+	if renamed, ok := qrFields[field]; ok {
+		return renamed
+	}
+
+	return field
+}
@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+
+	linkv1 "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated/link/v1"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/logger"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// NewGateway returns the JSON API generated from the HTTP bindings of
+// link.v1.LinkService, served in-process by server. Errors are converted
+// by Status, so HTTP clients get the same codes and details as gRPC ones.
+func NewGateway(ctx context.Context, server linkv1.LinkServiceServer, l logger.Interface) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{UseProtoNames: true},
+		}),
+		runtime.WithIncomingHeaderMatcher(gatewayHeader),
+		runtime.WithErrorHandler(gatewayError(l)),
+	)
+
+	if err := linkv1.RegisterLinkServiceHandlerServer(ctx, mux, server); err != nil {
+		return nil, fmt.Errorf("grpc - NewGateway - RegisterLinkServiceHandlerServer: %w", err)
+	}
+
+	return mux, nil
+}
+
+// gatewayHeader passes the User-Agent on under the key gRPC clients send
+// it with, so redirect rules match HTTP and gRPC callers alike.
+func gatewayHeader(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == "User-Agent" {
+		return "user-agent", true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func gatewayError(l logger.Interface) runtime.ErrorHandlerFunc {
+	return func(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+		var httpErr *runtime.HTTPStatusError
+		if !errors.As(err, &httpErr) {
+			method, _ := runtime.RPCMethod(ctx)
+			err = statusErr(l, method, err)
+		}
+
+		runtime.DefaultHTTPErrorHandler(ctx, mux, m, w, r, err)
+	}
+}
@@ -0,0 +1,163 @@
+package grpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	mock_handler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/handler/mocks"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/logger"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGateway(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		userAgent      string
+		expectedStatus int
+		expectedBody   []string
+		mockBehaviour  func(usecase *mock_handler.MockLinkUsecase)
+	}{
+		{
+			name:           "Create",
+			method:         http.MethodPost,
+			target:         "/api/v2/links",
+			body:           `{"original_url":"https://example.com/docs","tags":["docs"]}`,
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				`"short_url":"https://sho.rt/abc123"`,
+				`"create_time":"2024-03-01T12:00:00Z"`,
+			},
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().
+					CreateShortLink(gomock.Any(), &dto.CreateLinkRequest{Link: "https://example.com/docs", Tags: []string{"docs"}}).
+					Return(&model.Link{Token: "abc123", ShortLink: "https://sho.rt/abc123", CreatedAt: created}, nil)
+			},
+		},
+		{
+			name:           "Create without URL",
+			method:         http.MethodPost,
+			target:         "/api/v2/links",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"message":"bad request"`, `"field":"original_url"`},
+			mockBehaviour:  func(usecase *mock_handler.MockLinkUsecase) {},
+		},
+		{
+			name:           "Unknown field",
+			method:         http.MethodPost,
+			target:         "/api/v2/links",
+			body:           `{"link":"https://example.com"}`,
+			expectedStatus: http.StatusBadRequest,
+			mockBehaviour:  func(usecase *mock_handler.MockLinkUsecase) {},
+		},
+		{
+			name:           "Get",
+			method:         http.MethodGet,
+			target:         "/api/v2/links/abc123?domain=go.example.com",
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`"domain":"go.example.com"`},
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().
+					PreviewLink(gomock.Any(), "go.example.com", "abc123").
+					Return(&model.Link{Token: "abc123", Domain: "go.example.com"}, nil)
+			},
+		},
+		{
+			name:           "Not found",
+			method:         http.MethodGet,
+			target:         "/api/v2/links/abc123",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   []string{`"message":"link not found"`, `"reason":"LINK_NOT_FOUND"`},
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().PreviewLink(gomock.Any(), "", "abc123").Return(nil, apierror.NotFoundError())
+			},
+		},
+		{
+			name:           "Resolve",
+			method:         http.MethodPost,
+			target:         "/api/v2/links/abc123:resolve",
+			body:           `{"visit":{"query":{"ref":"docs"}}}`,
+			userAgent:      "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)",
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{`"target_url":"https://example.com/docs"`, `"redirect_code":302`},
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().
+					GetFullLink(gomock.Any(), "", "abc123", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, visit *model.Visit) (*model.Redirect, error) {
+						require.Equal(t, "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)", visit.UserAgent)
+						require.Equal(t, "docs", visit.Query.Get("ref"))
+
+						return &model.Redirect{Target: "https://example.com/docs", Code: http.StatusFound}, nil
+					})
+			},
+		},
+		{
+			name:           "List without tag",
+			method:         http.MethodGet,
+			target:         "/api/v2/links",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   []string{`"field":"tag"`},
+			mockBehaviour:  func(usecase *mock_handler.MockLinkUsecase) {},
+		},
+		{
+			name:           "Internal",
+			method:         http.MethodGet,
+			target:         "/api/v2/links?tag=docs",
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   []string{`"message":"internal server error"`},
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().ListLinks(gomock.Any(), "docs").Return(nil, errors.New("connection reset"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			usecase := mock_handler.NewMockLinkUsecase(ctrl)
+			tc.mockBehaviour(usecase)
+
+			mux, err := grpc.NewGateway(context.Background(), grpc.NewLinkV1Handler(usecase), logger.New("error"))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			if tc.userAgent != "" {
+				req.Header.Set("User-Agent", tc.userAgent)
+			}
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			require.True(t, json.Valid(w.Body.Bytes()))
+
+			for _, expected := range tc.expectedBody {
+				require.Contains(t, w.Body.String(), expected)
+			}
+		})
+	}
+}
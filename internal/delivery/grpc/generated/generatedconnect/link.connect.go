@@ -0,0 +1,233 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: link.proto
+
+package generatedconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	generated "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// ShortLinkServiceName is the fully-qualified name of the ShortLinkService service.
+	ShortLinkServiceName = "link.ShortLinkService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ShortLinkServiceGetFullLinkProcedure is the fully-qualified name of the ShortLinkService's
+	// GetFullLink RPC.
+	ShortLinkServiceGetFullLinkProcedure = "/link.ShortLinkService/GetFullLink"
+	// ShortLinkServiceCreateShortLinkProcedure is the fully-qualified name of the ShortLinkService's
+	// CreateShortLink RPC.
+	ShortLinkServiceCreateShortLinkProcedure = "/link.ShortLinkService/CreateShortLink"
+	// ShortLinkServiceGetQRCodeProcedure is the fully-qualified name of the ShortLinkService's
+	// GetQRCode RPC.
+	ShortLinkServiceGetQRCodeProcedure = "/link.ShortLinkService/GetQRCode"
+	// ShortLinkServiceUpdateLinkProcedure is the fully-qualified name of the ShortLinkService's
+	// UpdateLink RPC.
+	ShortLinkServiceUpdateLinkProcedure = "/link.ShortLinkService/UpdateLink"
+	// ShortLinkServiceListLinksProcedure is the fully-qualified name of the ShortLinkService's
+	// ListLinks RPC.
+	ShortLinkServiceListLinksProcedure = "/link.ShortLinkService/ListLinks"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	shortLinkServiceServiceDescriptor               = generated.File_link_proto.Services().ByName("ShortLinkService")
+	shortLinkServiceGetFullLinkMethodDescriptor     = shortLinkServiceServiceDescriptor.Methods().ByName("GetFullLink")
+	shortLinkServiceCreateShortLinkMethodDescriptor = shortLinkServiceServiceDescriptor.Methods().ByName("CreateShortLink")
+	shortLinkServiceGetQRCodeMethodDescriptor       = shortLinkServiceServiceDescriptor.Methods().ByName("GetQRCode")
+	shortLinkServiceUpdateLinkMethodDescriptor      = shortLinkServiceServiceDescriptor.Methods().ByName("UpdateLink")
+	shortLinkServiceListLinksMethodDescriptor       = shortLinkServiceServiceDescriptor.Methods().ByName("ListLinks")
+)
+
+// ShortLinkServiceClient is a client for the link.ShortLinkService service.
+type ShortLinkServiceClient interface {
+	GetFullLink(context.Context, *connect.Request[generated.ShortLinkRequest]) (*connect.Response[generated.ShortLinkResponse], error)
+	CreateShortLink(context.Context, *connect.Request[generated.CreateShortLinkRequest]) (*connect.Response[generated.CreateShortLinkResponse], error)
+	GetQRCode(context.Context, *connect.Request[generated.QRCodeRequest]) (*connect.Response[generated.QRCodeResponse], error)
+	UpdateLink(context.Context, *connect.Request[generated.UpdateLinkRequest]) (*connect.Response[generated.LinkInfo], error)
+	ListLinks(context.Context, *connect.Request[generated.ListLinksRequest]) (*connect.Response[generated.ListLinksResponse], error)
+}
+
+// NewShortLinkServiceClient constructs a client for the link.ShortLinkService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewShortLinkServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) ShortLinkServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &shortLinkServiceClient{
+		getFullLink: connect.NewClient[generated.ShortLinkRequest, generated.ShortLinkResponse](
+			httpClient,
+			baseURL+ShortLinkServiceGetFullLinkProcedure,
+			connect.WithSchema(shortLinkServiceGetFullLinkMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createShortLink: connect.NewClient[generated.CreateShortLinkRequest, generated.CreateShortLinkResponse](
+			httpClient,
+			baseURL+ShortLinkServiceCreateShortLinkProcedure,
+			connect.WithSchema(shortLinkServiceCreateShortLinkMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getQRCode: connect.NewClient[generated.QRCodeRequest, generated.QRCodeResponse](
+			httpClient,
+			baseURL+ShortLinkServiceGetQRCodeProcedure,
+			connect.WithSchema(shortLinkServiceGetQRCodeMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		updateLink: connect.NewClient[generated.UpdateLinkRequest, generated.LinkInfo](
+			httpClient,
+			baseURL+ShortLinkServiceUpdateLinkProcedure,
+			connect.WithSchema(shortLinkServiceUpdateLinkMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listLinks: connect.NewClient[generated.ListLinksRequest, generated.ListLinksResponse](
+			httpClient,
+			baseURL+ShortLinkServiceListLinksProcedure,
+			connect.WithSchema(shortLinkServiceListLinksMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// shortLinkServiceClient implements ShortLinkServiceClient.
+type shortLinkServiceClient struct {
+	getFullLink     *connect.Client[generated.ShortLinkRequest, generated.ShortLinkResponse]
+	createShortLink *connect.Client[generated.CreateShortLinkRequest, generated.CreateShortLinkResponse]
+	getQRCode       *connect.Client[generated.QRCodeRequest, generated.QRCodeResponse]
+	updateLink      *connect.Client[generated.UpdateLinkRequest, generated.LinkInfo]
+	listLinks       *connect.Client[generated.ListLinksRequest, generated.ListLinksResponse]
+}
+
+// GetFullLink calls link.ShortLinkService.GetFullLink.
+func (c *shortLinkServiceClient) GetFullLink(ctx context.Context, req *connect.Request[generated.ShortLinkRequest]) (*connect.Response[generated.ShortLinkResponse], error) {
+	return c.getFullLink.CallUnary(ctx, req)
+}
+
+// CreateShortLink calls link.ShortLinkService.CreateShortLink.
+func (c *shortLinkServiceClient) CreateShortLink(ctx context.Context, req *connect.Request[generated.CreateShortLinkRequest]) (*connect.Response[generated.CreateShortLinkResponse], error) {
+	return c.createShortLink.CallUnary(ctx, req)
+}
+
+// GetQRCode calls link.ShortLinkService.GetQRCode.
+func (c *shortLinkServiceClient) GetQRCode(ctx context.Context, req *connect.Request[generated.QRCodeRequest]) (*connect.Response[generated.QRCodeResponse], error) {
+	return c.getQRCode.CallUnary(ctx, req)
+}
+
+// UpdateLink calls link.ShortLinkService.UpdateLink.
+func (c *shortLinkServiceClient) UpdateLink(ctx context.Context, req *connect.Request[generated.UpdateLinkRequest]) (*connect.Response[generated.LinkInfo], error) {
+	return c.updateLink.CallUnary(ctx, req)
+}
+
+// ListLinks calls link.ShortLinkService.ListLinks.
+func (c *shortLinkServiceClient) ListLinks(ctx context.Context, req *connect.Request[generated.ListLinksRequest]) (*connect.Response[generated.ListLinksResponse], error) {
+	return c.listLinks.CallUnary(ctx, req)
+}
+
+// ShortLinkServiceHandler is an implementation of the link.ShortLinkService service.
+type ShortLinkServiceHandler interface {
+	GetFullLink(context.Context, *connect.Request[generated.ShortLinkRequest]) (*connect.Response[generated.ShortLinkResponse], error)
+	CreateShortLink(context.Context, *connect.Request[generated.CreateShortLinkRequest]) (*connect.Response[generated.CreateShortLinkResponse], error)
+	GetQRCode(context.Context, *connect.Request[generated.QRCodeRequest]) (*connect.Response[generated.QRCodeResponse], error)
+	UpdateLink(context.Context, *connect.Request[generated.UpdateLinkRequest]) (*connect.Response[generated.LinkInfo], error)
+	ListLinks(context.Context, *connect.Request[generated.ListLinksRequest]) (*connect.Response[generated.ListLinksResponse], error)
+}
+
+// NewShortLinkServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewShortLinkServiceHandler(svc ShortLinkServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	shortLinkServiceGetFullLinkHandler := connect.NewUnaryHandler(
+		ShortLinkServiceGetFullLinkProcedure,
+		svc.GetFullLink,
+		connect.WithSchema(shortLinkServiceGetFullLinkMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	shortLinkServiceCreateShortLinkHandler := connect.NewUnaryHandler(
+		ShortLinkServiceCreateShortLinkProcedure,
+		svc.CreateShortLink,
+		connect.WithSchema(shortLinkServiceCreateShortLinkMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	shortLinkServiceGetQRCodeHandler := connect.NewUnaryHandler(
+		ShortLinkServiceGetQRCodeProcedure,
+		svc.GetQRCode,
+		connect.WithSchema(shortLinkServiceGetQRCodeMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	shortLinkServiceUpdateLinkHandler := connect.NewUnaryHandler(
+		ShortLinkServiceUpdateLinkProcedure,
+		svc.UpdateLink,
+		connect.WithSchema(shortLinkServiceUpdateLinkMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	shortLinkServiceListLinksHandler := connect.NewUnaryHandler(
+		ShortLinkServiceListLinksProcedure,
+		svc.ListLinks,
+		connect.WithSchema(shortLinkServiceListLinksMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/link.ShortLinkService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ShortLinkServiceGetFullLinkProcedure:
+			shortLinkServiceGetFullLinkHandler.ServeHTTP(w, r)
+		case ShortLinkServiceCreateShortLinkProcedure:
+			shortLinkServiceCreateShortLinkHandler.ServeHTTP(w, r)
+		case ShortLinkServiceGetQRCodeProcedure:
+			shortLinkServiceGetQRCodeHandler.ServeHTTP(w, r)
+		case ShortLinkServiceUpdateLinkProcedure:
+			shortLinkServiceUpdateLinkHandler.ServeHTTP(w, r)
+		case ShortLinkServiceListLinksProcedure:
+			shortLinkServiceListLinksHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedShortLinkServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedShortLinkServiceHandler struct{}
+
+func (UnimplementedShortLinkServiceHandler) GetFullLink(context.Context, *connect.Request[generated.ShortLinkRequest]) (*connect.Response[generated.ShortLinkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("link.ShortLinkService.GetFullLink is not implemented"))
+}
+
+func (UnimplementedShortLinkServiceHandler) CreateShortLink(context.Context, *connect.Request[generated.CreateShortLinkRequest]) (*connect.Response[generated.CreateShortLinkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("link.ShortLinkService.CreateShortLink is not implemented"))
+}
+
+func (UnimplementedShortLinkServiceHandler) GetQRCode(context.Context, *connect.Request[generated.QRCodeRequest]) (*connect.Response[generated.QRCodeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("link.ShortLinkService.GetQRCode is not implemented"))
+}
+
+func (UnimplementedShortLinkServiceHandler) UpdateLink(context.Context, *connect.Request[generated.UpdateLinkRequest]) (*connect.Response[generated.LinkInfo], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("link.ShortLinkService.UpdateLink is not implemented"))
+}
+
+func (UnimplementedShortLinkServiceHandler) ListLinks(context.Context, *connect.Request[generated.ListLinksRequest]) (*connect.Response[generated.ListLinksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("link.ShortLinkService.ListLinks is not implemented"))
+}
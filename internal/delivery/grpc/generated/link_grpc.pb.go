@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.25.3
+// source: link.proto
 
 package generated
 
@@ -20,6 +24,9 @@ const _ = grpc.SupportPackageIsVersion7
 type ShortLinkServiceClient interface {
 	GetFullLink(ctx context.Context, in *ShortLinkRequest, opts ...grpc.CallOption) (*ShortLinkResponse, error)
 	CreateShortLink(ctx context.Context, in *CreateShortLinkRequest, opts ...grpc.CallOption) (*CreateShortLinkResponse, error)
+	GetQRCode(ctx context.Context, in *QRCodeRequest, opts ...grpc.CallOption) (*QRCodeResponse, error)
+	UpdateLink(ctx context.Context, in *UpdateLinkRequest, opts ...grpc.CallOption) (*LinkInfo, error)
+	ListLinks(ctx context.Context, in *ListLinksRequest, opts ...grpc.CallOption) (*ListLinksResponse, error)
 }
 
 type shortLinkServiceClient struct {
@@ -48,12 +55,42 @@ func (c *shortLinkServiceClient) CreateShortLink(ctx context.Context, in *Create
 	return out, nil
 }
 
+func (c *shortLinkServiceClient) GetQRCode(ctx context.Context, in *QRCodeRequest, opts ...grpc.CallOption) (*QRCodeResponse, error) {
+	out := new(QRCodeResponse)
+	err := c.cc.Invoke(ctx, "/link.ShortLinkService/GetQRCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortLinkServiceClient) UpdateLink(ctx context.Context, in *UpdateLinkRequest, opts ...grpc.CallOption) (*LinkInfo, error) {
+	out := new(LinkInfo)
+	err := c.cc.Invoke(ctx, "/link.ShortLinkService/UpdateLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortLinkServiceClient) ListLinks(ctx context.Context, in *ListLinksRequest, opts ...grpc.CallOption) (*ListLinksResponse, error) {
+	out := new(ListLinksResponse)
+	err := c.cc.Invoke(ctx, "/link.ShortLinkService/ListLinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShortLinkServiceServer is the server API for ShortLinkService service.
 // All implementations must embed UnimplementedShortLinkServiceServer
 // for forward compatibility
 type ShortLinkServiceServer interface {
 	GetFullLink(context.Context, *ShortLinkRequest) (*ShortLinkResponse, error)
 	CreateShortLink(context.Context, *CreateShortLinkRequest) (*CreateShortLinkResponse, error)
+	GetQRCode(context.Context, *QRCodeRequest) (*QRCodeResponse, error)
+	UpdateLink(context.Context, *UpdateLinkRequest) (*LinkInfo, error)
+	ListLinks(context.Context, *ListLinksRequest) (*ListLinksResponse, error)
 	mustEmbedUnimplementedShortLinkServiceServer()
 }
 
@@ -67,6 +104,15 @@ func (UnimplementedShortLinkServiceServer) GetFullLink(context.Context, *ShortLi
 func (UnimplementedShortLinkServiceServer) CreateShortLink(context.Context, *CreateShortLinkRequest) (*CreateShortLinkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateShortLink not implemented")
 }
+func (UnimplementedShortLinkServiceServer) GetQRCode(context.Context, *QRCodeRequest) (*QRCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQRCode not implemented")
+}
+func (UnimplementedShortLinkServiceServer) UpdateLink(context.Context, *UpdateLinkRequest) (*LinkInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLink not implemented")
+}
+func (UnimplementedShortLinkServiceServer) ListLinks(context.Context, *ListLinksRequest) (*ListLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLinks not implemented")
+}
 func (UnimplementedShortLinkServiceServer) mustEmbedUnimplementedShortLinkServiceServer() {}
 
 // UnsafeShortLinkServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -116,6 +162,60 @@ func _ShortLinkService_CreateShortLink_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortLinkService_GetQRCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QRCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServiceServer).GetQRCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/link.ShortLinkService/GetQRCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServiceServer).GetQRCode(ctx, req.(*QRCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortLinkService_UpdateLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServiceServer).UpdateLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/link.ShortLinkService/UpdateLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServiceServer).UpdateLink(ctx, req.(*UpdateLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortLinkService_ListLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortLinkServiceServer).ListLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/link.ShortLinkService/ListLinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortLinkServiceServer).ListLinks(ctx, req.(*ListLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ShortLinkService_ServiceDesc is the grpc.ServiceDesc for ShortLinkService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -131,6 +231,18 @@ var ShortLinkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateShortLink",
 			Handler:    _ShortLinkService_CreateShortLink_Handler,
 		},
+		{
+			MethodName: "GetQRCode",
+			Handler:    _ShortLinkService_GetQRCode_Handler,
+		},
+		{
+			MethodName: "UpdateLink",
+			Handler:    _ShortLinkService_UpdateLink_Handler,
+		},
+		{
+			MethodName: "ListLinks",
+			Handler:    _ShortLinkService_ListLinks_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "link.proto",
@@ -2,16 +2,33 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"net"
+	"net/url"
+	"time"
 
 	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated"
 	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
 	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
 	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var errNoShortLink = apierror.InvalidFieldError("shortLink", errors.New("short link is required"))
+
 type LinkUsecase interface {
-	GetFullLink(ctx context.Context, token string) (string, error)
+	GetFullLink(ctx context.Context, domain, token string, visit *model.Visit) (*model.Redirect, error)
+	UnlockLink(ctx context.Context, domain, token, password string, visit *model.Visit) (*model.Redirect, error)
 	CreateShortLink(ctx context.Context, linkRequest *dto.CreateLinkRequest) (*model.Link, error)
+	PreviewLink(ctx context.Context, domain, token string) (*model.Link, error)
+	GetQRCode(ctx context.Context, domain, token string, request *dto.QRCodeRequest) (*model.QRCode, error)
+	UpdateLink(ctx context.Context, domain, token string, request *dto.UpdateLinkRequest) (*model.Link, error)
+	DeleteLink(ctx context.Context, domain, token string) error
+	ListLinks(ctx context.Context, tag string) ([]*model.Link, error)
+	WatchLinks(ctx context.Context, cursor string) (<-chan *model.LinkEvent, error)
 }
 
 type LinkGrpcHandler struct {
@@ -26,29 +43,190 @@ func NewLinkHandler(usecase LinkUsecase) *LinkGrpcHandler {
 }
 
 func (lgh *LinkGrpcHandler) CreateShortLink(ctx context.Context, request *generated.CreateShortLinkRequest) (*generated.CreateShortLinkResponse, error) {
-	addLink := &dto.CreateLinkRequest{Link: request.OriginalLink}
+	addLink := &dto.CreateLinkRequest{
+		Link:              request.OriginalLink,
+		Domain:            request.Domain,
+		Password:          request.Password,
+		MaxClicks:         request.MaxClicks,
+		FallbackLink:      request.FallbackLink,
+		ForwardPath:       request.ForwardPath,
+		ForwardQuery:      model.QueryMode(request.ForwardQuery),
+		RedirectCode:      int(request.RedirectCode),
+		Interstitial:      request.Interstitial,
+		InterstitialDelay: int(request.InterstitialDelay),
+		Title:             request.Title,
+		Description:       request.Description,
+		Tags:              request.Tags,
+		Notes:             request.Notes,
+		UTMPreset:         request.UtmPreset,
+		UTM:               utmOf(request.Utm),
+		OpenGraph:         openGraphOf(request.OpenGraph),
+	}
+
+	for _, rule := range request.Rules {
+		addLink.Rules = append(addLink.Rules, model.Rule{
+			Browser:  rule.Browser,
+			OS:       rule.Os,
+			Language: rule.Language,
+			Country:  rule.Country,
+			Query:    rule.Query,
+			Target:   rule.Target,
+		})
+	}
+
+	if request.ActiveFrom != "" {
+		activeFrom, err := time.Parse(time.RFC3339, request.ActiveFrom)
+		if err != nil {
+			return nil, apierror.NewAPIError(apierror.ErrBadRequest, err)
+		}
+
+		addLink.ActiveFrom = activeFrom
+	}
+
+	for _, variant := range request.Variants {
+		addLink.Variants = append(addLink.Variants, model.Variant{
+			Target: variant.Target,
+			Weight: int(variant.Weight),
+		})
+	}
+
+	if err := addLink.Validate(); err != nil {
+		return nil, renameFields(err, legacyField)
+	}
+
 	link, err := lgh.usecase.CreateShortLink(ctx, addLink)
 	if err != nil {
 		return nil, err
 	}
 
-	return &generated.CreateShortLinkResponse{
-		ShortLink: link.ShortLink,
-		ExpiresAt: link.ExpiresAt.String(),
-	}, nil
+	response := &generated.CreateShortLinkResponse{
+		ShortLink:  link.ShortLink,
+		ExpiresAt:  link.ExpiresAt.String(), //nolint:staticcheck // kept for existing clients
+		ExpireTime: timestamppb.New(link.ExpiresAt),
+	}
+
+	if !link.ActiveFrom.IsZero() {
+		response.ActiveFrom = link.ActiveFrom.Format(time.RFC3339)
+	}
+
+	return response, nil
 }
 
 func (lgh *LinkGrpcHandler) GetFullLink(ctx context.Context, request *generated.ShortLinkRequest) (*generated.ShortLinkResponse, error) {
 	if request.ShortLink == "" {
-		return nil, apierror.BadRequestError()
+		return nil, errNoShortLink
 	}
 
-	link, err := lgh.usecase.GetFullLink(ctx, request.ShortLink)
+	var (
+		redirect *model.Redirect
+		err      error
+		visit    = visitOf(ctx, request)
+	)
+
+	if request.Password != "" {
+		redirect, err = lgh.usecase.UnlockLink(ctx, request.Domain, request.ShortLink, request.Password, visit)
+	} else {
+		redirect, err = lgh.usecase.GetFullLink(ctx, request.Domain, request.ShortLink, visit)
+	}
+
+	redirect, err = withFallback(redirect, err)
 	if err != nil {
 		return nil, err
 	}
 
-	return &generated.ShortLinkResponse{
-		OriginalLink: link,
+	response := &generated.ShortLinkResponse{
+		OriginalLink: redirect.Target,
+		RedirectCode: int32(redirect.Code),
+	}
+
+	if redirect.Interstitial {
+		response.RedirectCode = 0
+		response.InterstitialDelay = int32(redirect.Delay)
+	}
+
+	return response, nil
+}
+
+func (lgh *LinkGrpcHandler) GetQRCode(ctx context.Context, request *generated.QRCodeRequest) (*generated.QRCodeResponse, error) {
+	if request.ShortLink == "" {
+		return nil, errNoShortLink
+	}
+
+	qrRequest := &dto.QRCodeRequest{
+		Format:     request.Format,
+		Size:       int(request.Size),
+		ECC:        request.Ecc,
+		Foreground: request.Foreground,
+		Background: request.Background,
+	}
+
+	if request.Margin != nil {
+		margin := int(*request.Margin)
+		qrRequest.Margin = &margin
+	}
+
+	if err := qrRequest.Validate(); err != nil {
+		return nil, renameFields(err, qrField)
+	}
+
+	code, err := lgh.usecase.GetQRCode(ctx, request.Domain, request.ShortLink, qrRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &generated.QRCodeResponse{
+		Image:       code.Image,
+		ContentType: code.ContentType,
 	}, nil
 }
+
+// visitOf collects the attributes redirect rules match on, preferring the ones
+// set in the request over those of the calling connection.
+func visitOf(ctx context.Context, request *generated.ShortLinkRequest) *model.Visit {
+	visit := &model.Visit{
+		VisitorID:      request.VisitorId,
+		Path:           request.Path,
+		UserAgent:      request.UserAgent,
+		AcceptLanguage: request.AcceptLanguage,
+		IP:             net.ParseIP(request.ClientIp),
+		Query:          url.Values{},
+	}
+
+	for key, value := range request.Query {
+		visit.Query.Set(key, value)
+	}
+
+	return withCaller(ctx, visit)
+}
+
+// withCaller fills in the user agent and IP of the calling connection
+// where the visit does not name them.
+func withCaller(ctx context.Context, visit *model.Visit) *model.Visit {
+	if visit.UserAgent == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if ua := md.Get("user-agent"); len(ua) > 0 {
+				visit.UserAgent = ua[0]
+			}
+		}
+	}
+
+	if visit.IP == nil {
+		if p, ok := peer.FromContext(ctx); ok {
+			if addr, ok := p.Addr.(*net.TCPAddr); ok {
+				visit.IP = addr.IP
+			}
+		}
+	}
+
+	return visit
+}
+
+// withFallback resolves links that are not active yet to their fallback,
+// as the HTTP redirect does; links without one keep failing.
+func withFallback(redirect *model.Redirect, err error) (*model.Redirect, error) {
+	if err != nil && (redirect == nil || !errors.Is(err, apierror.ErrLinkNotActive)) {
+		return nil, err
+	}
+
+	return redirect, nil
+}
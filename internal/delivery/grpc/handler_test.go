@@ -3,6 +3,10 @@ package grpc_test
 import (
 	"context"
 	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
 	"testing"
 	"time"
 
@@ -15,6 +19,8 @@ import (
 	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
 
 	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 func TestCreateShortLink(t *testing.T) {
@@ -50,6 +56,10 @@ func TestCreateShortLink(t *testing.T) {
 	if response.ShortLink != expectedResponse.ShortLink || response.ExpiresAt != expectedResponse.ExpiresAt {
 		t.Errorf("Unexpected response. Expected: %v, Got: %v", expectedResponse, response)
 	}
+
+	if !response.ExpireTime.AsTime().Equal(expectedExpiresAt) {
+		t.Errorf("Unexpected expire time. Expected: %v, Got: %v", expectedExpiresAt, response.ExpireTime.AsTime())
+	}
 }
 
 func TestGetFullLink(t *testing.T) {
@@ -67,8 +77,8 @@ func TestGetFullLink(t *testing.T) {
 	expectedOriginalLink := "http://example.com"
 
 	mockUsecase.EXPECT().
-		GetFullLink(ctx, request.ShortLink).
-		Return(expectedOriginalLink, nil)
+		GetFullLink(ctx, request.Domain, request.ShortLink, gomock.Any()).
+		Return(&model.Redirect{Target: expectedOriginalLink, Code: http.StatusFound}, nil)
 
 	response, err := handler.GetFullLink(ctx, request)
 	if err != nil {
@@ -114,6 +124,45 @@ func TestCreateShortLink_Error(t *testing.T) {
 	}
 }
 
+func TestCreateShortLink_Empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := grpc.NewLinkHandler(mock_handler.NewMockLinkUsecase(ctrl))
+
+	_, err := handler.CreateShortLink(context.Background(), &generated.CreateShortLinkRequest{})
+	if !errors.Is(err, apierror.ErrBadRequest) {
+		t.Errorf("Unexpected error. Expected: %v, Got: %v", apierror.ErrBadRequest, err)
+	}
+}
+
+func TestCreateShortLink_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := grpc.NewLinkHandler(mock_handler.NewMockLinkUsecase(ctrl))
+
+	_, err := handler.CreateShortLink(context.Background(), &generated.CreateShortLinkRequest{
+		OriginalLink: "https://example.com",
+		MaxClicks:    -1,
+		FallbackLink: "javascript:alert(1)",
+		OpenGraph:    &generated.OpenGraph{Image: "/cover.png"},
+	})
+	if !errors.Is(err, apierror.ErrBadRequest) {
+		t.Fatalf("Unexpected error. Expected: %v, Got: %v", apierror.ErrBadRequest, err)
+	}
+
+	var fields []string
+	for _, fieldErr := range apierror.FieldErrorsOf(err) {
+		fields = append(fields, fieldErr.Field)
+	}
+
+	expectedFields := []string{"maxClicks", "fallbackLink", "openGraph.image"}
+	if !reflect.DeepEqual(fields, expectedFields) {
+		t.Errorf("Unexpected fields. Expected: %v, Got: %v", expectedFields, fields)
+	}
+}
+
 func TestGetFullLink_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -129,8 +178,8 @@ func TestGetFullLink_Error(t *testing.T) {
 	expectedError := apierror.BadRequestError()
 
 	mockUsecase.EXPECT().
-		GetFullLink(ctx, request.ShortLink).
-		Return("", expectedError)
+		GetFullLink(ctx, request.Domain, request.ShortLink, gomock.Any()).
+		Return(nil, expectedError)
 
 	_, err := handler.GetFullLink(ctx, request)
 	if err == nil {
@@ -142,3 +191,252 @@ func TestGetFullLink_Error(t *testing.T) {
 		t.Errorf("Unexpected error. Expected: %v, Got: %v", expectedError, err)
 	}
 }
+
+func TestGetFullLink_Password(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkHandler(mockUsecase)
+
+	ctx := context.Background()
+	request := &generated.ShortLinkRequest{
+		ShortLink: "abc123",
+		Password:  "secret",
+	}
+
+	mockUsecase.EXPECT().
+		UnlockLink(ctx, request.Domain, request.ShortLink, request.Password, gomock.Any()).
+		Return(&model.Redirect{Target: "http://example.com", Code: http.StatusFound}, nil)
+
+	response, err := handler.GetFullLink(ctx, request)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+		return
+	}
+
+	if response.OriginalLink != "http://example.com" {
+		t.Errorf("Unexpected response. Expected: %v, Got: %v", "http://example.com", response.OriginalLink)
+	}
+}
+
+func TestGetFullLink_Visit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkHandler(mockUsecase)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-agent", "grpc-client"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234}})
+
+	request := &generated.ShortLinkRequest{
+		ShortLink:      "abc123",
+		AcceptLanguage: "fr",
+		Query:          map[string]string{"ref": "mail"},
+	}
+
+	mockUsecase.EXPECT().
+		GetFullLink(ctx, request.Domain, request.ShortLink, &model.Visit{
+			UserAgent:      "grpc-client",
+			AcceptLanguage: "fr",
+			IP:             net.ParseIP("192.0.2.1"),
+			Query:          url.Values{"ref": {"mail"}},
+		}).
+		Return(&model.Redirect{Target: "http://example.com/fr", Code: http.StatusFound}, nil)
+
+	response, err := handler.GetFullLink(ctx, request)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+		return
+	}
+
+	if response.OriginalLink != "http://example.com/fr" {
+		t.Errorf("Unexpected response. Expected: %v, Got: %v", "http://example.com/fr", response.OriginalLink)
+	}
+}
+
+func TestCreateShortLink_Variants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkHandler(mockUsecase)
+
+	ctx := context.Background()
+	request := &generated.CreateShortLinkRequest{
+		OriginalLink: "http://example.com",
+		Variants: []*generated.WeightedTarget{
+			{Target: "http://example.com/a", Weight: 70},
+			{Target: "http://example.com/b", Weight: 30},
+		},
+	}
+
+	mockUsecase.EXPECT().
+		CreateShortLink(ctx, &dto.CreateLinkRequest{
+			Link: request.OriginalLink,
+			Variants: []model.Variant{
+				{Target: "http://example.com/a", Weight: 70},
+				{Target: "http://example.com/b", Weight: 30},
+			},
+		}).
+		Return(&model.Link{ShortLink: "http://short.link/abc123"}, nil)
+
+	response, err := handler.CreateShortLink(ctx, request)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+		return
+	}
+
+	if response.ShortLink != "http://short.link/abc123" {
+		t.Errorf("Unexpected response. Expected: %v, Got: %v", "http://short.link/abc123", response.ShortLink)
+	}
+}
+
+func TestGetFullLink_Interstitial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkHandler(mockUsecase)
+
+	ctx := context.Background()
+	request := &generated.ShortLinkRequest{
+		ShortLink: "abc123",
+	}
+
+	mockUsecase.EXPECT().
+		GetFullLink(ctx, request.Domain, request.ShortLink, gomock.Any()).
+		Return(&model.Redirect{Target: "http://example.com", Code: http.StatusFound, Interstitial: true, Delay: 5}, nil)
+
+	response, err := handler.GetFullLink(ctx, request)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+		return
+	}
+
+	if response.RedirectCode != 0 || response.InterstitialDelay != 5 {
+		t.Errorf("Unexpected response. Expected an interstitial with delay 5, Got: %v", response)
+	}
+}
+
+func TestGetFullLink_NotActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkHandler(mockUsecase)
+
+	ctx := context.Background()
+	notActive := apierror.NewAPIError(apierror.ErrLinkNotActive, nil)
+
+	mockUsecase.EXPECT().
+		GetFullLink(ctx, "", "scheduled", gomock.Any()).
+		Return(&model.Redirect{Target: "https://example.com/soon", Code: http.StatusFound}, notActive)
+	mockUsecase.EXPECT().
+		GetFullLink(ctx, "", "unscheduled", gomock.Any()).
+		Return(nil, notActive)
+
+	response, err := handler.GetFullLink(ctx, &generated.ShortLinkRequest{ShortLink: "scheduled"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if response.OriginalLink != "https://example.com/soon" || response.RedirectCode != http.StatusFound {
+		t.Errorf("Unexpected response. Expected the fallback link, Got: %v", response)
+	}
+
+	_, err = handler.GetFullLink(ctx, &generated.ShortLinkRequest{ShortLink: "unscheduled"})
+	if !errors.Is(err, apierror.ErrLinkNotActive) {
+		t.Errorf("Unexpected error. Expected: %v, Got: %v", apierror.ErrLinkNotActive, err)
+	}
+}
+
+func TestGetQRCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkHandler(mockUsecase)
+
+	ctx := context.Background()
+	margin := int32(0)
+	request := &generated.QRCodeRequest{
+		ShortLink: "abc123",
+		Format:    "svg",
+		Size:      512,
+		Margin:    &margin,
+	}
+
+	zero := 0
+	mockUsecase.EXPECT().
+		GetQRCode(ctx, request.Domain, request.ShortLink, &dto.QRCodeRequest{Format: "svg", Size: 512, Margin: &zero}).
+		Return(&model.QRCode{Image: []byte("<svg/>"), ContentType: "image/svg+xml"}, nil)
+
+	response, err := handler.GetQRCode(ctx, request)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+		return
+	}
+
+	if string(response.Image) != "<svg/>" || response.ContentType != "image/svg+xml" {
+		t.Errorf("Unexpected response: %v", response)
+	}
+}
+
+func TestGetQRCode_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := grpc.NewLinkHandler(mock_handler.NewMockLinkUsecase(ctrl))
+
+	_, err := handler.GetQRCode(context.Background(), &generated.QRCodeRequest{
+		ShortLink:  "abc123",
+		Size:       16,
+		Foreground: "red",
+	})
+	if !errors.Is(err, apierror.ErrBadRequest) {
+		t.Fatalf("Unexpected error. Expected: %v, Got: %v", apierror.ErrBadRequest, err)
+	}
+
+	var fields []string
+	for _, fieldErr := range apierror.FieldErrorsOf(err) {
+		fields = append(fields, fieldErr.Field)
+	}
+
+	expectedFields := []string{"size", "foreground"}
+	if !reflect.DeepEqual(fields, expectedFields) {
+		t.Errorf("Unexpected fields. Expected: %v, Got: %v", expectedFields, fields)
+	}
+}
+
+func TestUpdateLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkHandler(mockUsecase)
+
+	ctx := context.Background()
+	title := "Docs"
+	request := &generated.UpdateLinkRequest{
+		ShortLink: "abc123",
+		Title:     &title,
+		Tags:      &generated.TagList{Tags: []string{"docs"}},
+	}
+
+	tags := []string{"docs"}
+	mockUsecase.EXPECT().
+		UpdateLink(ctx, "", "abc123", &dto.UpdateLinkRequest{Title: &title, Tags: &tags}).
+		Return(&model.Link{ShortLink: "http://short.link/abc123", Title: "Docs", Tags: tags, UTM: model.UTM{Source: "newsletter"}}, nil)
+
+	response, err := handler.UpdateLink(ctx, request)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+		return
+	}
+
+	if response.Title != "Docs" || response.Utm.GetSource() != "newsletter" {
+		t.Errorf("Unexpected response: %v", response)
+	}
+}
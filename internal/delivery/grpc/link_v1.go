@@ -0,0 +1,439 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	linkv1 "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated/link/v1"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errRequired = errors.New("is required")
+
+var eventTypes = map[model.LinkEventType]linkv1.LinkEvent_Type{
+	model.LinkCreated: linkv1.LinkEvent_TYPE_CREATED,
+	model.LinkUpdated: linkv1.LinkEvent_TYPE_UPDATED,
+	model.LinkExpired: linkv1.LinkEvent_TYPE_EXPIRED,
+	model.LinkDeleted: linkv1.LinkEvent_TYPE_DELETED,
+}
+
+var queryModes = map[linkv1.QueryMode]model.QueryMode{
+	linkv1.QueryMode_QUERY_MODE_UNSPECIFIED: "",
+	linkv1.QueryMode_QUERY_MODE_DROP:        model.QueryDrop,
+	linkv1.QueryMode_QUERY_MODE_APPEND:      model.QueryAppend,
+	linkv1.QueryMode_QUERY_MODE_OVERRIDE:    model.QueryOverride,
+}
+
+// LinkV1Handler serves link.v1.LinkService.
+type LinkV1Handler struct {
+	usecase LinkUsecase
+	linkv1.UnimplementedLinkServiceServer
+}
+
+func NewLinkV1Handler(usecase LinkUsecase) *LinkV1Handler {
+	return &LinkV1Handler{
+		usecase: usecase,
+	}
+}
+
+func (h *LinkV1Handler) ResolveLink(ctx context.Context, request *linkv1.ResolveLinkRequest) (*linkv1.ResolveLinkResponse, error) {
+	if request.Token == "" {
+		return nil, apierror.InvalidFieldError("token", errRequired)
+	}
+
+	visit, err := visitV1(ctx, request.Visit)
+	if err != nil {
+		return nil, err
+	}
+
+	var redirect *model.Redirect
+
+	if request.Password != "" {
+		redirect, err = h.usecase.UnlockLink(ctx, request.Domain, request.Token, request.Password, visit)
+	} else {
+		redirect, err = h.usecase.GetFullLink(ctx, request.Domain, request.Token, visit)
+	}
+
+	redirect, err = withFallback(redirect, err)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &linkv1.ResolveLinkResponse{
+		TargetUrl: redirect.Target,
+	}
+
+	if redirect.Interstitial {
+		response.Interstitial = true
+
+		if redirect.Delay > 0 {
+			response.InterstitialDelay = durationpb.New(time.Duration(redirect.Delay) * time.Second)
+		}
+	} else {
+		response.RedirectCode = int32(redirect.Code)
+	}
+
+	return response, nil
+}
+
+func (h *LinkV1Handler) CreateLink(ctx context.Context, request *linkv1.CreateLinkRequest) (*linkv1.Link, error) {
+	addLink, err := createLinkV1(request)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := h.usecase.CreateShortLink(ctx, addLink)
+	if err != nil {
+		return nil, err
+	}
+
+	return linkV1(link), nil
+}
+
+func (h *LinkV1Handler) GetLink(ctx context.Context, request *linkv1.GetLinkRequest) (*linkv1.Link, error) {
+	if request.Token == "" {
+		return nil, apierror.InvalidFieldError("token", errRequired)
+	}
+
+	link, err := h.usecase.PreviewLink(ctx, request.Domain, request.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return linkV1(link), nil
+}
+
+func (h *LinkV1Handler) UpdateLink(ctx context.Context, request *linkv1.UpdateLinkRequest) (*linkv1.Link, error) {
+	if request.Token == "" {
+		return nil, apierror.InvalidFieldError("token", errRequired)
+	}
+
+	update := &dto.UpdateLinkRequest{
+		Title:       request.Title,
+		Description: request.Description,
+		Notes:       request.Notes,
+	}
+
+	if request.Tags != nil {
+		update.Tags = &request.Tags.Tags
+	}
+
+	if request.OpenGraph != nil {
+		og := openGraphV1(request.OpenGraph)
+		update.OpenGraph = &og
+	}
+
+	if err := update.Validate(); err != nil {
+		return nil, renameFields(err, v1Field)
+	}
+
+	link, err := h.usecase.UpdateLink(ctx, request.Domain, request.Token, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return linkV1(link), nil
+}
+
+func (h *LinkV1Handler) DeleteLink(ctx context.Context, request *linkv1.DeleteLinkRequest) (*emptypb.Empty, error) {
+	if request.Token == "" {
+		return nil, apierror.InvalidFieldError("token", errRequired)
+	}
+
+	if err := h.usecase.DeleteLink(ctx, request.Domain, request.Token); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *LinkV1Handler) ListLinks(ctx context.Context, request *linkv1.ListLinksRequest) (*linkv1.ListLinksResponse, error) {
+	if request.Tag == "" {
+		return nil, apierror.InvalidFieldError("tag", errRequired)
+	}
+
+	links, err := h.usecase.ListLinks(ctx, request.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &linkv1.ListLinksResponse{Links: make([]*linkv1.Link, 0, len(links))}
+	for _, link := range links {
+		response.Links = append(response.Links, linkV1(link))
+	}
+
+	return response, nil
+}
+
+func (h *LinkV1Handler) GetQRCode(ctx context.Context, request *linkv1.GetQRCodeRequest) (*linkv1.GetQRCodeResponse, error) {
+	if request.Token == "" {
+		return nil, apierror.InvalidFieldError("token", errRequired)
+	}
+
+	qrRequest := &dto.QRCodeRequest{
+		Format:     request.Format,
+		Size:       int(request.Size),
+		ECC:        request.Ecc,
+		Foreground: request.Foreground,
+		Background: request.Background,
+	}
+
+	if request.Margin != nil {
+		margin := int(*request.Margin)
+		qrRequest.Margin = &margin
+	}
+
+	if err := qrRequest.Validate(); err != nil {
+		return nil, renameFields(err, qrField)
+	}
+
+	code, err := h.usecase.GetQRCode(ctx, request.Domain, request.Token, qrRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &linkv1.GetQRCodeResponse{
+		Image:       code.Image,
+		ContentType: code.ContentType,
+	}, nil
+}
+
+func (h *LinkV1Handler) WatchLinks(request *linkv1.WatchLinksRequest, stream linkv1.LinkService_WatchLinksServer) error {
+	ctx := stream.Context()
+
+	events, err := h.usecase.WatchLinks(ctx, request.Cursor)
+	if err != nil {
+		return err
+	}
+
+	for event := range events {
+		if err := stream.Send(linkEventV1(event)); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// The stream fell behind the events kept.
+	return apierror.NewAPIError(apierror.ErrCursorExpired, nil)
+}
+
+// createLinkV1 checks the fields of request and converts it to the request
+// the usecase takes. Violations are reported by the names of link.v1 fields.
+func createLinkV1(request *linkv1.CreateLinkRequest) (*dto.CreateLinkRequest, error) {
+	forwardQuery, ok := queryModes[request.ForwardQuery]
+	if !ok {
+		return nil, apierror.InvalidFieldError("forward_query", fmt.Errorf("unknown query mode %d", request.ForwardQuery))
+	}
+
+	addLink := &dto.CreateLinkRequest{
+		Link:         request.OriginalUrl,
+		Domain:       request.Domain,
+		Alias:        request.Alias,
+		Password:     request.Password,
+		MaxClicks:    request.MaxClicks,
+		FallbackLink: request.FallbackUrl,
+		ForwardPath:  request.ForwardPath,
+		ForwardQuery: forwardQuery,
+		RedirectCode: int(request.RedirectCode),
+		Interstitial: request.Interstitial,
+		Title:        request.Title,
+		Description:  request.Description,
+		Tags:         request.Tags,
+		Notes:        request.Notes,
+		UTMPreset:    request.UtmPreset,
+		UTM:          utmV1(request.Utm),
+		OpenGraph:    openGraphV1(request.OpenGraph),
+	}
+
+	if request.ActiveTime != nil {
+		if err := request.ActiveTime.CheckValid(); err != nil {
+			return nil, apierror.InvalidFieldError("active_time", err)
+		}
+
+		addLink.ActiveFrom = request.ActiveTime.AsTime()
+	}
+
+	if request.InterstitialDelay != nil {
+		if err := request.InterstitialDelay.CheckValid(); err != nil {
+			return nil, apierror.InvalidFieldError("interstitial_delay", err)
+		}
+
+		delay := request.InterstitialDelay.AsDuration()
+		if delay%time.Second != 0 {
+			return nil, apierror.InvalidFieldError("interstitial_delay", errors.New("must be whole seconds"))
+		}
+
+		addLink.InterstitialDelay = int(delay / time.Second)
+	}
+
+	if request.Ttl != nil {
+		if err := request.Ttl.CheckValid(); err != nil {
+			return nil, apierror.InvalidFieldError("ttl", err)
+		}
+
+		ttl := request.Ttl.AsDuration()
+		if ttl%time.Second != 0 {
+			return nil, apierror.InvalidFieldError("ttl", errors.New("must be whole seconds"))
+		}
+
+		addLink.TTL = int64(ttl / time.Second)
+	}
+
+	for _, rule := range request.Rules {
+		addLink.Rules = append(addLink.Rules, model.Rule{
+			Browser:  rule.Browser,
+			OS:       rule.Os,
+			Language: rule.Language,
+			Country:  rule.Country,
+			Query:    rule.Query,
+			Target:   rule.TargetUrl,
+		})
+	}
+
+	for _, variant := range request.Variants {
+		addLink.Variants = append(addLink.Variants, model.Variant{
+			Target: variant.TargetUrl,
+			Weight: int(variant.Weight),
+		})
+	}
+
+	if err := addLink.Validate(); err != nil {
+		return nil, renameFields(err, v1Field)
+	}
+
+	return addLink, nil
+}
+
+func visitV1(ctx context.Context, v *linkv1.Visit) (*model.Visit, error) {
+	visit := &model.Visit{Query: url.Values{}}
+
+	if v != nil {
+		visit.VisitorID = v.VisitorId
+		visit.Path = v.Path
+		visit.UserAgent = v.UserAgent
+		visit.AcceptLanguage = v.AcceptLanguage
+
+		if v.ClientIp != "" {
+			visit.IP = net.ParseIP(v.ClientIp)
+			if visit.IP == nil {
+				return nil, apierror.InvalidFieldError("visit.client_ip", errors.New("must be an IP address"))
+			}
+		}
+
+		for key, value := range v.Query {
+			visit.Query.Set(key, value)
+		}
+	}
+
+	return withCaller(ctx, visit), nil
+}
+
+func linkV1(link *model.Link) *linkv1.Link {
+	info := &linkv1.Link{
+		Token:             link.Token,
+		Domain:            link.Domain,
+		ShortUrl:          link.ShortLink,
+		OriginalUrl:       link.OriginalLink,
+		Title:             link.Title,
+		Description:       link.Description,
+		Tags:              link.Tags,
+		Notes:             link.Notes,
+		PasswordProtected: link.Protected(),
+		MaxClicks:         link.MaxClicks,
+		CreateTime:        timestampV1(link.CreatedAt),
+		ExpireTime:        timestampV1(link.ExpiresAt),
+		ActiveTime:        timestampV1(link.ActiveFrom),
+	}
+
+	if !link.UTM.Empty() {
+		info.Utm = &linkv1.UTM{
+			Source:   link.UTM.Source,
+			Medium:   link.UTM.Medium,
+			Campaign: link.UTM.Campaign,
+			Term:     link.UTM.Term,
+			Content:  link.UTM.Content,
+		}
+	}
+
+	if link.OpenGraph.IsDefined() {
+		info.OpenGraph = &linkv1.OpenGraph{
+			Title:       link.OpenGraph.Title,
+			Description: link.OpenGraph.Description,
+			Image:       link.OpenGraph.Image,
+		}
+	}
+
+	if link.Page.IsDefined() {
+		info.Page = &linkv1.Page{
+			Title:       link.Page.Title,
+			Description: link.Page.Description,
+			Image:       link.Page.Image,
+			Favicon:     link.Page.Favicon,
+		}
+	}
+
+	return info
+}
+
+func linkEventV1(event *model.LinkEvent) *linkv1.LinkEvent {
+	info := &linkv1.LinkEvent{
+		Cursor:    event.Cursor,
+		Type:      eventTypes[event.Type],
+		Token:     event.Token,
+		Domain:    event.Domain,
+		EventTime: timestampV1(event.Time),
+	}
+
+	if event.Link != nil {
+		info.Link = linkV1(event.Link)
+	}
+
+	return info
+}
+
+func timestampV1(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+func utmV1(utm *linkv1.UTM) model.UTM {
+	if utm == nil {
+		return model.UTM{}
+	}
+
+	return model.UTM{
+		Source:   utm.Source,
+		Medium:   utm.Medium,
+		Campaign: utm.Campaign,
+		Term:     utm.Term,
+		Content:  utm.Content,
+	}
+}
+
+func openGraphV1(og *linkv1.OpenGraph) model.OpenGraph {
+	if og == nil {
+		return model.OpenGraph{}
+	}
+
+	return model.OpenGraph{
+		Title:       og.Title,
+		Description: og.Description,
+		Image:       og.Image,
+	}
+}
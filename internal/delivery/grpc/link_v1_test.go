@@ -0,0 +1,388 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc"
+	linkv1 "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated/link/v1"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	mock_handler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/handler/mocks"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/validator"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLinkV1_CreateLink(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkV1Handler(mockUsecase)
+
+	created := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	activeFrom := created.Add(time.Hour)
+
+	mockUsecase.EXPECT().
+		CreateShortLink(gomock.Any(), &dto.CreateLinkRequest{
+			Link:              "https://example.com/docs",
+			ActiveFrom:        activeFrom,
+			ForwardQuery:      model.QueryAppend,
+			Interstitial:      true,
+			InterstitialDelay: 5,
+			Rules:             []model.Rule{{OS: "ios", Target: "https://apps.apple.com/app/id1"}},
+			Tags:              []string{"docs"},
+		}).
+		Return(&model.Link{
+			OriginalLink: "https://example.com/docs",
+			ShortLink:    "https://sho.rt/abc123",
+			Token:        "abc123",
+			CreatedAt:    created,
+			ExpiresAt:    created.Add(24 * time.Hour),
+			ActiveFrom:   activeFrom,
+			Tags:         []string{"docs"},
+		}, nil)
+
+	link, err := handler.CreateLink(context.Background(), &linkv1.CreateLinkRequest{
+		OriginalUrl:       "https://example.com/docs",
+		ActiveTime:        timestamppb.New(activeFrom),
+		ForwardQuery:      linkv1.QueryMode_QUERY_MODE_APPEND,
+		Interstitial:      true,
+		InterstitialDelay: durationpb.New(5 * time.Second),
+		Rules:             []*linkv1.Rule{{Os: "ios", TargetUrl: "https://apps.apple.com/app/id1"}},
+		Tags:              []string{"docs"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "abc123", link.Token)
+	require.Equal(t, "https://sho.rt/abc123", link.ShortUrl)
+	require.Equal(t, "https://example.com/docs", link.OriginalUrl)
+	require.Equal(t, created, link.CreateTime.AsTime())
+	require.Equal(t, created.Add(24*time.Hour), link.ExpireTime.AsTime())
+	require.Equal(t, activeFrom, link.ActiveTime.AsTime())
+	require.False(t, link.PasswordProtected)
+}
+
+func TestLinkV1_CreateLink_AliasAndTTL(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkV1Handler(mockUsecase)
+
+	mockUsecase.EXPECT().
+		CreateShortLink(gomock.Any(), &dto.CreateLinkRequest{Link: "https://example.com", Alias: "spring_sale", TTL: 3600}).
+		Return(&model.Link{Token: "spring_sale", OriginalLink: "https://example.com"}, nil)
+	mockUsecase.EXPECT().
+		CreateShortLink(gomock.Any(), gomock.Any()).
+		Return(nil, apierror.NewAPIError(apierror.ErrAliasTaken, nil))
+
+	request := &linkv1.CreateLinkRequest{OriginalUrl: "https://example.com", Alias: "spring_sale", Ttl: durationpb.New(time.Hour)}
+
+	link, err := handler.CreateLink(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, "spring_sale", link.Token)
+
+	_, err = handler.CreateLink(context.Background(), request)
+	require.ErrorIs(t, err, apierror.ErrAliasTaken)
+}
+
+func TestLinkV1_CreateLink_Invalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		request       *linkv1.CreateLinkRequest
+		expectedField string
+	}{
+		{
+			name:          "No URL",
+			request:       &linkv1.CreateLinkRequest{},
+			expectedField: "original_url",
+		},
+		{
+			name:          "Relative URL",
+			request:       &linkv1.CreateLinkRequest{OriginalUrl: "/docs"},
+			expectedField: "original_url",
+		},
+		{
+			name:          "Fallback without host",
+			request:       &linkv1.CreateLinkRequest{OriginalUrl: "https://example.com", FallbackUrl: "javascript:alert(1)"},
+			expectedField: "fallback_url",
+		},
+		{
+			name:          "Negative clicks",
+			request:       &linkv1.CreateLinkRequest{OriginalUrl: "https://example.com", MaxClicks: -1},
+			expectedField: "max_clicks",
+		},
+		{
+			name:          "Unknown query mode",
+			request:       &linkv1.CreateLinkRequest{OriginalUrl: "https://example.com", ForwardQuery: 42},
+			expectedField: "forward_query",
+		},
+		{
+			name:          "Invalid timestamp",
+			request:       &linkv1.CreateLinkRequest{OriginalUrl: "https://example.com", ActiveTime: &timestamppb.Timestamp{Nanos: -1}},
+			expectedField: "active_time",
+		},
+		{
+			name:          "Fractional delay",
+			request:       &linkv1.CreateLinkRequest{OriginalUrl: "https://example.com", InterstitialDelay: durationpb.New(1500 * time.Millisecond)},
+			expectedField: "interstitial_delay",
+		},
+		{
+			name:          "Short TTL",
+			request:       &linkv1.CreateLinkRequest{OriginalUrl: "https://example.com", Ttl: durationpb.New(30 * time.Second)},
+			expectedField: "ttl",
+		},
+		{
+			name:          "Fractional TTL",
+			request:       &linkv1.CreateLinkRequest{OriginalUrl: "https://example.com", Ttl: durationpb.New(90*time.Second + time.Millisecond)},
+			expectedField: "ttl",
+		},
+		{
+			name:          "Alias pattern",
+			request:       &linkv1.CreateLinkRequest{OriginalUrl: "https://example.com", Alias: "spring/sale"},
+			expectedField: "alias",
+		},
+		{
+			name: "Rule target",
+			request: &linkv1.CreateLinkRequest{
+				OriginalUrl: "https://example.com",
+				Rules:       []*linkv1.Rule{{Os: "ios", TargetUrl: "https://example.com/ios"}, {Os: "android"}},
+			},
+			expectedField: "rules[1].target_url",
+		},
+		{
+			name: "Long title",
+			request: &linkv1.CreateLinkRequest{
+				OriginalUrl: "https://example.com",
+				Title:       strings.Repeat("t", 201),
+			},
+			expectedField: "title",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := grpc.NewLinkV1Handler(mock_handler.NewMockLinkUsecase(ctrl))
+
+			_, err := handler.CreateLink(context.Background(), tc.request)
+			require.ErrorIs(t, err, apierror.ErrBadRequest)
+
+			var fieldErr *apierror.FieldError
+			var apiErr *apierror.APIError
+			require.True(t, errors.As(err, &apiErr))
+			require.True(t, errors.As(apiErr.Internal(), &fieldErr))
+			require.Equal(t, tc.expectedField, fieldErr.Field)
+		})
+	}
+}
+
+func TestLinkV1_CreateLink_EveryViolation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := grpc.NewLinkV1Handler(mock_handler.NewMockLinkUsecase(ctrl))
+
+	_, err := handler.CreateLink(context.Background(), &linkv1.CreateLinkRequest{
+		OriginalUrl: "https://example.com/" + strings.Repeat("a", 2048),
+		FallbackUrl: "example.com",
+		Variants:    []*linkv1.WeightedTarget{{TargetUrl: "https://example.com/a", Weight: 1 << 30}, {TargetUrl: "/b", Weight: 1}},
+	})
+	require.ErrorIs(t, err, apierror.ErrBadRequest)
+
+	fields := map[string]string{}
+	for _, fieldErr := range apierror.FieldErrorsOf(err) {
+		fields[fieldErr.Field] = fieldErr.Code
+	}
+
+	require.Equal(t, map[string]string{
+		"original_url":           validator.CodeTooLong,
+		"fallback_url":           validator.CodeInvalidURL,
+		"variants[0].weight":     validator.CodeTooLarge,
+		"variants[1].target_url": validator.CodeInvalidURL,
+	}, fields)
+}
+
+func TestLinkV1_ResolveLink(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkV1Handler(mockUsecase)
+
+	mockUsecase.EXPECT().
+		GetFullLink(gomock.Any(), "", "abc123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, visit *model.Visit) (*model.Redirect, error) {
+			require.Equal(t, "203.0.113.7", visit.IP.String())
+			require.Equal(t, "docs", visit.Query.Get("ref"))
+
+			return &model.Redirect{Target: "https://example.com/docs", Interstitial: true, Delay: 3}, nil
+		})
+
+	response, err := handler.ResolveLink(context.Background(), &linkv1.ResolveLinkRequest{
+		Token: "abc123",
+		Visit: &linkv1.Visit{ClientIp: "203.0.113.7", Query: map[string]string{"ref": "docs"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/docs", response.TargetUrl)
+	require.True(t, response.Interstitial)
+	require.Zero(t, response.RedirectCode)
+	require.Equal(t, 3*time.Second, response.InterstitialDelay.AsDuration())
+
+	_, err = handler.ResolveLink(context.Background(), &linkv1.ResolveLinkRequest{
+		Token: "abc123",
+		Visit: &linkv1.Visit{ClientIp: "not an ip"},
+	})
+	require.ErrorIs(t, err, apierror.ErrBadRequest)
+
+	_, err = handler.ResolveLink(context.Background(), &linkv1.ResolveLinkRequest{})
+	require.ErrorIs(t, err, apierror.ErrBadRequest)
+}
+
+func TestLinkV1_ResolveLink_NotActive(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkV1Handler(mockUsecase)
+
+	notActive := apierror.NewAPIError(apierror.ErrLinkNotActive, nil)
+	mockUsecase.EXPECT().
+		GetFullLink(gomock.Any(), "", "scheduled", gomock.Any()).
+		Return(&model.Redirect{Target: "https://example.com/soon", Code: http.StatusFound}, notActive)
+	mockUsecase.EXPECT().
+		GetFullLink(gomock.Any(), "", "unscheduled", gomock.Any()).
+		Return(nil, notActive)
+
+	response, err := handler.ResolveLink(context.Background(), &linkv1.ResolveLinkRequest{Token: "scheduled"})
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/soon", response.TargetUrl)
+	require.Equal(t, int32(http.StatusFound), response.RedirectCode)
+
+	_, err = handler.ResolveLink(context.Background(), &linkv1.ResolveLinkRequest{Token: "unscheduled"})
+	require.ErrorIs(t, err, apierror.ErrLinkNotActive)
+}
+
+func TestLinkV1_GetLink(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkV1Handler(mockUsecase)
+
+	mockUsecase.EXPECT().
+		PreviewLink(gomock.Any(), "go.example.com", "abc123").
+		Return(&model.Link{Token: "abc123", Domain: "go.example.com", OriginalLink: "https://example.com"}, nil)
+
+	link, err := handler.GetLink(context.Background(), &linkv1.GetLinkRequest{Token: "abc123", Domain: "go.example.com"})
+	require.NoError(t, err)
+	require.Equal(t, "go.example.com", link.Domain)
+	require.Nil(t, link.CreateTime)
+	require.Nil(t, link.ActiveTime)
+}
+
+func TestLinkV1_DeleteLink(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkV1Handler(mockUsecase)
+
+	mockUsecase.EXPECT().DeleteLink(gomock.Any(), "go.example.com", "abc123").Return(nil)
+	mockUsecase.EXPECT().DeleteLink(gomock.Any(), "", "gone").Return(apierror.ErrLinkNotFound)
+
+	_, err := handler.DeleteLink(context.Background(), &linkv1.DeleteLinkRequest{Token: "abc123", Domain: "go.example.com"})
+	require.NoError(t, err)
+
+	_, err = handler.DeleteLink(context.Background(), &linkv1.DeleteLinkRequest{Token: "gone"})
+	require.ErrorIs(t, err, apierror.ErrLinkNotFound)
+
+	_, err = handler.DeleteLink(context.Background(), &linkv1.DeleteLinkRequest{})
+	require.ErrorIs(t, err, apierror.ErrBadRequest)
+}
+
+type watchStream struct {
+	grpclib.ServerStream
+	ctx    context.Context
+	events []*linkv1.LinkEvent
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(event *linkv1.LinkEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestLinkV1_WatchLinks(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := grpc.NewLinkV1Handler(mockUsecase)
+
+	eventTime := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	events := make(chan *model.LinkEvent, 3)
+	events <- &model.LinkEvent{Cursor: "c-1", Type: model.LinkUpdated, Token: "abc123", Time: eventTime, Link: &model.Link{Token: "abc123", Title: "Docs"}}
+	events <- &model.LinkEvent{Cursor: "c-2", Type: model.LinkExpired, Token: "old", Time: eventTime}
+	events <- &model.LinkEvent{Cursor: "c-3", Type: model.LinkDeleted, Token: "gone", Time: eventTime}
+	close(events)
+
+	mockUsecase.EXPECT().WatchLinks(gomock.Any(), "c-0").Return(events, nil)
+	mockUsecase.EXPECT().WatchLinks(gomock.Any(), "garbage").Return(nil, apierror.InvalidFieldError("cursor", errors.New("invalid")))
+
+	stream := &watchStream{ctx: context.Background()}
+
+	// The channel closing while the stream is open means it fell behind.
+	err := handler.WatchLinks(&linkv1.WatchLinksRequest{Cursor: "c-0"}, stream)
+	require.ErrorIs(t, err, apierror.ErrCursorExpired)
+
+	require.Len(t, stream.events, 3)
+	require.Equal(t, "c-1", stream.events[0].Cursor)
+	require.Equal(t, linkv1.LinkEvent_TYPE_UPDATED, stream.events[0].Type)
+	require.Equal(t, "Docs", stream.events[0].Link.Title)
+	require.Equal(t, eventTime, stream.events[0].EventTime.AsTime())
+	require.Equal(t, linkv1.LinkEvent_TYPE_EXPIRED, stream.events[1].Type)
+	require.Nil(t, stream.events[1].Link)
+	require.Equal(t, linkv1.LinkEvent_TYPE_DELETED, stream.events[2].Type)
+
+	err = handler.WatchLinks(&linkv1.WatchLinksRequest{Cursor: "garbage"}, &watchStream{ctx: context.Background()})
+	require.ErrorIs(t, err, apierror.ErrBadRequest)
+}
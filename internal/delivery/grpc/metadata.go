@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/grpc/generated"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+)
+
+func (lgh *LinkGrpcHandler) UpdateLink(ctx context.Context, request *generated.UpdateLinkRequest) (*generated.LinkInfo, error) {
+	if request.ShortLink == "" {
+		return nil, errNoShortLink
+	}
+
+	update := &dto.UpdateLinkRequest{
+		Title:       request.Title,
+		Description: request.Description,
+		Notes:       request.Notes,
+	}
+
+	if request.Tags != nil {
+		update.Tags = &request.Tags.Tags
+	}
+
+	if request.OpenGraph != nil {
+		og := openGraphOf(request.OpenGraph)
+		update.OpenGraph = &og
+	}
+
+	if err := update.Validate(); err != nil {
+		return nil, renameFields(err, legacyField)
+	}
+
+	link, err := lgh.usecase.UpdateLink(ctx, request.Domain, request.ShortLink, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return linkInfo(link), nil
+}
+
+func (lgh *LinkGrpcHandler) ListLinks(ctx context.Context, request *generated.ListLinksRequest) (*generated.ListLinksResponse, error) {
+	links, err := lgh.usecase.ListLinks(ctx, request.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &generated.ListLinksResponse{Links: make([]*generated.LinkInfo, 0, len(links))}
+	for _, link := range links {
+		response.Links = append(response.Links, linkInfo(link))
+	}
+
+	return response, nil
+}
+
+func linkInfo(link *model.Link) *generated.LinkInfo {
+	info := &generated.LinkInfo{
+		ShortLink:    link.ShortLink,
+		OriginalLink: link.OriginalLink,
+		Title:        link.Title,
+		Description:  link.Description,
+		Tags:         link.Tags,
+		Notes:        link.Notes,
+		ExpiresAt:    link.ExpiresAt.Format(time.RFC3339),
+	}
+
+	if !link.UTM.Empty() {
+		info.Utm = &generated.UTM{
+			Source:   link.UTM.Source,
+			Medium:   link.UTM.Medium,
+			Campaign: link.UTM.Campaign,
+			Term:     link.UTM.Term,
+			Content:  link.UTM.Content,
+		}
+	}
+
+	if link.Page.IsDefined() {
+		info.Page = &generated.Page{
+			Title:       link.Page.Title,
+			Description: link.Page.Description,
+			Image:       link.Page.Image,
+			Favicon:     link.Page.Favicon,
+		}
+	}
+
+	if link.OpenGraph.IsDefined() {
+		info.OpenGraph = &generated.OpenGraph{
+			Title:       link.OpenGraph.Title,
+			Description: link.OpenGraph.Description,
+			Image:       link.OpenGraph.Image,
+		}
+	}
+
+	if !link.ActiveFrom.IsZero() {
+		info.ActiveFrom = link.ActiveFrom.Format(time.RFC3339)
+	}
+
+	return info
+}
+
+func utmOf(utm *generated.UTM) model.UTM {
+	if utm == nil {
+		return model.UTM{}
+	}
+
+	return model.UTM{
+		Source:   utm.Source,
+		Medium:   utm.Medium,
+		Campaign: utm.Campaign,
+		Term:     utm.Term,
+		Content:  utm.Content,
+	}
+}
+
+func openGraphOf(og *generated.OpenGraph) model.OpenGraph {
+	if og == nil {
+		return model.OpenGraph{}
+	}
+
+	return model.OpenGraph{
+		Title:       og.Title,
+		Description: og.Description,
+		Image:       og.Image,
+	}
+}
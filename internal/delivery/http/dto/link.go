@@ -1,12 +1,108 @@
 package dto
 
-import "time"
+import (
+	"time"
 
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+)
+
+// CreateLinkRequest shortens Link. Alias asks for a token of its own instead
+// of a generated one. TTL is the lifetime of the link in seconds; zero keeps
+// the default.
 type CreateLinkRequest struct {
-	Link string `json:"link"`
+	Link              string          `json:"link" validate:"required,max=2048,weburl"`
+	Domain            string          `json:"domain,omitempty"`
+	Alias             string          `json:"alias,omitempty" validate:"omitempty,alias"`
+	TTL               int64           `json:"ttl,omitempty" validate:"omitempty,min=60,max=31536000"`
+	Password          string          `json:"password,omitempty"`
+	MaxClicks         int64           `json:"max_clicks,omitempty" validate:"min=0"`
+	ActiveFrom        time.Time       `json:"active_from,omitempty"`
+	FallbackLink      string          `json:"fallback_link,omitempty" validate:"omitempty,max=2048,weburl"`
+	Rules             []model.Rule    `json:"rules,omitempty" validate:"max=32,dive"`
+	Variants          []model.Variant `json:"variants,omitempty" validate:"omitempty,min=2,max=16,dive"`
+	ForwardPath       bool            `json:"forward_path,omitempty"`
+	ForwardQuery      model.QueryMode `json:"forward_query,omitempty" validate:"omitempty,oneof=drop append override"`
+	RedirectCode      int             `json:"redirect_code,omitempty" validate:"omitempty,oneof=301 302 307 308"`
+	Interstitial      bool            `json:"interstitial,omitempty"`
+	InterstitialDelay int             `json:"interstitial_delay,omitempty" validate:"min=0,max=30"`
+	Title             string          `json:"title,omitempty" validate:"max=200"`
+	Description       string          `json:"description,omitempty" validate:"max=1000"`
+	Tags              []string        `json:"tags,omitempty" validate:"max=16,dive,min=1,max=64"`
+	Notes             string          `json:"notes,omitempty" validate:"max=4000"`
+	UTMPreset         string          `json:"utm_preset,omitempty"`
+	UTM               model.UTM       `json:"utm,omitempty"`
+	OpenGraph         model.OpenGraph `json:"open_graph,omitempty"`
+}
+
+// UpdateLinkRequest changes the metadata of a link; fields left out keep their value.
+type UpdateLinkRequest struct {
+	Title       *string          `json:"title,omitempty" validate:"omitempty,max=200"`
+	Description *string          `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Tags        *[]string        `json:"tags,omitempty" validate:"omitempty,max=16,dive,min=1,max=64"`
+	Notes       *string          `json:"notes,omitempty" validate:"omitempty,max=4000"`
+	OpenGraph   *model.OpenGraph `json:"open_graph,omitempty"`
 }
 
 type CreateLinkResponse struct {
-	ShortLink string    `json:"short_link"`
-	ExpiresAt time.Time `json:"expires_at"`
+	ShortLink  string     `json:"short_link"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	ActiveFrom *time.Time `json:"active_from,omitempty"`
+}
+
+type PreviewResponse struct {
+	ShortLink   string     `json:"short_link"`
+	Link        string     `json:"link,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	ActiveFrom  *time.Time `json:"active_from,omitempty"`
+}
+
+// LinkResponse describes a link to its owner, notes included.
+type LinkResponse struct {
+	ShortLink   string            `json:"short_link"`
+	Link        string            `json:"link"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Notes       string            `json:"notes,omitempty"`
+	UTM         *model.UTM        `json:"utm,omitempty"`
+	Page        *model.Page       `json:"page,omitempty"`
+	OpenGraph   *model.OpenGraph  `json:"open_graph,omitempty"`
+	Variants    []VariantResponse `json:"variants,omitempty"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+	ActiveFrom  *time.Time        `json:"active_from,omitempty"`
+}
+
+// VariantResponse is a variant of a split link with the visits it was served.
+type VariantResponse struct {
+	Target string `json:"target,omitempty"`
+	Weight int    `json:"weight"`
+	Hits   int64  `json:"hits"`
+}
+
+type ListLinksResponse struct {
+	Links []LinkResponse `json:"links"`
+}
+
+// LinkEventResponse is the data of a link event sent to watchers.
+type LinkEventResponse struct {
+	Type   string        `json:"type"`
+	Domain string        `json:"domain,omitempty"`
+	Token  string        `json:"token"`
+	Time   time.Time     `json:"time"`
+	Link   *LinkResponse `json:"link,omitempty"`
+}
+
+// QRCodeRequest holds the render parameters of a QR code; zero values take the defaults.
+// Fields are named as in the query of the HTTP API.
+//
+//easyjson:skip
+type QRCodeRequest struct {
+	Format     string `json:"format" validate:"omitempty,oneof=png svg"`
+	Size       int    `json:"size" validate:"omitempty,min=64,max=2048"`
+	ECC        string `json:"ecc" validate:"omitempty,oneof=L M Q H"`
+	Margin     *int   `json:"margin" validate:"omitempty,min=0,max=16"`
+	Foreground string `json:"fg" validate:"omitempty,rgb"`
+	Background string `json:"bg" validate:"omitempty,rgb"`
 }
@@ -4,9 +4,11 @@ package dto
 
 import (
 	json "encoding/json"
+	model "github.com/CodeMaster482/ShortLinkAPI/internal/model"
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -17,7 +19,280 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson16eb09bcDecodeShortLinkAPIInternalDeliveryHttpDto(in *jlexer.Lexer, out *CreateLinkResponse) {
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto(in *jlexer.Lexer, out *VariantResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "target":
+			out.Target = string(in.String())
+		case "weight":
+			out.Weight = int(in.Int())
+		case "hits":
+			out.Hits = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto(out *jwriter.Writer, in VariantResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Target != "" {
+		const prefix string = ",\"target\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Target))
+	}
+	{
+		const prefix string = ",\"weight\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Weight))
+	}
+	{
+		const prefix string = ",\"hits\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Hits))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v VariantResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v VariantResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *VariantResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *VariantResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto(l, v)
+}
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto1(in *jlexer.Lexer, out *UpdateLinkRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "title":
+			if in.IsNull() {
+				in.Skip()
+				out.Title = nil
+			} else {
+				if out.Title == nil {
+					out.Title = new(string)
+				}
+				*out.Title = string(in.String())
+			}
+		case "description":
+			if in.IsNull() {
+				in.Skip()
+				out.Description = nil
+			} else {
+				if out.Description == nil {
+					out.Description = new(string)
+				}
+				*out.Description = string(in.String())
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				if out.Tags == nil {
+					out.Tags = new([]string)
+				}
+				if in.IsNull() {
+					in.Skip()
+					*out.Tags = nil
+				} else {
+					in.Delim('[')
+					if *out.Tags == nil {
+						if !in.IsDelim(']') {
+							*out.Tags = make([]string, 0, 4)
+						} else {
+							*out.Tags = []string{}
+						}
+					} else {
+						*out.Tags = (*out.Tags)[:0]
+					}
+					for !in.IsDelim(']') {
+						var v1 string
+						v1 = string(in.String())
+						*out.Tags = append(*out.Tags, v1)
+						in.WantComma()
+					}
+					in.Delim(']')
+				}
+			}
+		case "notes":
+			if in.IsNull() {
+				in.Skip()
+				out.Notes = nil
+			} else {
+				if out.Notes == nil {
+					out.Notes = new(string)
+				}
+				*out.Notes = string(in.String())
+			}
+		case "open_graph":
+			if in.IsNull() {
+				in.Skip()
+				out.OpenGraph = nil
+			} else {
+				if out.OpenGraph == nil {
+					out.OpenGraph = new(model.OpenGraph)
+				}
+				(*out.OpenGraph).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto1(out *jwriter.Writer, in UpdateLinkRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Title != nil {
+		const prefix string = ",\"title\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(*in.Title))
+	}
+	if in.Description != nil {
+		const prefix string = ",\"description\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Description))
+	}
+	if in.Tags != nil {
+		const prefix string = ",\"tags\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if *in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range *in.Tags {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Notes != nil {
+		const prefix string = ",\"notes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Notes))
+	}
+	if in.OpenGraph != nil {
+		const prefix string = ",\"open_graph\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(*in.OpenGraph).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UpdateLinkRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UpdateLinkRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UpdateLinkRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UpdateLinkRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto1(l, v)
+}
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto2(in *jlexer.Lexer, out *PreviewResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -38,10 +313,28 @@ func easyjson16eb09bcDecodeShortLinkAPIInternalDeliveryHttpDto(in *jlexer.Lexer,
 		switch key {
 		case "short_link":
 			out.ShortLink = string(in.String())
+		case "link":
+			out.Link = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		case "description":
+			out.Description = string(in.String())
 		case "expires_at":
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.ExpiresAt).UnmarshalJSON(data))
 			}
+		case "active_from":
+			if in.IsNull() {
+				in.Skip()
+				out.ActiveFrom = nil
+			} else {
+				if out.ActiveFrom == nil {
+					out.ActiveFrom = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ActiveFrom).UnmarshalJSON(data))
+				}
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -52,7 +345,7 @@ func easyjson16eb09bcDecodeShortLinkAPIInternalDeliveryHttpDto(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjson16eb09bcEncodeShortLinkAPIInternalDeliveryHttpDto(out *jwriter.Writer, in CreateLinkResponse) {
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto2(out *jwriter.Writer, in PreviewResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -61,38 +354,591 @@ func easyjson16eb09bcEncodeShortLinkAPIInternalDeliveryHttpDto(out *jwriter.Writ
 		out.RawString(prefix[1:])
 		out.String(string(in.ShortLink))
 	}
+	if in.Link != "" {
+		const prefix string = ",\"link\":"
+		out.RawString(prefix)
+		out.String(string(in.Link))
+	}
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
 	{
 		const prefix string = ",\"expires_at\":"
 		out.RawString(prefix)
 		out.Raw((in.ExpiresAt).MarshalJSON())
 	}
+	if in.ActiveFrom != nil {
+		const prefix string = ",\"active_from\":"
+		out.RawString(prefix)
+		out.Raw((*in.ActiveFrom).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PreviewResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PreviewResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PreviewResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PreviewResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto2(l, v)
+}
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto3(in *jlexer.Lexer, out *ListLinksResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "links":
+			if in.IsNull() {
+				in.Skip()
+				out.Links = nil
+			} else {
+				in.Delim('[')
+				if out.Links == nil {
+					if !in.IsDelim(']') {
+						out.Links = make([]LinkResponse, 0, 0)
+					} else {
+						out.Links = []LinkResponse{}
+					}
+				} else {
+					out.Links = (out.Links)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 LinkResponse
+					(v4).UnmarshalEasyJSON(in)
+					out.Links = append(out.Links, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto3(out *jwriter.Writer, in ListLinksResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"links\":"
+		out.RawString(prefix[1:])
+		if in.Links == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Links {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ListLinksResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ListLinksResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ListLinksResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ListLinksResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto3(l, v)
+}
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto4(in *jlexer.Lexer, out *LinkResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "short_link":
+			out.ShortLink = string(in.String())
+		case "link":
+			out.Link = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 string
+					v7 = string(in.String())
+					out.Tags = append(out.Tags, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "notes":
+			out.Notes = string(in.String())
+		case "utm":
+			if in.IsNull() {
+				in.Skip()
+				out.UTM = nil
+			} else {
+				if out.UTM == nil {
+					out.UTM = new(model.UTM)
+				}
+				(*out.UTM).UnmarshalEasyJSON(in)
+			}
+		case "page":
+			if in.IsNull() {
+				in.Skip()
+				out.Page = nil
+			} else {
+				if out.Page == nil {
+					out.Page = new(model.Page)
+				}
+				(*out.Page).UnmarshalEasyJSON(in)
+			}
+		case "open_graph":
+			if in.IsNull() {
+				in.Skip()
+				out.OpenGraph = nil
+			} else {
+				if out.OpenGraph == nil {
+					out.OpenGraph = new(model.OpenGraph)
+				}
+				(*out.OpenGraph).UnmarshalEasyJSON(in)
+			}
+		case "variants":
+			if in.IsNull() {
+				in.Skip()
+				out.Variants = nil
+			} else {
+				in.Delim('[')
+				if out.Variants == nil {
+					if !in.IsDelim(']') {
+						out.Variants = make([]VariantResponse, 0, 2)
+					} else {
+						out.Variants = []VariantResponse{}
+					}
+				} else {
+					out.Variants = (out.Variants)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v8 VariantResponse
+					(v8).UnmarshalEasyJSON(in)
+					out.Variants = append(out.Variants, v8)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "expires_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+			}
+		case "active_from":
+			if in.IsNull() {
+				in.Skip()
+				out.ActiveFrom = nil
+			} else {
+				if out.ActiveFrom == nil {
+					out.ActiveFrom = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ActiveFrom).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto4(out *jwriter.Writer, in LinkResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"short_link\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ShortLink))
+	}
+	{
+		const prefix string = ",\"link\":"
+		out.RawString(prefix)
+		out.String(string(in.Link))
+	}
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v9, v10 := range in.Tags {
+				if v9 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v10))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Notes != "" {
+		const prefix string = ",\"notes\":"
+		out.RawString(prefix)
+		out.String(string(in.Notes))
+	}
+	if in.UTM != nil {
+		const prefix string = ",\"utm\":"
+		out.RawString(prefix)
+		(*in.UTM).MarshalEasyJSON(out)
+	}
+	if in.Page != nil {
+		const prefix string = ",\"page\":"
+		out.RawString(prefix)
+		(*in.Page).MarshalEasyJSON(out)
+	}
+	if in.OpenGraph != nil {
+		const prefix string = ",\"open_graph\":"
+		out.RawString(prefix)
+		(*in.OpenGraph).MarshalEasyJSON(out)
+	}
+	if len(in.Variants) != 0 {
+		const prefix string = ",\"variants\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v11, v12 := range in.Variants {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				(v12).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	if in.ActiveFrom != nil {
+		const prefix string = ",\"active_from\":"
+		out.RawString(prefix)
+		out.Raw((*in.ActiveFrom).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LinkResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LinkResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LinkResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LinkResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto4(l, v)
+}
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto5(in *jlexer.Lexer, out *LinkEventResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "domain":
+			out.Domain = string(in.String())
+		case "token":
+			out.Token = string(in.String())
+		case "time":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Time).UnmarshalJSON(data))
+			}
+		case "link":
+			if in.IsNull() {
+				in.Skip()
+				out.Link = nil
+			} else {
+				if out.Link == nil {
+					out.Link = new(LinkResponse)
+				}
+				(*out.Link).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto5(out *jwriter.Writer, in LinkEventResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	if in.Domain != "" {
+		const prefix string = ",\"domain\":"
+		out.RawString(prefix)
+		out.String(string(in.Domain))
+	}
+	{
+		const prefix string = ",\"token\":"
+		out.RawString(prefix)
+		out.String(string(in.Token))
+	}
+	{
+		const prefix string = ",\"time\":"
+		out.RawString(prefix)
+		out.Raw((in.Time).MarshalJSON())
+	}
+	if in.Link != nil {
+		const prefix string = ",\"link\":"
+		out.RawString(prefix)
+		(*in.Link).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LinkEventResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LinkEventResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LinkEventResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LinkEventResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto5(l, v)
+}
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto6(in *jlexer.Lexer, out *CreateLinkResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "short_link":
+			out.ShortLink = string(in.String())
+		case "expires_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ExpiresAt).UnmarshalJSON(data))
+			}
+		case "active_from":
+			if in.IsNull() {
+				in.Skip()
+				out.ActiveFrom = nil
+			} else {
+				if out.ActiveFrom == nil {
+					out.ActiveFrom = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ActiveFrom).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto6(out *jwriter.Writer, in CreateLinkResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"short_link\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ShortLink))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpiresAt).MarshalJSON())
+	}
+	if in.ActiveFrom != nil {
+		const prefix string = ",\"active_from\":"
+		out.RawString(prefix)
+		out.Raw((*in.ActiveFrom).MarshalJSON())
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v CreateLinkResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson16eb09bcEncodeShortLinkAPIInternalDeliveryHttpDto(&w, v)
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CreateLinkResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson16eb09bcEncodeShortLinkAPIInternalDeliveryHttpDto(w, v)
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CreateLinkResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson16eb09bcDecodeShortLinkAPIInternalDeliveryHttpDto(&r, v)
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CreateLinkResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson16eb09bcDecodeShortLinkAPIInternalDeliveryHttpDto(l, v)
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto6(l, v)
 }
-func easyjson16eb09bcDecodeShortLinkAPIInternalDeliveryHttpDto1(in *jlexer.Lexer, out *CreateLinkRequest) {
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto7(in *jlexer.Lexer, out *CreateLinkRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -113,6 +959,113 @@ func easyjson16eb09bcDecodeShortLinkAPIInternalDeliveryHttpDto1(in *jlexer.Lexer
 		switch key {
 		case "link":
 			out.Link = string(in.String())
+		case "domain":
+			out.Domain = string(in.String())
+		case "alias":
+			out.Alias = string(in.String())
+		case "ttl":
+			out.TTL = int64(in.Int64())
+		case "password":
+			out.Password = string(in.String())
+		case "max_clicks":
+			out.MaxClicks = int64(in.Int64())
+		case "active_from":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ActiveFrom).UnmarshalJSON(data))
+			}
+		case "fallback_link":
+			out.FallbackLink = string(in.String())
+		case "rules":
+			if in.IsNull() {
+				in.Skip()
+				out.Rules = nil
+			} else {
+				in.Delim('[')
+				if out.Rules == nil {
+					if !in.IsDelim(']') {
+						out.Rules = make([]model.Rule, 0, 0)
+					} else {
+						out.Rules = []model.Rule{}
+					}
+				} else {
+					out.Rules = (out.Rules)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 model.Rule
+					(v13).UnmarshalEasyJSON(in)
+					out.Rules = append(out.Rules, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "variants":
+			if in.IsNull() {
+				in.Skip()
+				out.Variants = nil
+			} else {
+				in.Delim('[')
+				if out.Variants == nil {
+					if !in.IsDelim(']') {
+						out.Variants = make([]model.Variant, 0, 2)
+					} else {
+						out.Variants = []model.Variant{}
+					}
+				} else {
+					out.Variants = (out.Variants)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v14 model.Variant
+					(v14).UnmarshalEasyJSON(in)
+					out.Variants = append(out.Variants, v14)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "forward_path":
+			out.ForwardPath = bool(in.Bool())
+		case "forward_query":
+			out.ForwardQuery = model.QueryMode(in.String())
+		case "redirect_code":
+			out.RedirectCode = int(in.Int())
+		case "interstitial":
+			out.Interstitial = bool(in.Bool())
+		case "interstitial_delay":
+			out.InterstitialDelay = int(in.Int())
+		case "title":
+			out.Title = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v15 string
+					v15 = string(in.String())
+					out.Tags = append(out.Tags, v15)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "notes":
+			out.Notes = string(in.String())
+		case "utm_preset":
+			out.UTMPreset = string(in.String())
+		case "utm":
+			(out.UTM).UnmarshalEasyJSON(in)
+		case "open_graph":
+			(out.OpenGraph).UnmarshalEasyJSON(in)
 		default:
 			in.SkipRecursive()
 		}
@@ -123,7 +1076,7 @@ func easyjson16eb09bcDecodeShortLinkAPIInternalDeliveryHttpDto1(in *jlexer.Lexer
 		in.Consumed()
 	}
 }
-func easyjson16eb09bcEncodeShortLinkAPIInternalDeliveryHttpDto1(out *jwriter.Writer, in CreateLinkRequest) {
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto7(out *jwriter.Writer, in CreateLinkRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -132,29 +1085,161 @@ func easyjson16eb09bcEncodeShortLinkAPIInternalDeliveryHttpDto1(out *jwriter.Wri
 		out.RawString(prefix[1:])
 		out.String(string(in.Link))
 	}
+	if in.Domain != "" {
+		const prefix string = ",\"domain\":"
+		out.RawString(prefix)
+		out.String(string(in.Domain))
+	}
+	if in.Alias != "" {
+		const prefix string = ",\"alias\":"
+		out.RawString(prefix)
+		out.String(string(in.Alias))
+	}
+	if in.TTL != 0 {
+		const prefix string = ",\"ttl\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TTL))
+	}
+	if in.Password != "" {
+		const prefix string = ",\"password\":"
+		out.RawString(prefix)
+		out.String(string(in.Password))
+	}
+	if in.MaxClicks != 0 {
+		const prefix string = ",\"max_clicks\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MaxClicks))
+	}
+	if true {
+		const prefix string = ",\"active_from\":"
+		out.RawString(prefix)
+		out.Raw((in.ActiveFrom).MarshalJSON())
+	}
+	if in.FallbackLink != "" {
+		const prefix string = ",\"fallback_link\":"
+		out.RawString(prefix)
+		out.String(string(in.FallbackLink))
+	}
+	if len(in.Rules) != 0 {
+		const prefix string = ",\"rules\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v16, v17 := range in.Rules {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				(v17).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Variants) != 0 {
+		const prefix string = ",\"variants\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v18, v19 := range in.Variants {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				(v19).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.ForwardPath {
+		const prefix string = ",\"forward_path\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.ForwardPath))
+	}
+	if in.ForwardQuery != "" {
+		const prefix string = ",\"forward_query\":"
+		out.RawString(prefix)
+		out.String(string(in.ForwardQuery))
+	}
+	if in.RedirectCode != 0 {
+		const prefix string = ",\"redirect_code\":"
+		out.RawString(prefix)
+		out.Int(int(in.RedirectCode))
+	}
+	if in.Interstitial {
+		const prefix string = ",\"interstitial\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Interstitial))
+	}
+	if in.InterstitialDelay != 0 {
+		const prefix string = ",\"interstitial_delay\":"
+		out.RawString(prefix)
+		out.Int(int(in.InterstitialDelay))
+	}
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v20, v21 := range in.Tags {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v21))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Notes != "" {
+		const prefix string = ",\"notes\":"
+		out.RawString(prefix)
+		out.String(string(in.Notes))
+	}
+	if in.UTMPreset != "" {
+		const prefix string = ",\"utm_preset\":"
+		out.RawString(prefix)
+		out.String(string(in.UTMPreset))
+	}
+	if (in.UTM).IsDefined() {
+		const prefix string = ",\"utm\":"
+		out.RawString(prefix)
+		(in.UTM).MarshalEasyJSON(out)
+	}
+	if (in.OpenGraph).IsDefined() {
+		const prefix string = ",\"open_graph\":"
+		out.RawString(prefix)
+		(in.OpenGraph).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v CreateLinkRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson16eb09bcEncodeShortLinkAPIInternalDeliveryHttpDto1(&w, v)
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CreateLinkRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson16eb09bcEncodeShortLinkAPIInternalDeliveryHttpDto1(w, v)
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CreateLinkRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson16eb09bcDecodeShortLinkAPIInternalDeliveryHttpDto1(&r, v)
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CreateLinkRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson16eb09bcDecodeShortLinkAPIInternalDeliveryHttpDto1(l, v)
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto7(l, v)
 }
@@ -0,0 +1,237 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson11659187DecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto(in *jlexer.Lexer, out *Problem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		case "status":
+			out.Status = int(in.Int())
+		case "detail":
+			out.Detail = string(in.String())
+		case "instance":
+			out.Instance = string(in.String())
+		case "request_id":
+			out.RequestID = string(in.String())
+		case "invalid-params":
+			if in.IsNull() {
+				in.Skip()
+				out.InvalidParams = nil
+			} else {
+				in.Delim('[')
+				if out.InvalidParams == nil {
+					if !in.IsDelim(']') {
+						out.InvalidParams = make([]InvalidParam, 0, 1)
+					} else {
+						out.InvalidParams = []InvalidParam{}
+					}
+				} else {
+					out.InvalidParams = (out.InvalidParams)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 InvalidParam
+					(v1).UnmarshalEasyJSON(in)
+					out.InvalidParams = append(out.InvalidParams, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson11659187EncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto(out *jwriter.Writer, in Problem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int(int(in.Status))
+	}
+	if in.Detail != "" {
+		const prefix string = ",\"detail\":"
+		out.RawString(prefix)
+		out.String(string(in.Detail))
+	}
+	if in.Instance != "" {
+		const prefix string = ",\"instance\":"
+		out.RawString(prefix)
+		out.String(string(in.Instance))
+	}
+	if in.RequestID != "" {
+		const prefix string = ",\"request_id\":"
+		out.RawString(prefix)
+		out.String(string(in.RequestID))
+	}
+	if len(in.InvalidParams) != 0 {
+		const prefix string = ",\"invalid-params\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.InvalidParams {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Problem) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson11659187EncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Problem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson11659187EncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Problem) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson11659187DecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Problem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson11659187DecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto(l, v)
+}
+func easyjson11659187DecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto1(in *jlexer.Lexer, out *InvalidParam) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "code":
+			out.Code = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson11659187EncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto1(out *jwriter.Writer, in InvalidParam) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v InvalidParam) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson11659187EncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v InvalidParam) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson11659187EncodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *InvalidParam) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson11659187DecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *InvalidParam) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson11659187DecodeGithubComCodeMaster482ShortLinkAPIInternalDeliveryHttpDto1(l, v)
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"regexp"
+
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/validator"
+)
+
+// requestValidator checks requests against the rules in their validate tags.
+// HTTP and gRPC handlers share it, so both report the same violations.
+// Colours are given as rgb: six hex digits, optionally after a '#'.
+// Aliases are 3 to 64 letters, digits and underscores.
+var requestValidator = validator.New(
+	validator.Pattern("rgb", regexp.MustCompile(`^#?[0-9A-Fa-f]{6}$`)),
+	validator.Pattern("alias", regexp.MustCompile(`^[A-Za-z0-9_]{3,64}$`)),
+)
+
+// Validate reports every field of the request that breaks its rules.
+func (r *CreateLinkRequest) Validate() error {
+	return requestValidator.Struct(r)
+}
+
+// Validate reports every field of the request that breaks its rules.
+func (r *UpdateLinkRequest) Validate() error {
+	return requestValidator.Struct(r)
+}
+
+// Validate reports every field of the request that breaks its rules.
+func (r *CreateWebhookRequest) Validate() error {
+	return requestValidator.Struct(r)
+}
+
+// Validate reports every render parameter of the request that breaks its rules.
+func (r *QRCodeRequest) Validate() error {
+	return requestValidator.Struct(r)
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CreateWebhookRequest registers a URL notified of the given link events and
+// of links reaching any of the click thresholds. A secret is generated when
+// none is given.
+type CreateWebhookRequest struct {
+	URL             string   `json:"url" validate:"required,max=2048,weburl"`
+	Secret          string   `json:"secret,omitempty" validate:"omitempty,min=16,max=256"`
+	Events          []string `json:"events,omitempty" validate:"dive,oneof=link.created link.updated link.expired link.deleted"`
+	ClickThresholds []int64  `json:"click_thresholds,omitempty" validate:"max=16,dive,gt=0"`
+}
+
+// WebhookResponse describes a webhook. The secret is only returned once,
+// when the webhook is created.
+type WebhookResponse struct {
+	ID              string    `json:"id"`
+	URL             string    `json:"url"`
+	Secret          string    `json:"secret,omitempty"`
+	Events          []string  `json:"events,omitempty"`
+	ClickThresholds []int64   `json:"click_thresholds,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type ListWebhooksResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+// WebhookDeliveryResponse describes a request to a webhook that was given up on.
+type WebhookDeliveryResponse struct {
+	ID        string          `json:"id"`
+	Event     string          `json:"event"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// eventsHeartbeat is how often idle event streams send a comment, so proxies
+// in between keep the connection open.
+const eventsHeartbeat = 15 * time.Second
+
+// WatchLinks streams link events as server-sent events. Clients resume after
+// the event named by the Last-Event-ID header, which EventSource sends when
+// it reconnects, or by the cursor query parameter.
+func (h *LinkHandler) WatchLinks(ctx *gin.Context) {
+	cursor := ctx.GetHeader("Last-Event-ID")
+	if cursor == "" {
+		cursor = ctx.Query("cursor")
+	}
+
+	events, err := h.usecase.WatchLinks(ctx.Request.Context(), cursor)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	// The stream outlives the write timeout of the server.
+	_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			if err := writeEvent(ctx.Writer, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(ctx.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+
+		ctx.Writer.Flush()
+	}
+}
+
+func writeEvent(w io.Writer, event *model.LinkEvent) error {
+	response := &dto.LinkEventResponse{
+		Type:   string(event.Type),
+		Domain: event.Domain,
+		Token:  event.Token,
+		Time:   event.Time,
+	}
+
+	if event.Link != nil {
+		response.Link = linkResponse(event.Link)
+	}
+
+	data, err := response.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.Cursor, event.Type, data)
+
+	return err
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mock_handler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/handler/mocks"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/middleware"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+)
+
+func TestWatchLinks(t *testing.T) {
+	eventTime := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	events := func(events ...*model.LinkEvent) <-chan *model.LinkEvent {
+		ch := make(chan *model.LinkEvent, len(events))
+		for _, event := range events {
+			ch <- event
+		}
+		close(ch)
+
+		return ch
+	}
+
+	testCases := []struct {
+		name           string
+		target         string
+		lastEventID    string
+		expectedStatus int
+		expectedBody   string
+		mockBehaviour  func(usecase *mock_handler.MockLinkUsecase)
+	}{
+		{
+			name:           "Events",
+			target:         "/events",
+			expectedStatus: http.StatusOK,
+			expectedBody: "id: c-1\nevent: created\n" +
+				`data: {"type":"created","token":"abc123","time":"2024-03-01T12:00:00Z","link":{"short_link":"https://sho.rt/abc123","link":"https://example.com","expires_at":"0001-01-01T00:00:00Z"}}` + "\n\n" +
+				"id: c-2\nevent: expired\n" +
+				`data: {"type":"expired","domain":"go.example.com","token":"old","time":"2024-03-01T12:00:00Z"}` + "\n\n",
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().WatchLinks(gomock.Any(), "").Return(events(
+					&model.LinkEvent{
+						Cursor: "c-1",
+						Type:   model.LinkCreated,
+						Token:  "abc123",
+						Time:   eventTime,
+						Link:   &model.Link{ShortLink: "https://sho.rt/abc123", OriginalLink: "https://example.com"},
+					},
+					&model.LinkEvent{Cursor: "c-2", Type: model.LinkExpired, Domain: "go.example.com", Token: "old", Time: eventTime},
+				), nil)
+			},
+		},
+		{
+			name:           "Last-Event-ID",
+			target:         "/events?cursor=ignored",
+			lastEventID:    "c-1",
+			expectedStatus: http.StatusOK,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().WatchLinks(gomock.Any(), "c-1").Return(events(), nil)
+			},
+		},
+		{
+			name:           "Cursor",
+			target:         "/events?cursor=c-1",
+			expectedStatus: http.StatusOK,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().WatchLinks(gomock.Any(), "c-1").Return(events(), nil)
+			},
+		},
+		{
+			name:           "Expired cursor",
+			target:         "/events?cursor=c-0",
+			expectedStatus: http.StatusGone,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:cursor-expired","title":"cursor expired","status":410,"instance":"/events"}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().WatchLinks(gomock.Any(), "c-0").
+					Return(nil, apierror.NewAPIError(apierror.ErrCursorExpired, nil))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			usecase := mock_handler.NewMockLinkUsecase(ctrl)
+			handler := NewLinkHandler(usecase)
+
+			router := gin.New()
+			router.Use(middleware.ErrorMiddleware())
+			router.GET("/events", handler.WatchLinks)
+
+			test.mockBehaviour(usecase)
+
+			req := httptest.NewRequest(http.MethodGet, test.target, http.NoBody)
+			if test.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", test.lastEventID)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatus {
+				t.Errorf("expected status %d; got %d", test.expectedStatus, w.Code)
+			}
+
+			if test.expectedStatus == http.StatusOK && !strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream") {
+				t.Errorf("expected an event stream; got %q", w.Header().Get("Content-Type"))
+			}
+
+			if test.expectedBody != "" && w.Body.String() != test.expectedBody {
+				t.Errorf("expected body %q; got %q", test.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/peer"
+)
+
+// Gateway serves the request with h, a bridge to the gRPC API such as the
+// JSON gateway or the Connect handler. The client IP gin resolves is passed
+// on as the gRPC peer, so visits resolved through the bridge are attributed
+// like the ones resolved by LinkHandler.
+func Gateway(h http.Handler) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ip := net.ParseIP(ctx.ClientIP()); ip != nil {
+			p := &peer.Peer{Addr: &net.TCPAddr{IP: ip}}
+			ctx.Request = ctx.Request.WithContext(peer.NewContext(ctx.Request.Context(), p))
+		}
+
+		h.ServeHTTP(ctx.Writer, ctx.Request)
+	}
+}
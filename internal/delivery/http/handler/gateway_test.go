@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/peer"
+)
+
+func TestGateway(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	var got net.Addr
+
+	gateway := Gateway(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p, ok := peer.FromContext(r.Context()); ok {
+			got = p.Addr
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	router := gin.New()
+	router.GET("/:key", func(ctx *gin.Context) { ctx.Status(http.StatusFound) })
+	router.GET("/:key/*path", func(ctx *gin.Context) { ctx.Status(http.StatusFound) })
+	router.GET("/api/v2/links/:token", gateway)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/links/token", http.NoBody)
+	req.RemoteAddr = "203.0.113.7:51234"
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d; got %d", http.StatusNoContent, w.Code)
+	}
+
+	addr, ok := got.(*net.TCPAddr)
+	if !ok || addr.IP.String() != "203.0.113.7" {
+		t.Errorf("expected peer 203.0.113.7; got %v", got)
+	}
+}
@@ -2,23 +2,48 @@ package handler
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
 	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
 	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/useragent"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mailru/easyjson"
 )
 
+// VisitorCookie keeps a visitor on the same variant of split links across visits.
+const VisitorCookie = "sl_visitor"
+
+const visitorCookieMaxAge = 365 * 24 * 60 * 60
+
 type LinkHandler struct {
 	usecase LinkUsecase
 }
 
 type LinkUsecase interface {
-	GetFullLink(ctx context.Context, token string) (string, error)
+	GetFullLink(ctx context.Context, domain, token string, visit *model.Visit) (*model.Redirect, error)
+	UnlockLink(ctx context.Context, domain, token, password string, visit *model.Visit) (*model.Redirect, error)
+	PreviewLink(ctx context.Context, domain, token string) (*model.Link, error)
+	GetQRCode(ctx context.Context, domain, token string, request *dto.QRCodeRequest) (*model.QRCode, error)
+	UpdateLink(ctx context.Context, domain, token string, request *dto.UpdateLinkRequest) (*model.Link, error)
+	DeleteLink(ctx context.Context, domain, token string) error
+	ListLinks(ctx context.Context, tag string) ([]*model.Link, error)
 	CreateShortLink(ctx context.Context, linkRequest *dto.CreateLinkRequest) (*model.Link, error)
+	WatchLinks(ctx context.Context, cursor string) (<-chan *model.LinkEvent, error)
+}
+
+type WebhookUsecase interface {
+	CreateWebhook(ctx context.Context, request *dto.CreateWebhookRequest) (*model.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	ListDeadDeliveries(ctx context.Context, id string) ([]*model.WebhookDelivery, error)
 }
 
 func NewLinkHandler(usecase LinkUsecase) *LinkHandler {
@@ -33,13 +58,92 @@ func (h *LinkHandler) GetLink(ctx *gin.Context) {
 		return
 	}
 
-	link, err := h.usecase.GetFullLink(ctx.Request.Context(), token)
+	if token, ok := strings.CutSuffix(token, PreviewSuffix); ok && token != "" {
+		h.previewLink(ctx, token)
+		return
+	}
+
+	// Humans and link preview bots get different responses from the same URL.
+	ctx.Header("Vary", "User-Agent")
+
+	if ctx.GetHeader(PasswordHeader) == "" && useragent.LinkPreviewer(ctx.Request.UserAgent()) && h.socialPreview(ctx, token) {
+		return
+	}
+
+	var (
+		redirect *model.Redirect
+		err      error
+		visit    = visitOf(ctx)
+	)
+
+	if password := ctx.GetHeader(PasswordHeader); password != "" {
+		redirect, err = h.usecase.UnlockLink(ctx.Request.Context(), ctx.Request.Host, token, password, visit)
+	} else {
+		redirect, err = h.usecase.GetFullLink(ctx.Request.Context(), ctx.Request.Host, token, visit)
+	}
+
 	if err != nil {
-		_ = ctx.Error(err)
+		h.redirectError(ctx, redirect, err)
 		return
 	}
 
-	ctx.Redirect(http.StatusFound, link)
+	keepVisitor(ctx, redirect, visit)
+	h.redirect(ctx, redirect)
+}
+
+// visitOf collects the request attributes redirect rules match on. Browsers
+// that do not have a visitor ID yet get a new one for this visit.
+func visitOf(ctx *gin.Context) *model.Visit {
+	visitorID, err := ctx.Cookie(VisitorCookie)
+	if err != nil || visitorID == "" {
+		visitorID = newVisitorID()
+	}
+
+	return &model.Visit{
+		VisitorID:      visitorID,
+		Path:           ctx.Param("path"),
+		UserAgent:      ctx.Request.UserAgent(),
+		AcceptLanguage: ctx.GetHeader("Accept-Language"),
+		IP:             net.ParseIP(ctx.ClientIP()),
+		Query:          ctx.Request.URL.Query(),
+	}
+}
+
+// keepVisitor hands the visitor ID out to browsers that do not have it yet
+// when they were sent to a variant. Only those redirects are never stored by
+// caches, so no shared cache replays the cookie to another visitor.
+func keepVisitor(ctx *gin.Context, r *model.Redirect, visit *model.Visit) {
+	if !r.Sticky {
+		return
+	}
+
+	if visitorID, err := ctx.Cookie(VisitorCookie); err == nil && visitorID == visit.VisitorID {
+		return
+	}
+
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(VisitorCookie, visit.VisitorID, visitorCookieMaxAge, "/", "", ctx.Request.TLS != nil, true)
+}
+
+func newVisitorID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// redirectError sends visitors of a scheduled link to its fallback and asks for
+// the password of protected links; other errors go to the error middleware.
+func (h *LinkHandler) redirectError(ctx *gin.Context, fallback *model.Redirect, err error) {
+	switch {
+	case errors.Is(err, apierror.ErrLinkNotActive) && fallback != nil:
+		ctx.Header("Cache-Control", "no-store")
+		ctx.Redirect(fallback.Code, fallback.Target)
+	case isPasswordError(err):
+		h.passwordError(ctx, err)
+	default:
+		_ = ctx.Error(err)
+	}
 }
 
 func (h *LinkHandler) CreateLink(ctx *gin.Context) {
@@ -49,8 +153,8 @@ func (h *LinkHandler) CreateLink(ctx *gin.Context) {
 		return
 	}
 
-	if request.Link == "" {
-		_ = ctx.Error(apierror.BadRequestError())
+	if err := request.Validate(); err != nil {
+		_ = ctx.Error(err)
 		return
 	}
 
@@ -65,6 +169,10 @@ func (h *LinkHandler) CreateLink(ctx *gin.Context) {
 		ExpiresAt: link.ExpiresAt,
 	}
 
+	if !link.ActiveFrom.IsZero() {
+		response.ActiveFrom = &link.ActiveFrom
+	}
+
 	responseJSON, err := response.MarshalJSON()
 	if err != nil {
 		_ = ctx.Error(err)
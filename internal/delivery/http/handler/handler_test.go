@@ -5,6 +5,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -34,7 +35,7 @@ func TestGetLink(t *testing.T) {
 			expectedHeader: "https://example.com",
 			expectedBody:   "",
 			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
-				usecase.EXPECT().GetFullLink(gomock.Any(), "validToken").Return("https://example.com", nil).Times(1)
+				usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "validToken", gomock.Any()).Return(&model.Redirect{Target: "https://example.com", Code: http.StatusFound}, nil).Times(1)
 			},
 		},
 		{
@@ -44,17 +45,28 @@ func TestGetLink(t *testing.T) {
 			expectedHeader: "",
 			expectedBody:   "404 page not found",
 			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
-				usecase.EXPECT().GetFullLink(gomock.Any(), "").Times(0)
+				usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "", gomock.Any()).Times(0)
 			},
 		},
 		{
 			name:           "Not Found Token",
 			token:          "token",
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusNotFound,
 			expectedHeader: "",
 			expectedBody:   "",
 			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
-				usecase.EXPECT().GetFullLink(gomock.Any(), "token").Return("", apierror.ErrLinkNotFound).Times(1)
+				usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "token", gomock.Any()).Return(nil, apierror.ErrLinkNotFound).Times(1)
+			},
+		},
+		{
+			name:           "Exhausted Token",
+			token:          "burned",
+			expectedStatus: http.StatusGone,
+			expectedHeader: "",
+			expectedBody:   `{"type":"urn:problem-type:shortlink:link-exhausted","title":"link is no longer available","status":410,"instance":"/burned"}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "burned", gomock.Any()).
+					Return(nil, apierror.NewAPIError(apierror.ErrLinkExhausted, nil)).Times(1)
 			},
 		},
 	}
@@ -130,8 +142,8 @@ func TestCreateLink(t *testing.T) {
 		{
 			name:           "Creation Error",
 			requestBody:    `{"link":"https://example.com"}`,
-			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"message":"internal server error","status":500}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:unable-to-create-link","title":"unable to create link","status":409,"instance":"/url"}`,
 			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
 				usecase.EXPECT().CreateShortLink(
 					gomock.Any(),
@@ -145,14 +157,28 @@ func TestCreateLink(t *testing.T) {
 			name:           "Corruted Request Body",
 			requestBody:    `{"link":"https://example.co`,
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"message":"bad request","status":400}`,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:bad-request","title":"bad request","status":400,"instance":"/url"}`,
 			mockBehaviour:  func(usecase *mock_handler.MockLinkUsecase) {},
 		},
 		{
 			name:           "Empty link value",
 			requestBody:    `{"link":""}`,
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"message":"bad request","status":400}`,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:bad-request","title":"bad request","status":400,"detail":"link: is required","instance":"/url","invalid-params":[{"name":"link","code":"required","reason":"is required"}]}`,
+			mockBehaviour:  func(usecase *mock_handler.MockLinkUsecase) {},
+		},
+		{
+			name:           "Every invalid field",
+			requestBody:    `{"link":"mailto:someone@example.com","max_clicks":-1,"rules":[{"os":"ios","target":"/ios"}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:bad-request","title":"bad request","status":400,"detail":"link: must be an absolute URL; max_clicks: must be at least 0; rules[0].target: must be an absolute URL","instance":"/url","invalid-params":[{"name":"link","code":"invalid_url","reason":"must be an absolute URL"},{"name":"max_clicks","code":"too_small","reason":"must be at least 0"},{"name":"rules[0].target","code":"invalid_url","reason":"must be an absolute URL"}]}`,
+			mockBehaviour:  func(usecase *mock_handler.MockLinkUsecase) {},
+		},
+		{
+			name:           "Alias and TTL out of bounds",
+			requestBody:    `{"link":"https://example.com","alias":"spring sale","ttl":5}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:bad-request","title":"bad request","status":400,"detail":"alias: must match ^[A-Za-z0-9_]{3,64}$; ttl: must be at least 60","instance":"/url","invalid-params":[{"name":"alias","code":"pattern_mismatch","reason":"must match ^[A-Za-z0-9_]{3,64}$"},{"name":"ttl","code":"too_small","reason":"must be at least 60"}]}`,
 			mockBehaviour:  func(usecase *mock_handler.MockLinkUsecase) {},
 		},
 	}
@@ -193,3 +219,349 @@ func TestCreateLink(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLink_Protected(t *testing.T) {
+	testCases := []struct {
+		name             string
+		accept           string
+		password         string
+		expectedStatus   int
+		expectedLocation string
+		expectedBody     string
+		mockBehaviour    func(usecase *mock_handler.MockLinkUsecase)
+	}{
+		{
+			name:           "Browser gets unlock form",
+			accept:         "text/html,application/xhtml+xml",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `<form method="post">`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+					Return(nil, apierror.NewAPIError(apierror.ErrPasswordRequired, nil))
+			},
+		},
+		{
+			name:           "API client gets JSON error",
+			accept:         "application/json",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:password-required","title":"password required","status":401,"instance":"/token"}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+					Return(nil, apierror.NewAPIError(apierror.ErrPasswordRequired, nil))
+			},
+		},
+		{
+			name:             "Password header",
+			password:         "secret",
+			expectedStatus:   http.StatusFound,
+			expectedLocation: "https://example.com",
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().UnlockLink(gomock.Any(), gomock.Any(), "token", "secret", gomock.Any()).Return(&model.Redirect{Target: "https://example.com", Code: http.StatusFound}, nil)
+			},
+		},
+		{
+			name:           "Wrong password header",
+			password:       "guess",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:password-invalid","title":"invalid password","status":403,"instance":"/token"}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().UnlockLink(gomock.Any(), gomock.Any(), "token", "guess", gomock.Any()).
+					Return(nil, apierror.NewAPIError(apierror.ErrPasswordInvalid, nil))
+			},
+		},
+		{
+			name:             "Not active with fallback",
+			expectedStatus:   http.StatusFound,
+			expectedLocation: "https://example.com/soon",
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+					Return(&model.Redirect{Target: "https://example.com/soon", Code: http.StatusFound}, apierror.NewAPIError(apierror.ErrLinkNotActive, nil))
+			},
+		},
+		{
+			name:           "Not active without fallback",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:link-not-active","title":"link is not active yet","status":403,"instance":"/token"}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+					Return(nil, apierror.NewAPIError(apierror.ErrLinkNotActive, nil))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			usecase := mock_handler.NewMockLinkUsecase(ctrl)
+			handler := NewLinkHandler(usecase)
+
+			router := gin.New()
+			router.Use(middleware.ErrorMiddleware())
+			router.GET("/:key", handler.GetLink)
+
+			test.mockBehaviour(usecase)
+
+			req := httptest.NewRequest(http.MethodGet, "/token", http.NoBody)
+			req.Header.Set("Accept", test.accept)
+
+			if test.password != "" {
+				req.Header.Set(PasswordHeader, test.password)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatus {
+				t.Errorf("expected status %d; got %d", test.expectedStatus, w.Code)
+			}
+
+			if test.expectedLocation != "" && w.Header().Get("Location") != test.expectedLocation {
+				t.Errorf("expected location %q; got %q", test.expectedLocation, w.Header().Get("Location"))
+			}
+
+			if !strings.Contains(w.Body.String(), test.expectedBody) {
+				t.Errorf("expected body to contain %q; got %q", test.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUnlockLink(t *testing.T) {
+	testCases := []struct {
+		name             string
+		form             string
+		expectedStatus   int
+		expectedLocation string
+		expectedBody     string
+		mockBehaviour    func(usecase *mock_handler.MockLinkUsecase)
+	}{
+		{
+			name:             "Correct password",
+			form:             "password=secret",
+			expectedStatus:   http.StatusSeeOther,
+			expectedLocation: "https://example.com",
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().UnlockLink(gomock.Any(), gomock.Any(), "token", "secret", gomock.Any()).Return(&model.Redirect{Target: "https://example.com", Code: http.StatusFound}, nil)
+			},
+		},
+		{
+			name:           "Wrong password",
+			form:           "password=guess",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   "invalid password",
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().UnlockLink(gomock.Any(), gomock.Any(), "token", "guess", gomock.Any()).
+					Return(nil, apierror.NewAPIError(apierror.ErrPasswordInvalid, nil))
+			},
+		},
+		{
+			name:           "Throttled",
+			form:           "password=guess",
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody:   "too many attempts",
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().UnlockLink(gomock.Any(), gomock.Any(), "token", "guess", gomock.Any()).
+					Return(nil, apierror.NewAPIError(apierror.ErrTooManyAttempts, nil))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			usecase := mock_handler.NewMockLinkUsecase(ctrl)
+			handler := NewLinkHandler(usecase)
+
+			router := gin.New()
+			router.Use(middleware.ErrorMiddleware())
+			router.POST("/:key", handler.UnlockLink)
+
+			test.mockBehaviour(usecase)
+
+			req := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(test.form))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("Accept", "text/html")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatus {
+				t.Errorf("expected status %d; got %d", test.expectedStatus, w.Code)
+			}
+
+			if test.expectedLocation != "" && w.Header().Get("Location") != test.expectedLocation {
+				t.Errorf("expected location %q; got %q", test.expectedLocation, w.Header().Get("Location"))
+			}
+
+			if !strings.Contains(w.Body.String(), test.expectedBody) {
+				t.Errorf("expected body to contain %q; got %q", test.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetLink_Visit(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gin.SetMode(gin.TestMode)
+	usecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := NewLinkHandler(usecase)
+
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.GET("/:key", handler.GetLink)
+	router.GET("/:key/*path", handler.GetLink)
+
+	usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+		DoAndReturn(func(_ context.Context, domain, _ string, visit *model.Visit) (*model.Redirect, error) {
+			if domain != "example.com" {
+				t.Errorf("expected domain example.com; got %s", domain)
+			}
+
+			if visit.UserAgent != "test-agent" || visit.AcceptLanguage != "de-DE" {
+				t.Errorf("unexpected headers in visit: %+v", visit)
+			}
+
+			if visit.IP.String() != "192.0.2.1" {
+				t.Errorf("expected ip 192.0.2.1; got %s", visit.IP)
+			}
+
+			if visit.Path != "/docs/page" {
+				t.Errorf("expected path /docs/page; got %q", visit.Path)
+			}
+
+			if visit.Query.Get("ref") != "mail" {
+				t.Errorf("expected query ref=mail; got %v", visit.Query)
+			}
+
+			return &model.Redirect{Target: "https://example.com", Code: http.StatusFound, Sticky: true}, nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/token/docs/page?ref=mail", http.NoBody)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("User-Agent", "test-agent")
+	req.Header.Set("Accept-Language", "de-DE")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusFound {
+		t.Errorf("expected status %d; got %d", http.StatusFound, w.Code)
+	}
+
+	if !strings.HasPrefix(w.Header().Get("Set-Cookie"), VisitorCookie+"=") {
+		t.Errorf("expected a visitor cookie; got %q", w.Header().Get("Set-Cookie"))
+	}
+}
+
+func TestGetLink_ReturningVisitor(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gin.SetMode(gin.TestMode)
+	usecase := mock_handler.NewMockLinkUsecase(ctrl)
+	handler := NewLinkHandler(usecase)
+
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.GET("/:key", handler.GetLink)
+
+	usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, visit *model.Visit) (*model.Redirect, error) {
+			if visit.VisitorID != "returning" {
+				t.Errorf("expected visitor id %q; got %q", "returning", visit.VisitorID)
+			}
+
+			return &model.Redirect{Target: "https://example.com/b", Code: http.StatusFound, Sticky: true}, nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/token", http.NoBody)
+	req.AddCookie(&http.Cookie{Name: VisitorCookie, Value: "returning"})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Header().Get("Set-Cookie") != "" {
+		t.Errorf("expected no new cookie; got %q", w.Header().Get("Set-Cookie"))
+	}
+}
+
+func TestGetLink_VisitorCookie(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		redirect     *model.Redirect
+		cacheControl string
+		cookie       bool
+	}{
+		{
+			name: "Static",
+			redirect: &model.Redirect{
+				Target:    "https://example.com",
+				Code:      http.StatusMovedPermanently,
+				ExpiresAt: time.Now().Add(time.Hour),
+				Cacheable: true,
+			},
+			cacheControl: "public, max-age=",
+		},
+		{
+			name: "Split",
+			redirect: &model.Redirect{
+				Target: "https://example.com/b",
+				Code:   http.StatusMovedPermanently,
+				Sticky: true,
+			},
+			cacheControl: "no-store",
+			cookie:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			usecase := mock_handler.NewMockLinkUsecase(ctrl)
+			handler := NewLinkHandler(usecase)
+
+			router := gin.New()
+			router.Use(middleware.ErrorMiddleware())
+			router.GET("/:key", handler.GetLink)
+
+			usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "token", gomock.Any()).Return(tc.redirect, nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/token", http.NoBody))
+
+			if !strings.HasPrefix(w.Header().Get("Cache-Control"), tc.cacheControl) {
+				t.Errorf("expected Cache-Control %q; got %q", tc.cacheControl, w.Header().Get("Cache-Control"))
+			}
+
+			if cookie := w.Header().Get("Set-Cookie"); strings.HasPrefix(cookie, VisitorCookie+"=") != tc.cookie {
+				t.Errorf("expected visitor cookie %v; got %q", tc.cookie, cookie)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mailru/easyjson"
+)
+
+// UpdateLink changes the title, description, tags and notes of a link.
+func (h *LinkHandler) UpdateLink(ctx *gin.Context) {
+	token := ctx.Param("key")
+
+	if token == "" {
+		_ = ctx.Error(apierror.BadRequestError())
+		return
+	}
+
+	request := &dto.UpdateLinkRequest{}
+	if err := easyjson.UnmarshalFromReader(ctx.Request.Body, request); err != nil {
+		_ = ctx.Error(apierror.BadRequestError())
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	link, err := h.usecase.UpdateLink(ctx.Request.Context(), ctx.Request.Host, token, request)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	response := linkResponse(link)
+
+	responseJSON, err := response.MarshalJSON()
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", responseJSON)
+}
+
+// DeleteLink deletes a link.
+func (h *LinkHandler) DeleteLink(ctx *gin.Context) {
+	token := ctx.Param("key")
+
+	if token == "" {
+		_ = ctx.Error(apierror.BadRequestError())
+		return
+	}
+
+	if err := h.usecase.DeleteLink(ctx.Request.Context(), ctx.Request.Host, token); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListLinks returns the links carrying the tag given in the query.
+func (h *LinkHandler) ListLinks(ctx *gin.Context) {
+	links, err := h.usecase.ListLinks(ctx.Request.Context(), ctx.Query("tag"))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	response := &dto.ListLinksResponse{Links: make([]dto.LinkResponse, 0, len(links))}
+	for _, link := range links {
+		response.Links = append(response.Links, *linkResponse(link))
+	}
+
+	responseJSON, err := response.MarshalJSON()
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", responseJSON)
+}
+
+func linkResponse(link *model.Link) *dto.LinkResponse {
+	response := &dto.LinkResponse{
+		ShortLink:   link.ShortLink,
+		Link:        link.OriginalLink,
+		Title:       link.Title,
+		Description: link.Description,
+		Tags:        link.Tags,
+		Notes:       link.Notes,
+		ExpiresAt:   link.ExpiresAt,
+	}
+
+	if !link.UTM.Empty() {
+		response.UTM = &link.UTM
+	}
+
+	if link.Page.IsDefined() {
+		response.Page = &link.Page
+	}
+
+	if link.OpenGraph.IsDefined() {
+		response.OpenGraph = &link.OpenGraph
+	}
+
+	for _, v := range link.Variants {
+		response.Variants = append(response.Variants, dto.VariantResponse{Target: v.Target, Weight: v.Weight, Hits: v.Hits})
+	}
+
+	if !link.ActiveFrom.IsZero() {
+		response.ActiveFrom = &link.ActiveFrom
+	}
+
+	return response
+}
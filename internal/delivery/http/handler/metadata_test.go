@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	mock_handler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/handler/mocks"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/middleware"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+)
+
+func TestLinkMetadata(t *testing.T) {
+	link := &model.Link{
+		OriginalLink: "https://example.com/docs",
+		ShortLink:    "https://sho.rt/token",
+		Title:        "Docs",
+		Tags:         []string{"docs"},
+		UTM:          model.UTM{Source: "newsletter"},
+		ExpiresAt:    time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	split := &model.Link{
+		ShortLink: "https://sho.rt/split",
+		Variants: []model.Variant{
+			{Target: "https://example.com/a", Weight: 3, Hits: 7},
+			{Weight: 1, Hits: 2},
+		},
+		ExpiresAt: time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	title := "Docs"
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+		mockBehaviour  func(usecase *mock_handler.MockLinkUsecase)
+	}{
+		{
+			name:           "Update",
+			method:         http.MethodPatch,
+			path:           "/url/token",
+			body:           `{"title":"Docs"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"short_link":"https://sho.rt/token","link":"https://example.com/docs","title":"Docs","tags":["docs"],"utm":{"source":"newsletter"},"expires_at":"2100-01-01T00:00:00Z"}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().UpdateLink(gomock.Any(), gomock.Any(), "token", &dto.UpdateLinkRequest{Title: &title}).Return(link, nil)
+			},
+		},
+		{
+			name:           "Update with invalid body",
+			method:         http.MethodPatch,
+			path:           "/url/token",
+			body:           `{"title":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:bad-request","title":"bad request","status":400,"instance":"/url/token"}`,
+			mockBehaviour:  func(usecase *mock_handler.MockLinkUsecase) {},
+		},
+		{
+			name:           "Delete",
+			method:         http.MethodDelete,
+			path:           "/url/token",
+			expectedStatus: http.StatusNoContent,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().DeleteLink(gomock.Any(), gomock.Any(), "token").Return(nil)
+			},
+		},
+		{
+			name:           "Delete unknown link",
+			method:         http.MethodDelete,
+			path:           "/url/token",
+			expectedStatus: http.StatusNotFound,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().DeleteLink(gomock.Any(), gomock.Any(), "token").Return(apierror.ErrLinkNotFound)
+			},
+		},
+		{
+			name:           "List",
+			method:         http.MethodGet,
+			path:           "/url?tag=docs",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"links":[{"short_link":"https://sho.rt/token","link":"https://example.com/docs","title":"Docs"`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().ListLinks(gomock.Any(), "docs").Return([]*model.Link{link}, nil)
+			},
+		},
+		{
+			name:           "List split link",
+			method:         http.MethodGet,
+			path:           "/url?tag=docs",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"links":[{"short_link":"https://sho.rt/split","link":"","variants":[{"target":"https://example.com/a","weight":3,"hits":7},{"weight":1,"hits":2}],"expires_at":"2100-01-01T00:00:00Z"}]}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().ListLinks(gomock.Any(), "docs").Return([]*model.Link{split}, nil)
+			},
+		},
+		{
+			name:           "List without tag",
+			method:         http.MethodGet,
+			path:           "/url",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:bad-request","title":"bad request","status":400,"instance":"/url"}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().ListLinks(gomock.Any(), "").Return(nil, apierror.BadRequestError())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			usecase := mock_handler.NewMockLinkUsecase(ctrl)
+			handler := NewLinkHandler(usecase)
+
+			router := gin.New()
+			router.Use(middleware.ErrorMiddleware())
+			router.GET("/url", handler.ListLinks)
+			router.PATCH("/url/:key", handler.UpdateLink)
+			router.DELETE("/url/:key", handler.DeleteLink)
+
+			test.mockBehaviour(usecase)
+
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatus {
+				t.Errorf("expected status %d; got %d", test.expectedStatus, w.Code)
+			}
+
+			if !strings.Contains(w.Body.String(), test.expectedBody) {
+				t.Errorf("expected body to contain %s; got %s", test.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
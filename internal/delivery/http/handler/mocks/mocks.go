@@ -51,17 +51,203 @@ func (mr *MockLinkUsecaseMockRecorder) CreateShortLink(ctx, linkRequest interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShortLink", reflect.TypeOf((*MockLinkUsecase)(nil).CreateShortLink), ctx, linkRequest)
 }
 
+// DeleteLink mocks base method.
+func (m *MockLinkUsecase) DeleteLink(ctx context.Context, domain, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLink", ctx, domain, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLink indicates an expected call of DeleteLink.
+func (mr *MockLinkUsecaseMockRecorder) DeleteLink(ctx, domain, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLink", reflect.TypeOf((*MockLinkUsecase)(nil).DeleteLink), ctx, domain, token)
+}
+
 // GetFullLink mocks base method.
-func (m *MockLinkUsecase) GetFullLink(ctx context.Context, token string) (string, error) {
+func (m *MockLinkUsecase) GetFullLink(ctx context.Context, domain, token string, visit *model.Visit) (*model.Redirect, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFullLink", ctx, token)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "GetFullLink", ctx, domain, token, visit)
+	ret0, _ := ret[0].(*model.Redirect)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFullLink indicates an expected call of GetFullLink.
-func (mr *MockLinkUsecaseMockRecorder) GetFullLink(ctx, token interface{}) *gomock.Call {
+func (mr *MockLinkUsecaseMockRecorder) GetFullLink(ctx, domain, token, visit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFullLink", reflect.TypeOf((*MockLinkUsecase)(nil).GetFullLink), ctx, domain, token, visit)
+}
+
+// GetQRCode mocks base method.
+func (m *MockLinkUsecase) GetQRCode(ctx context.Context, domain, token string, request *dto.QRCodeRequest) (*model.QRCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQRCode", ctx, domain, token, request)
+	ret0, _ := ret[0].(*model.QRCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQRCode indicates an expected call of GetQRCode.
+func (mr *MockLinkUsecaseMockRecorder) GetQRCode(ctx, domain, token, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQRCode", reflect.TypeOf((*MockLinkUsecase)(nil).GetQRCode), ctx, domain, token, request)
+}
+
+// ListLinks mocks base method.
+func (m *MockLinkUsecase) ListLinks(ctx context.Context, tag string) ([]*model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLinks", ctx, tag)
+	ret0, _ := ret[0].([]*model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLinks indicates an expected call of ListLinks.
+func (mr *MockLinkUsecaseMockRecorder) ListLinks(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLinks", reflect.TypeOf((*MockLinkUsecase)(nil).ListLinks), ctx, tag)
+}
+
+// PreviewLink mocks base method.
+func (m *MockLinkUsecase) PreviewLink(ctx context.Context, domain, token string) (*model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewLink", ctx, domain, token)
+	ret0, _ := ret[0].(*model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewLink indicates an expected call of PreviewLink.
+func (mr *MockLinkUsecaseMockRecorder) PreviewLink(ctx, domain, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewLink", reflect.TypeOf((*MockLinkUsecase)(nil).PreviewLink), ctx, domain, token)
+}
+
+// UnlockLink mocks base method.
+func (m *MockLinkUsecase) UnlockLink(ctx context.Context, domain, token, password string, visit *model.Visit) (*model.Redirect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockLink", ctx, domain, token, password, visit)
+	ret0, _ := ret[0].(*model.Redirect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockLink indicates an expected call of UnlockLink.
+func (mr *MockLinkUsecaseMockRecorder) UnlockLink(ctx, domain, token, password, visit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockLink", reflect.TypeOf((*MockLinkUsecase)(nil).UnlockLink), ctx, domain, token, password, visit)
+}
+
+// UpdateLink mocks base method.
+func (m *MockLinkUsecase) UpdateLink(ctx context.Context, domain, token string, request *dto.UpdateLinkRequest) (*model.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLink", ctx, domain, token, request)
+	ret0, _ := ret[0].(*model.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLink indicates an expected call of UpdateLink.
+func (mr *MockLinkUsecaseMockRecorder) UpdateLink(ctx, domain, token, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLink", reflect.TypeOf((*MockLinkUsecase)(nil).UpdateLink), ctx, domain, token, request)
+}
+
+// WatchLinks mocks base method.
+func (m *MockLinkUsecase) WatchLinks(ctx context.Context, cursor string) (<-chan *model.LinkEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchLinks", ctx, cursor)
+	ret0, _ := ret[0].(<-chan *model.LinkEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchLinks indicates an expected call of WatchLinks.
+func (mr *MockLinkUsecaseMockRecorder) WatchLinks(ctx, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchLinks", reflect.TypeOf((*MockLinkUsecase)(nil).WatchLinks), ctx, cursor)
+}
+
+// MockWebhookUsecase is a mock of WebhookUsecase interface.
+type MockWebhookUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookUsecaseMockRecorder
+}
+
+// MockWebhookUsecaseMockRecorder is the mock recorder for MockWebhookUsecase.
+type MockWebhookUsecaseMockRecorder struct {
+	mock *MockWebhookUsecase
+}
+
+// NewMockWebhookUsecase creates a new mock instance.
+func NewMockWebhookUsecase(ctrl *gomock.Controller) *MockWebhookUsecase {
+	mock := &MockWebhookUsecase{ctrl: ctrl}
+	mock.recorder = &MockWebhookUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookUsecase) EXPECT() *MockWebhookUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookUsecase) CreateWebhook(ctx context.Context, request *dto.CreateWebhookRequest) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, request)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookUsecaseMockRecorder) CreateWebhook(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookUsecase)(nil).CreateWebhook), ctx, request)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookUsecase) DeleteWebhook(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookUsecaseMockRecorder) DeleteWebhook(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookUsecase)(nil).DeleteWebhook), ctx, id)
+}
+
+// ListDeadDeliveries mocks base method.
+func (m *MockWebhookUsecase) ListDeadDeliveries(ctx context.Context, id string) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadDeliveries", ctx, id)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeadDeliveries indicates an expected call of ListDeadDeliveries.
+func (mr *MockWebhookUsecaseMockRecorder) ListDeadDeliveries(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadDeliveries", reflect.TypeOf((*MockWebhookUsecase)(nil).ListDeadDeliveries), ctx, id)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockWebhookUsecase) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx)
+	ret0, _ := ret[0].([]*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockWebhookUsecaseMockRecorder) ListWebhooks(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFullLink", reflect.TypeOf((*MockLinkUsecase)(nil).GetFullLink), ctx, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockWebhookUsecase)(nil).ListWebhooks), ctx)
 }
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreviewSuffix appended to a token asks for the link preview instead of the redirect.
+const PreviewSuffix = "+"
+
+var previewTemplate = template.Must(template.New("preview").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Link preview</title>
+</head>
+<body>
+{{with .Title}}<h1>{{.}}</h1>
+{{end}}{{with .Description}}<p>{{.}}</p>
+{{end}}{{if .OriginalLink}}<p>{{.ShortLink}} leads to</p>
+<p><a href="{{.OriginalLink}}" rel="noopener noreferrer nofollow">{{.OriginalLink}}</a></p>
+{{else}}<p>{{.ShortLink}} does not lead anywhere right now.</p>
+{{end}}<p>The link expires on {{.ExpiresAt.Format "2006-01-02 15:04 MST"}}.</p>
+</body>
+</html>
+`))
+
+// previewLink shows where the link behind token leads without redirecting,
+// as an HTML page for browsers and as JSON for other clients.
+func (h *LinkHandler) previewLink(ctx *gin.Context, token string) {
+	link, err := h.usecase.PreviewLink(ctx.Request.Context(), ctx.Request.Host, token)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-cache")
+
+	if wantsHTML(ctx) {
+		h.previewPage(ctx, link)
+		return
+	}
+
+	response := &dto.PreviewResponse{
+		ShortLink:   link.ShortLink,
+		Link:        link.OriginalLink,
+		Title:       link.Title,
+		Description: link.Description,
+		ExpiresAt:   link.ExpiresAt,
+	}
+
+	if !link.ActiveFrom.IsZero() {
+		response.ActiveFrom = &link.ActiveFrom
+	}
+
+	responseJSON, err := response.MarshalJSON()
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", responseJSON)
+}
+
+func (h *LinkHandler) previewPage(ctx *gin.Context, link *model.Link) {
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.Status(http.StatusOK)
+
+	if err := previewTemplate.Execute(ctx.Writer, link); err != nil {
+		_ = ctx.Error(err)
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mock_handler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/handler/mocks"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/middleware"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+)
+
+func TestGetLink_Preview(t *testing.T) {
+	link := &model.Link{
+		OriginalLink: "https://example.com/docs",
+		ShortLink:    "https://sho.rt/token",
+		Token:        "token",
+		ExpiresAt:    time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	concealed := &model.Link{
+		ShortLink: link.ShortLink,
+		Token:     link.Token,
+		ExpiresAt: link.ExpiresAt,
+	}
+
+	testCases := []struct {
+		name           string
+		path           string
+		accept         string
+		expectedStatus int
+		expectedBody   string
+		mockBehaviour  func(usecase *mock_handler.MockLinkUsecase)
+	}{
+		{
+			name:           "JSON",
+			path:           "/token+",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"short_link":"https://sho.rt/token","link":"https://example.com/docs","expires_at":"2100-01-01T00:00:00Z"}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().PreviewLink(gomock.Any(), gomock.Any(), "token").Return(link, nil)
+			},
+		},
+		{
+			name:           "HTML",
+			path:           "/token+",
+			accept:         "text/html",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `<a href="https://example.com/docs" rel="noopener noreferrer nofollow">`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().PreviewLink(gomock.Any(), gomock.Any(), "token").Return(link, nil)
+			},
+		},
+		{
+			name:           "Concealed JSON",
+			path:           "/token+",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"short_link":"https://sho.rt/token","expires_at":"2100-01-01T00:00:00Z"}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().PreviewLink(gomock.Any(), gomock.Any(), "token").Return(concealed, nil)
+			},
+		},
+		{
+			name:           "Concealed HTML",
+			path:           "/token+",
+			accept:         "text/html",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `<p>https://sho.rt/token does not lead anywhere right now.</p>`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().PreviewLink(gomock.Any(), gomock.Any(), "token").Return(concealed, nil)
+			},
+		},
+		{
+			name:           "Protected",
+			path:           "/token+",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:password-required","title":"password required","status":401,"instance":"/token+"}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().PreviewLink(gomock.Any(), gomock.Any(), "token").
+					Return(nil, apierror.NewAPIError(apierror.ErrPasswordRequired, nil))
+			},
+		},
+		{
+			name:           "Bare suffix",
+			path:           "/+",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:link-not-found","title":"link not found","status":404,"instance":"/+"}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "+", gomock.Any()).Return(nil, apierror.NotFoundError())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			usecase := mock_handler.NewMockLinkUsecase(ctrl)
+			handler := NewLinkHandler(usecase)
+
+			router := gin.New()
+			router.Use(middleware.ErrorMiddleware())
+			router.GET("/:key", handler.GetLink)
+
+			test.mockBehaviour(usecase)
+
+			req := httptest.NewRequest(http.MethodGet, test.path, http.NoBody)
+			req.Header.Set("Accept", test.accept)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatus {
+				t.Errorf("expected status %d; got %d", test.expectedStatus, w.Code)
+			}
+
+			if !strings.Contains(w.Body.String(), test.expectedBody) {
+				t.Errorf("expected body to contain %q; got %q", test.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QRCodePath after a token on the API serves the QR code of the link.
+const QRCodePath = "/qr"
+
+// GetLinkOrQRCode serves the QR code of a link at QRCodePath and resolves the
+// link otherwise; gin cannot route /url/:key/qr next to /url/:key/*path.
+func (h *LinkHandler) GetLinkOrQRCode(ctx *gin.Context) {
+	if ctx.Param("path") == QRCodePath {
+		h.GetQRCode(ctx)
+		return
+	}
+
+	h.GetLink(ctx)
+}
+
+// GetQRCode renders the short link as a QR code, taking the format (png or svg),
+// size in pixels, ecc level, margin in modules and fg/bg colours from the query.
+func (h *LinkHandler) GetQRCode(ctx *gin.Context) {
+	token := ctx.Param("key")
+
+	if token == "" {
+		_ = ctx.Error(apierror.BadRequestError())
+		return
+	}
+
+	request := &dto.QRCodeRequest{
+		Format:     ctx.Query("format"),
+		ECC:        ctx.Query("ecc"),
+		Foreground: ctx.Query("fg"),
+		Background: ctx.Query("bg"),
+	}
+
+	if size := ctx.Query("size"); size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil {
+			_ = ctx.Error(apierror.BadRequestError())
+			return
+		}
+
+		request.Size = n
+	}
+
+	if margin := ctx.Query("margin"); margin != "" {
+		n, err := strconv.Atoi(margin)
+		if err != nil {
+			_ = ctx.Error(apierror.BadRequestError())
+			return
+		}
+
+		request.Margin = &n
+	}
+
+	if err := request.Validate(); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	code, err := h.usecase.GetQRCode(ctx.Request.Context(), ctx.Request.Host, token, request)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=86400")
+	ctx.Data(http.StatusOK, code.ContentType, code.Image)
+}
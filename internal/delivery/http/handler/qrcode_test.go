@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	mock_handler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/handler/mocks"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/middleware"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+)
+
+func TestGetLinkOrQRCode(t *testing.T) {
+	margin := 0
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedType   string
+		expectedBody   string
+		mockBehaviour  func(usecase *mock_handler.MockLinkUsecase)
+	}{
+		{
+			name:           "Defaults",
+			path:           "/url/token/qr",
+			expectedStatus: http.StatusOK,
+			expectedType:   "image/png",
+			expectedBody:   "png",
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().GetQRCode(gomock.Any(), gomock.Any(), "token", &dto.QRCodeRequest{}).
+					Return(&model.QRCode{Image: []byte("png"), ContentType: "image/png"}, nil)
+			},
+		},
+		{
+			name:           "Parameters",
+			path:           "/url/token/qr?format=svg&size=512&ecc=H&margin=0&fg=%23ff0000&bg=%23ffffff",
+			expectedStatus: http.StatusOK,
+			expectedType:   "image/svg+xml",
+			expectedBody:   "<svg/>",
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().GetQRCode(gomock.Any(), gomock.Any(), "token", &dto.QRCodeRequest{
+					Format: "svg", Size: 512, ECC: "H", Margin: &margin, Foreground: "#ff0000", Background: "#ffffff",
+				}).Return(&model.QRCode{Image: []byte("<svg/>"), ContentType: "image/svg+xml"}, nil)
+			},
+		},
+		{
+			name:           "Invalid size",
+			path:           "/url/token/qr?size=big",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:bad-request","title":"bad request","status":400,"instance":"/url/token/qr"}`,
+			mockBehaviour:  func(usecase *mock_handler.MockLinkUsecase) {},
+		},
+		{
+			name:           "Invalid colours",
+			path:           "/url/token/qr?fg=red&bg=%23ffffff0",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"invalid-params":[{"name":"fg","code":"pattern_mismatch","reason":"must match ^#?[0-9A-Fa-f]{6}$"},{"name":"bg","code":"pattern_mismatch","reason":"must match ^#?[0-9A-Fa-f]{6}$"}]`,
+			mockBehaviour:  func(usecase *mock_handler.MockLinkUsecase) {},
+		},
+		{
+			name:           "Not found",
+			path:           "/url/token/qr",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:link-not-found","title":"link not found","status":404,"instance":"/url/token/qr"}`,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().GetQRCode(gomock.Any(), gomock.Any(), "token", gomock.Any()).Return(nil, apierror.NotFoundError())
+			},
+		},
+		{
+			name:           "Other paths redirect",
+			path:           "/url/token/docs",
+			expectedStatus: http.StatusFound,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+					Return(&model.Redirect{Target: "https://example.com/docs", Code: http.StatusFound}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			usecase := mock_handler.NewMockLinkUsecase(ctrl)
+			handler := NewLinkHandler(usecase)
+
+			router := gin.New()
+			router.Use(middleware.ErrorMiddleware())
+			router.GET("/url/:key/*path", handler.GetLinkOrQRCode)
+
+			test.mockBehaviour(usecase)
+
+			req := httptest.NewRequest(http.MethodGet, test.path, http.NoBody)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatus {
+				t.Errorf("expected status %d; got %d", test.expectedStatus, w.Code)
+			}
+
+			if test.expectedType != "" && w.Header().Get("Content-Type") != test.expectedType {
+				t.Errorf("expected content type %s; got %s", test.expectedType, w.Header().Get("Content-Type"))
+			}
+
+			if !strings.Contains(w.Body.String(), test.expectedBody) {
+				t.Errorf("expected body to contain %s; got %s", test.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxCacheAge = 365 * 24 * time.Hour
+
+var interstitialTemplate = template.Must(template.New("interstitial").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+{{if .Delay}}<meta http-equiv="refresh" content="{{.Delay}};url={{.Target}}">
+{{end}}<title>Leaving for {{.Host}}</title>
+</head>
+<body>
+<p>You are leaving for {{.Host}}.</p>
+<p><a href="{{.Target}}" rel="noopener noreferrer">Continue to {{.Target}}</a></p>
+{{if .Delay}}<p>You will be taken there in {{.Delay}} seconds.</p>
+{{end}}</body>
+</html>
+`))
+
+type interstitialPage struct {
+	Target string
+	Host   string
+	Delay  int
+}
+
+// redirect sends the visitor on as the link asks for: with a redirect of the
+// link's status code or with an interstitial page naming the destination.
+func (h *LinkHandler) redirect(ctx *gin.Context, r *model.Redirect) {
+	ctx.Header("Cache-Control", cacheControl(r, time.Now()))
+
+	if !r.Interstitial {
+		ctx.Redirect(r.Code, r.Target)
+		return
+	}
+
+	h.interstitial(ctx, r)
+}
+
+func (h *LinkHandler) interstitial(ctx *gin.Context, r *model.Redirect) {
+	page := interstitialPage{
+		Target: r.Target,
+		Delay:  r.Delay,
+	}
+
+	if u, err := url.Parse(r.Target); err == nil {
+		page.Host = u.Hostname()
+	}
+
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.Status(http.StatusOK)
+
+	if err := interstitialTemplate.Execute(ctx.Writer, page); err != nil {
+		_ = ctx.Error(err)
+	}
+}
+
+// cacheControl lets caches keep permanent redirects until the link expires.
+// Temporary redirects and interstitial pages are revalidated on every visit,
+// and redirects of links that may send the next visit elsewhere are not stored at all.
+func cacheControl(r *model.Redirect, now time.Time) string {
+	if !r.Cacheable {
+		return "no-store"
+	}
+
+	if r.Interstitial || (r.Code != http.StatusMovedPermanently && r.Code != http.StatusPermanentRedirect) {
+		return "no-cache"
+	}
+
+	maxAge := r.ExpiresAt.Sub(now)
+	if maxAge > maxCacheAge {
+		maxAge = maxCacheAge
+	}
+
+	if maxAge <= 0 {
+		return "no-store"
+	}
+
+	return fmt.Sprintf("public, max-age=%d", int64(maxAge/time.Second))
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mock_handler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/handler/mocks"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/middleware"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+)
+
+func TestGetLink_Redirect(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		name                 string
+		redirect             *model.Redirect
+		expectedStatus       int
+		expectedCacheControl string
+		expectedBody         string
+	}{
+		{
+			name:                 "Moved permanently",
+			redirect:             &model.Redirect{Target: "https://example.com", Code: http.StatusMovedPermanently, ExpiresAt: expiresAt, Cacheable: true},
+			expectedStatus:       http.StatusMovedPermanently,
+			expectedCacheControl: "public, max-age=",
+		},
+		{
+			name:                 "Permanent redirect of a dynamic link",
+			redirect:             &model.Redirect{Target: "https://example.com", Code: http.StatusPermanentRedirect, ExpiresAt: expiresAt},
+			expectedStatus:       http.StatusPermanentRedirect,
+			expectedCacheControl: "no-store",
+		},
+		{
+			name:                 "Temporary redirect",
+			redirect:             &model.Redirect{Target: "https://example.com", Code: http.StatusTemporaryRedirect, ExpiresAt: expiresAt, Cacheable: true},
+			expectedStatus:       http.StatusTemporaryRedirect,
+			expectedCacheControl: "no-cache",
+		},
+		{
+			name:                 "Interstitial",
+			redirect:             &model.Redirect{Target: "https://example.com/a?b=1", Code: http.StatusFound, Interstitial: true, ExpiresAt: expiresAt, Cacheable: true},
+			expectedStatus:       http.StatusOK,
+			expectedCacheControl: "no-cache",
+			expectedBody:         `<p>You are leaving for example.com.</p>`,
+		},
+		{
+			name:                 "Interstitial with delay",
+			redirect:             &model.Redirect{Target: "https://example.com/a?b=1", Code: http.StatusFound, Interstitial: true, Delay: 5, ExpiresAt: expiresAt},
+			expectedStatus:       http.StatusOK,
+			expectedCacheControl: "no-store",
+			expectedBody:         `<meta http-equiv="refresh" content="5;url=https://example.com/a?b=1">`,
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			usecase := mock_handler.NewMockLinkUsecase(ctrl)
+			handler := NewLinkHandler(usecase)
+
+			router := gin.New()
+			router.Use(middleware.ErrorMiddleware())
+			router.GET("/:key", handler.GetLink)
+
+			usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "token", gomock.Any()).Return(test.redirect, nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/token", http.NoBody))
+
+			if w.Code != test.expectedStatus {
+				t.Errorf("expected status %d; got %d", test.expectedStatus, w.Code)
+			}
+
+			if !strings.HasPrefix(w.Header().Get("Cache-Control"), test.expectedCacheControl) {
+				t.Errorf("expected Cache-Control %q; got %q", test.expectedCacheControl, w.Header().Get("Cache-Control"))
+			}
+
+			if !strings.Contains(w.Body.String(), test.expectedBody) {
+				t.Errorf("expected body to contain %q; got %q", test.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCacheControl_MaxAge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		expiresAt time.Time
+		expected  string
+	}{
+		{expiresAt: now.Add(90 * time.Minute), expected: "public, max-age=5400"},
+		{expiresAt: now.Add(10 * 365 * 24 * time.Hour), expected: "public, max-age=31536000"},
+		{expiresAt: now.Add(-time.Minute), expected: "no-store"},
+	}
+
+	for _, test := range testCases {
+		r := &model.Redirect{Code: http.StatusMovedPermanently, ExpiresAt: test.expiresAt, Cacheable: true}
+
+		if got := cacheControl(r, now); got != test.expected {
+			t.Errorf("expected %q for expiry %s; got %q", test.expected, test.expiresAt, got)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+var socialTemplate = template.Must(template.New("social").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>{{.Card.Title}}</title>
+<meta property="og:type" content="website">
+<meta property="og:url" content="{{.ShortLink}}">
+<meta property="og:title" content="{{.Card.Title}}">
+{{with .Card.Description}}<meta property="og:description" content="{{.}}">
+<meta name="description" content="{{.}}">
+{{end}}{{with .Card.Image}}<meta property="og:image" content="{{.}}">
+<meta name="twitter:card" content="summary_large_image">
+{{else}}<meta name="twitter:card" content="summary">
+{{end}}</head>
+<body>
+<p>{{if .OriginalLink}}<a href="{{.OriginalLink}}" rel="noopener noreferrer nofollow">{{.Card.Title}}</a>{{else}}{{.Card.Title}}{{end}}</p>
+</body>
+</html>
+`))
+
+type socialCard struct {
+	ShortLink    string
+	OriginalLink string
+	Card         model.OpenGraph
+}
+
+// socialPreview answers services unfurling the link behind token with its
+// Open Graph tags instead of the redirect. It reports false when the link
+// has to go through the usual flow, as protected links do.
+func (h *LinkHandler) socialPreview(ctx *gin.Context, token string) bool {
+	link, err := h.usecase.PreviewLink(ctx.Request.Context(), ctx.Request.Host, token)
+	if err != nil {
+		if isPasswordError(err) {
+			return false
+		}
+
+		_ = ctx.Error(err)
+
+		return true
+	}
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.Status(http.StatusOK)
+
+	card := socialCard{
+		ShortLink:    link.ShortLink,
+		OriginalLink: link.OriginalLink,
+		Card:         link.Card(),
+	}
+
+	if err := socialTemplate.Execute(ctx.Writer, card); err != nil {
+		_ = ctx.Error(err)
+	}
+
+	return true
+}
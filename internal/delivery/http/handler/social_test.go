@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mock_handler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/handler/mocks"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/middleware"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+)
+
+const slackbot = "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)"
+
+func TestGetLink_SocialPreview(t *testing.T) {
+	link := func() *model.Link {
+		return &model.Link{
+			OriginalLink: "https://example.com/docs",
+			ShortLink:    "https://sho.rt/token",
+			Token:        "token",
+			Title:        "Docs",
+			ExpiresAt:    time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Page: model.Page{
+				Title:       "Example Docs",
+				Description: "Everything about examples",
+				Image:       "https://example.com/card.png",
+			},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		userAgent      string
+		password       string
+		expectedStatus int
+		expectedBody   []string
+		mockBehaviour  func(usecase *mock_handler.MockLinkUsecase)
+	}{
+		{
+			name:           "Page tags",
+			userAgent:      slackbot,
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				`<meta property="og:url" content="https://sho.rt/token">`,
+				`<meta property="og:title" content="Example Docs">`,
+				`<meta property="og:description" content="Everything about examples">`,
+				`<meta property="og:image" content="https://example.com/card.png">`,
+				`<meta name="twitter:card" content="summary_large_image">`,
+			},
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().PreviewLink(gomock.Any(), gomock.Any(), "token").Return(link(), nil)
+			},
+		},
+		{
+			name:           "Overrides",
+			userAgent:      "Twitterbot/1.0",
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				`<meta property="og:title" content="Read the docs">`,
+				`<meta property="og:description" content="Everything about examples">`,
+			},
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				l := link()
+				l.OpenGraph = model.OpenGraph{Title: "Read the docs"}
+
+				usecase.EXPECT().PreviewLink(gomock.Any(), gomock.Any(), "token").Return(l, nil)
+			},
+		},
+		{
+			name:           "No page",
+			userAgent:      slackbot,
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				`<meta property="og:title" content="example.com">`,
+				`<meta name="twitter:card" content="summary">`,
+			},
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				l := link()
+				l.Title = ""
+				l.Page = model.Page{}
+
+				usecase.EXPECT().PreviewLink(gomock.Any(), gomock.Any(), "token").Return(l, nil)
+			},
+		},
+		{
+			name:           "Concealed",
+			userAgent:      slackbot,
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				`<meta property="og:title" content="Docs">`,
+				`<p>Docs</p>`,
+			},
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				l := link()
+				l.OriginalLink = ""
+				l.Page = model.Page{}
+
+				usecase.EXPECT().PreviewLink(gomock.Any(), gomock.Any(), "token").Return(l, nil)
+			},
+		},
+		{
+			name:           "Human",
+			userAgent:      "Mozilla/5.0 (X11; Linux x86_64) Firefox/125.0",
+			expectedStatus: http.StatusFound,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+					Return(&model.Redirect{Target: "https://example.com/docs", Code: http.StatusFound}, nil)
+			},
+		},
+		{
+			name:           "Protected",
+			userAgent:      slackbot,
+			expectedStatus: http.StatusUnauthorized,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().PreviewLink(gomock.Any(), gomock.Any(), "token").
+					Return(nil, apierror.NewAPIError(apierror.ErrPasswordRequired, nil))
+				usecase.EXPECT().GetFullLink(gomock.Any(), gomock.Any(), "token", gomock.Any()).
+					Return(nil, apierror.NewAPIError(apierror.ErrPasswordRequired, nil))
+			},
+		},
+		{
+			name:           "Password",
+			userAgent:      slackbot,
+			password:       "secret",
+			expectedStatus: http.StatusFound,
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().UnlockLink(gomock.Any(), gomock.Any(), "token", "secret", gomock.Any()).
+					Return(&model.Redirect{Target: "https://example.com/docs", Code: http.StatusFound}, nil)
+			},
+		},
+		{
+			name:           "Not found",
+			userAgent:      slackbot,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   []string{`{"type":"urn:problem-type:shortlink:link-not-found","title":"link not found","status":404,"instance":"/token"}`},
+			mockBehaviour: func(usecase *mock_handler.MockLinkUsecase) {
+				usecase.EXPECT().PreviewLink(gomock.Any(), gomock.Any(), "token").Return(nil, apierror.NotFoundError())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			usecase := mock_handler.NewMockLinkUsecase(ctrl)
+			handler := NewLinkHandler(usecase)
+
+			router := gin.New()
+			router.Use(middleware.ErrorMiddleware())
+			router.GET("/:key", handler.GetLink)
+
+			test.mockBehaviour(usecase)
+
+			req := httptest.NewRequest(http.MethodGet, "/token", http.NoBody)
+			req.Header.Set("User-Agent", test.userAgent)
+			if test.password != "" {
+				req.Header.Set(PasswordHeader, test.password)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatus {
+				t.Errorf("expected status %d; got %d", test.expectedStatus, w.Code)
+			}
+
+			if vary := w.Header().Get("Vary"); vary != "User-Agent" {
+				t.Errorf("expected Vary: User-Agent; got %q", vary)
+			}
+
+			for _, expected := range test.expectedBody {
+				if !strings.Contains(w.Body.String(), expected) {
+					t.Errorf("expected body to contain %q; got %q", expected, w.Body.String())
+				}
+			}
+		})
+	}
+}
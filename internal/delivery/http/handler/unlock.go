@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordHeader lets API clients unlock a protected link without the HTML form.
+const PasswordHeader = "X-Link-Password"
+
+var unlockFormTemplate = template.Must(template.New("unlock").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Protected link</title>
+</head>
+<body>
+<form method="post">
+<p>This link is protected. Enter the password to continue.</p>
+{{if .}}<p role="alert">{{.}}</p>{{end}}
+<input type="password" name="password" autocomplete="current-password" required autofocus>
+<button type="submit">Continue</button>
+</form>
+</body>
+</html>
+`))
+
+// UnlockLink handles the unlock form submission for a protected link.
+func (h *LinkHandler) UnlockLink(ctx *gin.Context) {
+	token := ctx.Param("key")
+
+	if token == "" {
+		_ = ctx.Error(apierror.BadRequestError())
+		return
+	}
+
+	password := ctx.PostForm("password")
+	if password == "" {
+		password = ctx.GetHeader(PasswordHeader)
+	}
+
+	visit := visitOf(ctx)
+
+	redirect, err := h.usecase.UnlockLink(ctx.Request.Context(), ctx.Request.Host, token, password, visit)
+	if err != nil {
+		h.redirectError(ctx, redirect, err)
+		return
+	}
+
+	keepVisitor(ctx, redirect, visit)
+
+	// The answer to a password must not be replayed from a cache, and the
+	// form is left with a GET whatever status the link redirects with.
+	ctx.Header("Cache-Control", "no-store")
+
+	if redirect.Interstitial {
+		h.interstitial(ctx, redirect)
+		return
+	}
+
+	ctx.Redirect(http.StatusSeeOther, redirect.Target)
+}
+
+// passwordError shows the unlock form to browsers and leaves other clients to the error middleware.
+func (h *LinkHandler) passwordError(ctx *gin.Context, err error) {
+	if !wantsHTML(ctx) {
+		_ = ctx.Error(err)
+		return
+	}
+
+	var message string
+
+	status := http.StatusUnauthorized
+	if !errors.Is(err, apierror.ErrPasswordRequired) {
+		definition := apierror.Errors[apierror.Sentinel(err)]
+		status = definition.Code
+		message = definition.Message
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Status(status)
+
+	if err := unlockFormTemplate.Execute(ctx.Writer, message); err != nil {
+		_ = ctx.Error(err)
+	}
+}
+
+func isPasswordError(err error) bool {
+	return errors.Is(err, apierror.ErrPasswordRequired) ||
+		errors.Is(err, apierror.ErrPasswordInvalid) ||
+		errors.Is(err, apierror.ErrTooManyAttempts)
+}
+
+func wantsHTML(ctx *gin.Context) bool {
+	return strings.Contains(ctx.GetHeader("Accept"), "text/html")
+}
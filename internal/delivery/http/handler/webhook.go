@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mailru/easyjson"
+)
+
+// WebhookHandler manages the webhooks notified of link events.
+type WebhookHandler struct {
+	usecase WebhookUsecase
+}
+
+func NewWebhookHandler(usecase WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{usecase}
+}
+
+// CreateWebhook registers a webhook. The response is the only one carrying
+// its secret.
+func (h *WebhookHandler) CreateWebhook(ctx *gin.Context) {
+	request := &dto.CreateWebhookRequest{}
+	if err := easyjson.UnmarshalFromReader(ctx.Request.Body, request); err != nil {
+		_ = ctx.Error(apierror.BadRequestError())
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	hook, err := h.usecase.CreateWebhook(ctx.Request.Context(), request)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	response := webhookResponse(hook)
+	response.Secret = hook.Secret
+
+	responseJSON, err := response.MarshalJSON()
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusCreated, "application/json; charset=utf-8", responseJSON)
+}
+
+// ListWebhooks returns every webhook, without secrets.
+func (h *WebhookHandler) ListWebhooks(ctx *gin.Context) {
+	hooks, err := h.usecase.ListWebhooks(ctx.Request.Context())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	response := &dto.ListWebhooksResponse{Webhooks: make([]dto.WebhookResponse, 0, len(hooks))}
+	for _, hook := range hooks {
+		response.Webhooks = append(response.Webhooks, *webhookResponse(hook))
+	}
+
+	responseJSON, err := response.MarshalJSON()
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", responseJSON)
+}
+
+// DeleteWebhook stops notifying a webhook.
+func (h *WebhookHandler) DeleteWebhook(ctx *gin.Context) {
+	if err := h.usecase.DeleteWebhook(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListDeadDeliveries returns the latest requests to a webhook that were given up on.
+func (h *WebhookHandler) ListDeadDeliveries(ctx *gin.Context) {
+	deliveries, err := h.usecase.ListDeadDeliveries(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	response := &dto.ListWebhookDeliveriesResponse{Deliveries: make([]dto.WebhookDeliveryResponse, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, dto.WebhookDeliveryResponse{
+			ID:        delivery.ID,
+			Event:     string(delivery.Event),
+			Payload:   delivery.Payload,
+			Attempts:  delivery.Attempts,
+			LastError: delivery.LastError,
+			CreatedAt: delivery.CreatedAt,
+		})
+	}
+
+	responseJSON, err := response.MarshalJSON()
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", responseJSON)
+}
+
+func webhookResponse(hook *model.Webhook) *dto.WebhookResponse {
+	response := &dto.WebhookResponse{
+		ID:              hook.ID,
+		URL:             hook.URL,
+		ClickThresholds: hook.ClickThresholds,
+		CreatedAt:       hook.CreatedAt,
+	}
+
+	for _, event := range hook.Events {
+		response.Events = append(response.Events, string(event))
+	}
+
+	return response
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	mock_handler "github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/handler/mocks"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/middleware"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+)
+
+func TestWebhooks(t *testing.T) {
+	hook := &model.Webhook{
+		ID:              "hook",
+		URL:             "https://example.com/hook",
+		Secret:          "0123456789abcdef",
+		Events:          []model.WebhookEvent{model.WebhookLinkCreated},
+		ClickThresholds: []int64{100},
+		CreatedAt:       time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+		mockBehaviour  func(usecase *mock_handler.MockWebhookUsecase)
+	}{
+		{
+			name:           "Create",
+			method:         http.MethodPost,
+			path:           "/webhooks",
+			body:           `{"url":"https://example.com/hook","events":["link.created"],"click_thresholds":[100]}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"hook","url":"https://example.com/hook","secret":"0123456789abcdef","events":["link.created"],"click_thresholds":[100],"created_at":"2024-01-01T00:00:00Z"}`,
+			mockBehaviour: func(usecase *mock_handler.MockWebhookUsecase) {
+				usecase.EXPECT().CreateWebhook(gomock.Any(), &dto.CreateWebhookRequest{
+					URL:             "https://example.com/hook",
+					Events:          []string{"link.created"},
+					ClickThresholds: []int64{100},
+				}).Return(hook, nil)
+			},
+		},
+		{
+			name:           "Create without url",
+			method:         http.MethodPost,
+			path:           "/webhooks",
+			body:           `{"events":["link.created"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:bad-request","title":"bad request","status":400,"detail":"url: is required","instance":"/webhooks","invalid-params":[{"name":"url","code":"required","reason":"is required"}]}`,
+			mockBehaviour:  func(usecase *mock_handler.MockWebhookUsecase) {},
+		},
+		{
+			name:           "List",
+			method:         http.MethodGet,
+			path:           "/webhooks",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"webhooks":[{"id":"hook","url":"https://example.com/hook","events":["link.created"],"click_thresholds":[100],"created_at":"2024-01-01T00:00:00Z"}]}`,
+			mockBehaviour: func(usecase *mock_handler.MockWebhookUsecase) {
+				usecase.EXPECT().ListWebhooks(gomock.Any()).Return([]*model.Webhook{hook}, nil)
+			},
+		},
+		{
+			name:           "Delete",
+			method:         http.MethodDelete,
+			path:           "/webhooks/hook",
+			expectedStatus: http.StatusNoContent,
+			mockBehaviour: func(usecase *mock_handler.MockWebhookUsecase) {
+				usecase.EXPECT().DeleteWebhook(gomock.Any(), "hook").Return(nil)
+			},
+		},
+		{
+			name:           "Delete unknown",
+			method:         http.MethodDelete,
+			path:           "/webhooks/unknown",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:webhook-not-found","title":"webhook not found","status":404,"instance":"/webhooks/unknown"}`,
+			mockBehaviour: func(usecase *mock_handler.MockWebhookUsecase) {
+				usecase.EXPECT().DeleteWebhook(gomock.Any(), "unknown").Return(apierror.NewAPIError(apierror.ErrWebhookNotFound, nil))
+			},
+		},
+		{
+			name:           "Dead letters",
+			method:         http.MethodGet,
+			path:           "/webhooks/hook/dead-letters",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"deliveries":[{"id":"delivery","event":"link.created","payload":{"id":"delivery"},"attempts":8,"last_error":"webhook answered 500 Internal Server Error","created_at":"2024-01-01T00:00:00Z"}]}`,
+			mockBehaviour: func(usecase *mock_handler.MockWebhookUsecase) {
+				usecase.EXPECT().ListDeadDeliveries(gomock.Any(), "hook").Return([]*model.WebhookDelivery{{
+					ID:        "delivery",
+					WebhookID: "hook",
+					Event:     model.WebhookLinkCreated,
+					Payload:   []byte(`{"id":"delivery"}`),
+					Attempts:  8,
+					LastError: "webhook answered 500 Internal Server Error",
+					Dead:      true,
+					CreatedAt: hook.CreatedAt,
+				}}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gin.SetMode(gin.TestMode)
+			usecase := mock_handler.NewMockWebhookUsecase(ctrl)
+			handler := NewWebhookHandler(usecase)
+
+			router := gin.New()
+			router.Use(middleware.ErrorMiddleware())
+			router.POST("/webhooks", handler.CreateWebhook)
+			router.GET("/webhooks", handler.ListWebhooks)
+			router.DELETE("/webhooks/:id", handler.DeleteWebhook)
+			router.GET("/webhooks/:id/dead-letters", handler.ListDeadDeliveries)
+
+			test.mockBehaviour(usecase)
+
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatus {
+				t.Errorf("expected status %d; got %d", test.expectedStatus, w.Code)
+			}
+
+			if w.Body.String() != test.expectedBody {
+				t.Errorf("expected body %s; got %s", test.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
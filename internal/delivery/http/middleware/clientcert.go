@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	apperror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireClientCert rejects requests without a verified TLS client certificate.
+// The listener verifies certificates clients present; this decides per route
+// whether one is needed at all.
+func RequireClientCert() gin.HandlerFunc {
+	fn := func(ctx *gin.Context) {
+		if ctx.Request.TLS == nil || len(ctx.Request.TLS.VerifiedChains) == 0 {
+			_ = ctx.Error(apperror.NewAPIError(apperror.ErrCertificateRequired, nil))
+			ctx.Abort()
+
+			return
+		}
+
+		ctx.Next()
+	}
+
+	return fn
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// CORS lets browser clients on origins call the API. "*" allows any origin.
+// Besides the usual headers it allows the ones of the Connect and gRPC-Web
+// protocols, and exposes their trailers-in-headers.
+func CORS(origins []string, maxAge time.Duration) (gin.HandlerFunc, error) {
+	config := cors.Config{
+		AllowMethods: []string{"GET", "POST", "PATCH", "OPTIONS"},
+		AllowHeaders: []string{
+			"Origin", "Content-Type", "Accept", "Authorization",
+			"Connect-Protocol-Version", "Connect-Timeout-Ms",
+			"Grpc-Timeout", "X-Grpc-Web", "X-User-Agent",
+		},
+		ExposeHeaders: []string{
+			"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin",
+		},
+		MaxAge: maxAge,
+	}
+
+	if len(origins) == 1 && origins[0] == "*" {
+		config.AllowAllOrigins = true
+	} else {
+		config.AllowOrigins = origins
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("middleware - CORS: %w", err)
+	}
+
+	return cors.New(config), nil
+}
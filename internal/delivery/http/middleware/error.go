@@ -2,36 +2,84 @@ package middleware
 
 import (
 	"errors"
+	"strings"
 
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
 	apperror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
 
 	"github.com/gin-gonic/gin"
 )
 
+// ProblemTypePrefix prefixes the URNs identifying the kinds of problems.
+const ProblemTypePrefix = "urn:problem-type:shortlink:"
+
+// ErrorMiddleware renders the first error a handler recorded as RFC 7807
+// problem details. Errors outside the catalogue are reported as internal
+// errors without their cause; gin.Logger still logs it.
 func ErrorMiddleware() gin.HandlerFunc {
 	fn := func(ctx *gin.Context) {
 		ctx.Next()
 
-		if len(ctx.Errors) == 0 {
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+
+		problem := NewProblem(ctx, publicError(ctx.Errors))
+
+		body, err := problem.MarshalJSON()
+		if err != nil {
+			ctx.Status(problem.Status)
 			return
 		}
 
-		e := ctx.Errors[0].Unwrap()
+		ctx.Header("Cache-Control", "no-store")
+		ctx.Data(problem.Status, dto.ProblemContentType, body)
+	}
 
-		var err error
+	return fn
+}
 
+// NewProblem describes err as problem details of the current request.
+func NewProblem(ctx *gin.Context, err error) *dto.Problem {
+	definition := apperror.Errors[apperror.Sentinel(err)]
+
+	problem := &dto.Problem{
+		Type:      ProblemTypePrefix + strings.ReplaceAll(strings.ToLower(definition.Reason), "_", "-"),
+		Title:     definition.Message,
+		Status:    definition.Code,
+		Instance:  ctx.Request.URL.Path,
+		RequestID: GetRequestID(ctx),
+	}
+
+	if fieldErrs := apperror.FieldErrorsOf(err); len(fieldErrs) > 0 {
+		problem.Detail = fieldErrs.Error()
+		for _, fieldErr := range fieldErrs {
+			problem.InvalidParams = append(problem.InvalidParams, dto.InvalidParam{
+				Name:   fieldErr.Field,
+				Code:   fieldErr.Code,
+				Reason: fieldErr.Err.Error(),
+			})
+		}
+	}
+
+	return problem
+}
+
+// publicError picks the error to show clients: the first one meant for them,
+// else a bad request when gin failed to bind the request, else the first one.
+func publicError(errs []*gin.Error) error {
+	for _, e := range errs {
 		var apiErr *apperror.APIError
-		if errors.As(e, &apiErr) {
-			err = apiErr.Unwrap()
-		} else {
-			err = apperror.ErrInternalServer
+		if errors.As(e.Err, &apiErr) {
+			return apiErr
 		}
+	}
 
-		ctx.JSON(apperror.Errors[err].Code, gin.H{
-			"status":  apperror.Errors[err].Code,
-			"message": apperror.Errors[err].Message,
-		})
+	for _, e := range errs {
+		if e.IsType(gin.ErrorTypeBind) {
+			return apperror.BadRequestError()
+		}
 	}
 
-	return fn
+	return errs[0].Err
 }
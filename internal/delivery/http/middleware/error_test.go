@@ -0,0 +1,156 @@
+package middleware_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/middleware"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorMiddleware(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		errs           []error
+		bindErr        bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "API error",
+			errs:           []error{apierror.NotFoundError()},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:link-not-found","title":"link not found","status":404,"instance":"/path","request_id":"req-1"}`,
+		},
+		{
+			name:           "Field error",
+			errs:           []error{apierror.InvalidFieldError("link", errors.New("is required"))},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:bad-request","title":"bad request","status":400,"detail":"link: is required","instance":"/path","request_id":"req-1","invalid-params":[{"name":"link","code":"invalid","reason":"is required"}]}`,
+		},
+		{
+			name:           "Internal cause hidden",
+			errs:           []error{apierror.InternalError(errors.New("dial tcp 10.0.0.5:5432: connection refused"))},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:internal","title":"internal server error","status":500,"instance":"/path","request_id":"req-1"}`,
+		},
+		{
+			name:           "Unknown error",
+			errs:           []error{fmt.Errorf("pq: relation %q does not exist", "link")},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:internal","title":"internal server error","status":500,"instance":"/path","request_id":"req-1"}`,
+		},
+		{
+			name:           "API error preferred",
+			errs:           []error{errors.New("write: broken pipe"), apierror.NewAPIError(apierror.ErrCursorExpired, nil)},
+			expectedStatus: http.StatusGone,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:cursor-expired","title":"cursor expired","status":410,"instance":"/path","request_id":"req-1"}`,
+		},
+		{
+			name:           "Bind error",
+			errs:           []error{errors.New("invalid character 'x'")},
+			bindErr:        true,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"urn:problem-type:shortlink:bad-request","title":"bad request","status":400,"instance":"/path","request_id":"req-1"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(middleware.RequestID(), middleware.ErrorMiddleware())
+			router.GET("/path", func(ctx *gin.Context) {
+				for _, err := range tc.errs {
+					ginErr := ctx.Error(err)
+					if tc.bindErr {
+						ginErr.SetType(gin.ErrorTypeBind)
+					}
+				}
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/path", nil)
+			req.Header.Set(middleware.RequestIDHeader, "req-1")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedStatus, w.Code)
+			require.Equal(t, dto.ProblemContentType, w.Header().Get("Content-Type"))
+			require.Equal(t, tc.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestErrorMiddleware_Written(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware())
+	router.GET("/path", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, "partial")
+		_ = ctx.Error(errors.New("write: broken pipe"))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/path", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "partial", w.Body.String())
+}
+
+func TestRequestID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		header    string
+		generated bool
+	}{
+		{name: "Kept", header: "4bf92f35-77b3-4da6"},
+		{name: "Missing", generated: true},
+		{name: "Unsafe", header: "id\r\nSet-Cookie: a=b", generated: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(middleware.RequestID())
+
+			var seen string
+			router.GET("/path", func(ctx *gin.Context) {
+				seen = middleware.GetRequestID(ctx)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/path", nil)
+			if tc.header != "" {
+				req.Header.Set(middleware.RequestIDHeader, tc.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, seen, w.Header().Get(middleware.RequestIDHeader))
+			if tc.generated {
+				require.Len(t, seen, 32)
+			} else {
+				require.Equal(t, tc.header, seen)
+			}
+		})
+	}
+}
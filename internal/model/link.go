@@ -1,12 +1,295 @@
 package model
 
-import "time"
+import (
+	"net"
+	"net/url"
+	"time"
+)
 
 type Link struct {
-	OriginalLink string `db:"original_link"`
-	ShortLink    string
-	Token        string    `db:"token"`
-	ExpiresAt    time.Time `db:"expires_at"`
+	OriginalLink      string    `json:"original_link" db:"original_link"`
+	CanonicalLink     string    `json:"canonical_link,omitempty" db:"canonical_link"`
+	ShortLink         string    `json:"-"`
+	Token             string    `json:"token" db:"token"`
+	Domain            string    `json:"domain,omitempty" db:"domain"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	ExpiresAt         time.Time `json:"expires_at" db:"expires_at"`
+	ActiveFrom        time.Time `json:"active_from" db:"active_from"`
+	FallbackLink      string    `json:"fallback_link,omitempty" db:"fallback_link"`
+	PasswordHash      string    `json:"password_hash,omitempty" db:"password_hash"`
+	MaxClicks         int64     `json:"max_clicks,omitempty" db:"max_clicks"`
+	Rules             []Rule    `json:"rules,omitempty" db:"rules"`
+	Variants          []Variant `json:"variants,omitempty" db:"variants"`
+	ForwardPath       bool      `json:"forward_path,omitempty" db:"forward_path"`
+	ForwardQuery      QueryMode `json:"forward_query,omitempty" db:"forward_query"`
+	RedirectCode      int       `json:"redirect_code,omitempty" db:"redirect_code"`
+	Interstitial      bool      `json:"interstitial,omitempty" db:"interstitial"`
+	InterstitialDelay int       `json:"interstitial_delay,omitempty" db:"interstitial_delay"`
+	Title             string    `json:"title,omitempty" db:"title"`
+	Description       string    `json:"description,omitempty" db:"description"`
+	Tags              []string  `json:"tags,omitempty" db:"tags"`
+	Notes             string    `json:"notes,omitempty" db:"notes"`
+	UTM               UTM       `json:"utm,omitempty" db:"utm"`
+	Page              Page      `json:"page,omitempty" db:"page"`
+	OpenGraph         OpenGraph `json:"open_graph,omitempty" db:"open_graph"`
+}
+
+// OpenGraph tags shown when the link is unfurled in chats and social feeds.
+type OpenGraph struct {
+	Title       string `json:"title,omitempty" validate:"max=200"`
+	Description string `json:"description,omitempty" validate:"max=1000"`
+	Image       string `json:"image,omitempty" validate:"omitempty,max=2048,weburl,scheme=http https"`
+}
+
+// IsDefined lets easyjson omit links without overrides.
+func (o OpenGraph) IsDefined() bool {
+	return o != OpenGraph{}
+}
+
+// Card returns the Open Graph tags of the link: its own overrides first,
+// then what the destination page advertises, then its title and description.
+func (l *Link) Card() OpenGraph {
+	card := l.OpenGraph
+
+	if card.Title == "" {
+		card.Title = firstOf(l.Page.Title, l.Title)
+	}
+
+	if card.Title == "" {
+		if u, err := url.Parse(l.OriginalLink); err == nil {
+			card.Title = u.Host
+		}
+	}
+
+	if card.Description == "" {
+		card.Description = firstOf(l.Page.Description, l.Description)
+	}
+
+	if card.Image == "" {
+		card.Image = l.Page.Image
+	}
+
+	return card
+}
+
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// Page is what the destination page of a link advertises about itself,
+// fetched in the background after the link is created.
+type Page struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Image       string `json:"image,omitempty"`
+	Favicon     string `json:"favicon,omitempty"`
+}
+
+// IsDefined lets easyjson omit pages that were not fetched.
+func (p Page) IsDefined() bool {
+	return p != Page{}
+}
+
+// UTM holds the campaign parameters appended to the destination of a link at redirect time.
+type UTM struct {
+	Source   string `json:"source,omitempty"`
+	Medium   string `json:"medium,omitempty"`
+	Campaign string `json:"campaign,omitempty"`
+	Term     string `json:"term,omitempty"`
+	Content  string `json:"content,omitempty"`
+}
+
+// Empty reports whether no parameter is set.
+func (u UTM) Empty() bool {
+	return u == UTM{}
+}
+
+// IsDefined lets easyjson omit UTM parameters that are not set.
+func (u UTM) IsDefined() bool {
+	return !u.Empty()
+}
+
+// Params returns the parameters that are set under their utm_* query names.
+func (u UTM) Params() url.Values {
+	params := url.Values{}
+
+	for name, value := range map[string]string{
+		"utm_source":   u.Source,
+		"utm_medium":   u.Medium,
+		"utm_campaign": u.Campaign,
+		"utm_term":     u.Term,
+		"utm_content":  u.Content,
+	} {
+		if value != "" {
+			params.Set(name, value)
+		}
+	}
+
+	return params
+}
+
+// Merge returns u with the parameters it lacks taken from preset.
+func (u UTM) Merge(preset UTM) UTM {
+	if u.Source == "" {
+		u.Source = preset.Source
+	}
+
+	if u.Medium == "" {
+		u.Medium = preset.Medium
+	}
+
+	if u.Campaign == "" {
+		u.Campaign = preset.Campaign
+	}
+
+	if u.Term == "" {
+		u.Term = preset.Term
+	}
+
+	if u.Content == "" {
+		u.Content = preset.Content
+	}
+
+	return u
+}
+
+// Redirect tells how a resolved link sends the visitor on: with a redirect
+// of status Code, or with an interstitial page naming Target that moves on
+// by itself after Delay seconds, if Delay is positive. Redirects of links
+// that may send the next visit elsewhere are not Cacheable. Sticky redirects
+// went to a variant the visitor should keep on their next visit.
+//
+//easyjson:skip
+type Redirect struct {
+	Target       string
+	Code         int
+	Interstitial bool
+	Delay        int
+	ExpiresAt    time.Time
+	Cacheable    bool
+	Sticky       bool
+}
+
+// QRCode is a rendered QR code image of a short link.
+//
+//easyjson:skip
+type QRCode struct {
+	Image       []byte
+	ContentType string
+}
+
+// QueryMode tells how the query string of a visit is merged into the destination.
+type QueryMode string
+
+const (
+	// QueryDrop ignores the query string of the visit. It is the default.
+	QueryDrop QueryMode = "drop"
+	// QueryAppend adds the parameters of the visit to those of the destination.
+	QueryAppend QueryMode = "append"
+	// QueryOverride replaces parameters of the destination the visit also sets.
+	QueryOverride QueryMode = "override"
+)
+
+// Variant is one of several weighted destinations a link splits its visits between.
+// Hits are the visits it was served; they are counted apart from the link.
+type Variant struct {
+	Target string `json:"target" validate:"required,max=2048,weburl"`
+	Weight int    `json:"weight" validate:"gt=0,max=10000"`
+	Hits   int64  `json:"-"`
+}
+
+// Rule sends visits matching every non-empty condition to Target.
+// Rules are evaluated in order; visits no rule matches go to the variants or OriginalLink.
+type Rule struct {
+	Browser  string            `json:"browser,omitempty"`
+	OS       string            `json:"os,omitempty"`
+	Language string            `json:"language,omitempty"`
+	Country  string            `json:"country,omitempty"`
+	Query    map[string]string `json:"query,omitempty"`
+	Target   string            `json:"target" validate:"required,max=2048,weburl"`
+}
+
+// Empty reports whether the rule has no conditions and would match every visit.
+func (r *Rule) Empty() bool {
+	return r.Browser == "" && r.OS == "" && r.Language == "" && r.Country == "" && len(r.Query) == 0
+}
+
+// LinkEventType tells what happened to a link.
+type LinkEventType string
+
+const (
+	// LinkCreated is published for links stored for the first time.
+	LinkCreated LinkEventType = "created"
+	// LinkUpdated is published when the metadata of a link changes.
+	LinkUpdated LinkEventType = "updated"
+	// LinkExpired is published when expired links are swept from storage.
+	LinkExpired LinkEventType = "expired"
+	// LinkDeleted is published when the owner of a link deletes it.
+	LinkDeleted LinkEventType = "deleted"
+)
+
+// LinkEvent tells watchers about a change to a link. Cursor is set on
+// delivery and resumes watching after this event. Link is the link after
+// the change, prepared as for listing; it is nil for expired and deleted links.
+//
+//easyjson:skip
+type LinkEvent struct {
+	Cursor string
+	Type   LinkEventType
+	Domain string
+	Token  string
+	Time   time.Time
+	Link   *Link
+}
+
+// Visit describes the request resolving a link.
+//
+//easyjson:skip
+type Visit struct {
+	// VisitorID identifies a returning visitor so they keep seeing the same variant.
+	VisitorID string
+	// Path is the part of the request path after the token, if any.
+	Path           string
+	UserAgent      string
+	AcceptLanguage string
+	IP             net.IP
+	Query          url.Values
+}
+
+// Protected reports whether the link requires a password before redirecting.
+func (l *Link) Protected() bool {
+	return l.PasswordHash != ""
+}
+
+// Limited reports whether the link burns after MaxClicks redirects.
+func (l *Link) Limited() bool {
+	return l.MaxClicks > 0
+}
+
+// Active reports whether the link may resolve at now.
+func (l *Link) Active(now time.Time) bool {
+	return !now.Before(l.ActiveFrom)
+}
+
+// Split reports whether the link divides its visits between variants.
+func (l *Link) Split() bool {
+	return len(l.Variants) > 0
+}
+
+// Static reports whether every visit of the link goes to the same destination.
+func (l *Link) Static() bool {
+	return !l.Protected() && !l.Limited() && len(l.Rules) == 0 && !l.Split()
+}
+
+// Forwards reports whether the link passes the path or query of a visit on to its destination.
+func (l *Link) Forwards() bool {
+	return l.ForwardPath || (l.ForwardQuery != "" && l.ForwardQuery != QueryDrop)
 }
 
 // func (l *Link) Expired(now string) bool {
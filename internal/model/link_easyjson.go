@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson16eb09bcDecodeShortLinkAPIInternalModel(in *jlexer.Lexer, out *Link) {
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel(in *jlexer.Lexer, out *Variant) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -36,16 +36,664 @@ func easyjson16eb09bcDecodeShortLinkAPIInternalModel(in *jlexer.Lexer, out *Link
 			continue
 		}
 		switch key {
-		case "OriginalLink":
+		case "target":
+			out.Target = string(in.String())
+		case "weight":
+			out.Weight = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel(out *jwriter.Writer, in Variant) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Target))
+	}
+	{
+		const prefix string = ",\"weight\":"
+		out.RawString(prefix)
+		out.Int(int(in.Weight))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Variant) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Variant) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Variant) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Variant) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel(l, v)
+}
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel1(in *jlexer.Lexer, out *UTM) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "source":
+			out.Source = string(in.String())
+		case "medium":
+			out.Medium = string(in.String())
+		case "campaign":
+			out.Campaign = string(in.String())
+		case "term":
+			out.Term = string(in.String())
+		case "content":
+			out.Content = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel1(out *jwriter.Writer, in UTM) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Source != "" {
+		const prefix string = ",\"source\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Source))
+	}
+	if in.Medium != "" {
+		const prefix string = ",\"medium\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Medium))
+	}
+	if in.Campaign != "" {
+		const prefix string = ",\"campaign\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Campaign))
+	}
+	if in.Term != "" {
+		const prefix string = ",\"term\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Term))
+	}
+	if in.Content != "" {
+		const prefix string = ",\"content\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Content))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UTM) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UTM) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UTM) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UTM) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel1(l, v)
+}
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel2(in *jlexer.Lexer, out *Rule) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "browser":
+			out.Browser = string(in.String())
+		case "os":
+			out.OS = string(in.String())
+		case "language":
+			out.Language = string(in.String())
+		case "country":
+			out.Country = string(in.String())
+		case "query":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Query = make(map[string]string)
+				} else {
+					out.Query = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v1 string
+					v1 = string(in.String())
+					(out.Query)[key] = v1
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "target":
+			out.Target = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel2(out *jwriter.Writer, in Rule) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Browser != "" {
+		const prefix string = ",\"browser\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Browser))
+	}
+	if in.OS != "" {
+		const prefix string = ",\"os\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.OS))
+	}
+	if in.Language != "" {
+		const prefix string = ",\"language\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Language))
+	}
+	if in.Country != "" {
+		const prefix string = ",\"country\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Country))
+	}
+	if len(in.Query) != 0 {
+		const prefix string = ",\"query\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v2First := true
+			for v2Name, v2Value := range in.Query {
+				if v2First {
+					v2First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v2Name))
+				out.RawByte(':')
+				out.String(string(v2Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"target\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Target))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Rule) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Rule) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Rule) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Rule) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel2(l, v)
+}
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel3(in *jlexer.Lexer, out *Page) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "title":
+			out.Title = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "image":
+			out.Image = string(in.String())
+		case "favicon":
+			out.Favicon = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel3(out *jwriter.Writer, in Page) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Title))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Description))
+	}
+	if in.Image != "" {
+		const prefix string = ",\"image\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Image))
+	}
+	if in.Favicon != "" {
+		const prefix string = ",\"favicon\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Favicon))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Page) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Page) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Page) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Page) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel3(l, v)
+}
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel4(in *jlexer.Lexer, out *OpenGraph) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "title":
+			out.Title = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "image":
+			out.Image = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel4(out *jwriter.Writer, in OpenGraph) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Title))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Description))
+	}
+	if in.Image != "" {
+		const prefix string = ",\"image\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Image))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v OpenGraph) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OpenGraph) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *OpenGraph) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OpenGraph) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel4(l, v)
+}
+func easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel5(in *jlexer.Lexer, out *Link) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "original_link":
 			out.OriginalLink = string(in.String())
-		case "ShortLink":
-			out.ShortLink = string(in.String())
-		case "Token":
+		case "canonical_link":
+			out.CanonicalLink = string(in.String())
+		case "token":
 			out.Token = string(in.String())
-		case "ExpiresAt":
+		case "domain":
+			out.Domain = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		case "expires_at":
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.ExpiresAt).UnmarshalJSON(data))
 			}
+		case "active_from":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ActiveFrom).UnmarshalJSON(data))
+			}
+		case "fallback_link":
+			out.FallbackLink = string(in.String())
+		case "password_hash":
+			out.PasswordHash = string(in.String())
+		case "max_clicks":
+			out.MaxClicks = int64(in.Int64())
+		case "rules":
+			if in.IsNull() {
+				in.Skip()
+				out.Rules = nil
+			} else {
+				in.Delim('[')
+				if out.Rules == nil {
+					if !in.IsDelim(']') {
+						out.Rules = make([]Rule, 0, 0)
+					} else {
+						out.Rules = []Rule{}
+					}
+				} else {
+					out.Rules = (out.Rules)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 Rule
+					(v3).UnmarshalEasyJSON(in)
+					out.Rules = append(out.Rules, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "variants":
+			if in.IsNull() {
+				in.Skip()
+				out.Variants = nil
+			} else {
+				in.Delim('[')
+				if out.Variants == nil {
+					if !in.IsDelim(']') {
+						out.Variants = make([]Variant, 0, 2)
+					} else {
+						out.Variants = []Variant{}
+					}
+				} else {
+					out.Variants = (out.Variants)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 Variant
+					(v4).UnmarshalEasyJSON(in)
+					out.Variants = append(out.Variants, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "forward_path":
+			out.ForwardPath = bool(in.Bool())
+		case "forward_query":
+			out.ForwardQuery = QueryMode(in.String())
+		case "redirect_code":
+			out.RedirectCode = int(in.Int())
+		case "interstitial":
+			out.Interstitial = bool(in.Bool())
+		case "interstitial_delay":
+			out.InterstitialDelay = int(in.Int())
+		case "title":
+			out.Title = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 string
+					v5 = string(in.String())
+					out.Tags = append(out.Tags, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "notes":
+			out.Notes = string(in.String())
+		case "utm":
+			(out.UTM).UnmarshalEasyJSON(in)
+		case "page":
+			(out.Page).UnmarshalEasyJSON(in)
+		case "open_graph":
+			(out.OpenGraph).UnmarshalEasyJSON(in)
 		default:
 			in.SkipRecursive()
 		}
@@ -56,53 +704,180 @@ func easyjson16eb09bcDecodeShortLinkAPIInternalModel(in *jlexer.Lexer, out *Link
 		in.Consumed()
 	}
 }
-func easyjson16eb09bcEncodeShortLinkAPIInternalModel(out *jwriter.Writer, in Link) {
+func easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel5(out *jwriter.Writer, in Link) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"OriginalLink\":"
+		const prefix string = ",\"original_link\":"
 		out.RawString(prefix[1:])
 		out.String(string(in.OriginalLink))
 	}
-	{
-		const prefix string = ",\"ShortLink\":"
+	if in.CanonicalLink != "" {
+		const prefix string = ",\"canonical_link\":"
 		out.RawString(prefix)
-		out.String(string(in.ShortLink))
+		out.String(string(in.CanonicalLink))
 	}
 	{
-		const prefix string = ",\"Token\":"
+		const prefix string = ",\"token\":"
 		out.RawString(prefix)
 		out.String(string(in.Token))
 	}
+	if in.Domain != "" {
+		const prefix string = ",\"domain\":"
+		out.RawString(prefix)
+		out.String(string(in.Domain))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
 	{
-		const prefix string = ",\"ExpiresAt\":"
+		const prefix string = ",\"expires_at\":"
 		out.RawString(prefix)
 		out.Raw((in.ExpiresAt).MarshalJSON())
 	}
+	{
+		const prefix string = ",\"active_from\":"
+		out.RawString(prefix)
+		out.Raw((in.ActiveFrom).MarshalJSON())
+	}
+	if in.FallbackLink != "" {
+		const prefix string = ",\"fallback_link\":"
+		out.RawString(prefix)
+		out.String(string(in.FallbackLink))
+	}
+	if in.PasswordHash != "" {
+		const prefix string = ",\"password_hash\":"
+		out.RawString(prefix)
+		out.String(string(in.PasswordHash))
+	}
+	if in.MaxClicks != 0 {
+		const prefix string = ",\"max_clicks\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MaxClicks))
+	}
+	if len(in.Rules) != 0 {
+		const prefix string = ",\"rules\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v6, v7 := range in.Rules {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				(v7).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Variants) != 0 {
+		const prefix string = ",\"variants\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.Variants {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.ForwardPath {
+		const prefix string = ",\"forward_path\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.ForwardPath))
+	}
+	if in.ForwardQuery != "" {
+		const prefix string = ",\"forward_query\":"
+		out.RawString(prefix)
+		out.String(string(in.ForwardQuery))
+	}
+	if in.RedirectCode != 0 {
+		const prefix string = ",\"redirect_code\":"
+		out.RawString(prefix)
+		out.Int(int(in.RedirectCode))
+	}
+	if in.Interstitial {
+		const prefix string = ",\"interstitial\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Interstitial))
+	}
+	if in.InterstitialDelay != 0 {
+		const prefix string = ",\"interstitial_delay\":"
+		out.RawString(prefix)
+		out.Int(int(in.InterstitialDelay))
+	}
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v10, v11 := range in.Tags {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v11))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Notes != "" {
+		const prefix string = ",\"notes\":"
+		out.RawString(prefix)
+		out.String(string(in.Notes))
+	}
+	if (in.UTM).IsDefined() {
+		const prefix string = ",\"utm\":"
+		out.RawString(prefix)
+		(in.UTM).MarshalEasyJSON(out)
+	}
+	if (in.Page).IsDefined() {
+		const prefix string = ",\"page\":"
+		out.RawString(prefix)
+		(in.Page).MarshalEasyJSON(out)
+	}
+	if (in.OpenGraph).IsDefined() {
+		const prefix string = ",\"open_graph\":"
+		out.RawString(prefix)
+		(in.OpenGraph).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Link) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson16eb09bcEncodeShortLinkAPIInternalModel(&w, v)
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Link) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson16eb09bcEncodeShortLinkAPIInternalModel(w, v)
+	easyjson16eb09bcEncodeGithubComCodeMaster482ShortLinkAPIInternalModel5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Link) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson16eb09bcDecodeShortLinkAPIInternalModel(&r, v)
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Link) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson16eb09bcDecodeShortLinkAPIInternalModel(l, v)
+	easyjson16eb09bcDecodeGithubComCodeMaster482ShortLinkAPIInternalModel5(l, v)
 }
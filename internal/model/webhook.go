@@ -0,0 +1,83 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookEvent names what webhooks are notified of.
+type WebhookEvent string
+
+const (
+	// WebhookLinkCreated is sent for links stored for the first time.
+	WebhookLinkCreated WebhookEvent = "link.created"
+	// WebhookLinkUpdated is sent when the metadata of a link changes.
+	WebhookLinkUpdated WebhookEvent = "link.updated"
+	// WebhookLinkExpired is sent when an expired link is deleted from storage.
+	WebhookLinkExpired WebhookEvent = "link.expired"
+	// WebhookLinkDeleted is sent when the owner of a link deletes it.
+	WebhookLinkDeleted WebhookEvent = "link.deleted"
+	// WebhookLinkClicks is sent when a link reaches one of the click
+	// thresholds of the webhook.
+	WebhookLinkClicks WebhookEvent = "link.clicks"
+)
+
+// Webhook is a URL notified of link events, with the secret its
+// requests are signed with.
+type Webhook struct {
+	ID              string         `json:"id"`
+	URL             string         `json:"url"`
+	Secret          string         `json:"secret"`
+	Events          []WebhookEvent `json:"events,omitempty"`
+	ClickThresholds []int64        `json:"click_thresholds,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+// Subscribed reports whether the webhook is notified of payload.
+func (w *Webhook) Subscribed(payload *WebhookPayload) bool {
+	if payload.Event == WebhookLinkClicks {
+		for _, threshold := range w.ClickThresholds {
+			if threshold == payload.Clicks {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, event := range w.Events {
+		if event == payload.Event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookPayload is the body of a webhook request. ID identifies the
+// delivery, so receivers can drop the requests retried after they
+// already handled them. Link is left out for protected links.
+type WebhookPayload struct {
+	ID        string       `json:"id"`
+	Event     WebhookEvent `json:"event"`
+	Time      time.Time    `json:"time"`
+	Domain    string       `json:"domain,omitempty"`
+	Token     string       `json:"token"`
+	ShortLink string       `json:"short_link,omitempty"`
+	Link      string       `json:"link,omitempty"`
+	Clicks    int64        `json:"clicks,omitempty"`
+}
+
+// WebhookDelivery is a webhook request waiting to be sent, or given up on
+// once Dead. Attempts counts the failed ones so far.
+type WebhookDelivery struct {
+	ID          string          `json:"id"`
+	WebhookID   string          `json:"webhook_id"`
+	Event       WebhookEvent    `json:"event"`
+	Payload     json.RawMessage `json:"payload"`
+	Attempts    int             `json:"attempts"`
+	NextAttempt time.Time       `json:"next_attempt"`
+	LastError   string          `json:"last_error,omitempty"`
+	Dead        bool            `json:"dead,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
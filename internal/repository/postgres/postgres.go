@@ -31,11 +31,49 @@ type LinkStorage struct {
 	db DBConn
 }
 
-func (store *LinkStorage) GetLink(ctx context.Context, token string) (*model.Link, error) {
-	query := `SELECT s.original_link, s.token, s.expires_at FROM link s WHERE s.token = $1;`
+// linkColumns are the columns scanLink reads, in order.
+const linkColumns = `s.original_link, s.canonical_link, s.token, s.domain, s.expires_at, s.active_from, s.fallback_link, s.password_hash, s.max_clicks, s.rules, s.variants, s.forward_path, s.forward_query, s.redirect_code, s.interstitial, s.interstitial_delay, s.title, s.description, s.tags, s.notes, s.utm, s.page, s.open_graph, s.created_at`
+
+func scanLink(row pgx.Row) (*model.Link, error) {
 	link := model.Link{}
 
-	err := store.db.QueryRow(context.Background(), query, token).Scan(&link.OriginalLink, &link.Token, &link.ExpiresAt)
+	err := row.Scan(
+		&link.OriginalLink,
+		&link.CanonicalLink,
+		&link.Token,
+		&link.Domain,
+		&link.ExpiresAt,
+		&link.ActiveFrom,
+		&link.FallbackLink,
+		&link.PasswordHash,
+		&link.MaxClicks,
+		&link.Rules,
+		&link.Variants,
+		&link.ForwardPath,
+		&link.ForwardQuery,
+		&link.RedirectCode,
+		&link.Interstitial,
+		&link.InterstitialDelay,
+		&link.Title,
+		&link.Description,
+		&link.Tags,
+		&link.Notes,
+		&link.UTM,
+		&link.Page,
+		&link.OpenGraph,
+		&link.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+func (store *LinkStorage) GetLink(ctx context.Context, domain, token string) (*model.Link, error) {
+	query := `SELECT ` + linkColumns + ` FROM link s WHERE s.domain = $1 AND s.token = $2;`
+
+	link, err := scanLink(store.db.QueryRow(context.Background(), query, domain, token))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apierror.ErrLinkNotFound
@@ -43,21 +81,218 @@ func (store *LinkStorage) GetLink(ctx context.Context, token string) (*model.Lin
 		return nil, err
 	}
 
-	return &link, nil
+	return link, nil
 }
 
 func (store *LinkStorage) StoreLink(ctx context.Context, link *model.Link) error {
-	query := `INSERT INTO link (original_link, token, expires_at) VALUES ($1, $2, $3);`
+	query := `INSERT INTO link (original_link, canonical_link, token, domain, expires_at, active_from, fallback_link, password_hash, max_clicks, clicks_left, rules, variants, forward_path, forward_query, redirect_code, interstitial, interstitial_delay, title, description, tags, notes, utm, open_graph, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23);`
+
+	_, err := store.db.Exec(context.Background(), query,
+		link.OriginalLink,
+		link.CanonicalLink,
+		link.Token,
+		link.Domain,
+		link.ExpiresAt,
+		link.ActiveFrom,
+		link.FallbackLink,
+		link.PasswordHash,
+		link.MaxClicks,
+		link.Rules,
+		link.Variants,
+		link.ForwardPath,
+		link.ForwardQuery,
+		link.RedirectCode,
+		link.Interstitial,
+		link.InterstitialDelay,
+		link.Title,
+		link.Description,
+		tags(link),
+		link.Notes,
+		link.UTM,
+		link.OpenGraph,
+		link.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateMetadata stores the title, description, tags, notes and
+// Open Graph overrides of link.
+func (store *LinkStorage) UpdateMetadata(ctx context.Context, link *model.Link) error {
+	query := `UPDATE link SET title = $3, description = $4, tags = $5, notes = $6, open_graph = $7 WHERE domain = $1 AND token = $2;`
+
+	tag, err := store.db.Exec(ctx, query, link.Domain, link.Token, link.Title, link.Description, tags(link), link.Notes, link.OpenGraph)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apierror.ErrLinkNotFound
+	}
+
+	return nil
+}
+
+// DeleteLink deletes a link along with the visits counted per variant.
+func (store *LinkStorage) DeleteLink(ctx context.Context, domain, token string) error {
+	query := `DELETE FROM link WHERE domain = $1 AND token = $2;`
 
-	_, err := store.db.Exec(context.Background(), query, link.OriginalLink, link.Token, link.ExpiresAt)
+	tag, err := store.db.Exec(ctx, query, domain, token)
 	if err != nil {
 		return err
 	}
+
+	if tag.RowsAffected() == 0 {
+		return apierror.ErrLinkNotFound
+	}
+
 	return nil
 }
 
-func (store *LinkStorage) StartRecalculation(interval time.Duration, deleted chan []string) {
-	query := `DELETE FROM link WHERE expires_at < $1 RETURNING token`
+// StorePage stores what the destination page of a link advertises.
+func (store *LinkStorage) StorePage(ctx context.Context, domain, token string, page model.Page) error {
+	query := `UPDATE link SET page = $3 WHERE domain = $1 AND token = $2;`
+
+	tag, err := store.db.Exec(ctx, query, domain, token, page)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apierror.ErrLinkNotFound
+	}
+
+	return nil
+}
+
+// ListLinksByTag returns up to limit links tagged with tag, newest first.
+func (store *LinkStorage) ListLinksByTag(ctx context.Context, tag string, limit int) ([]*model.Link, error) {
+	query := `SELECT ` + linkColumns + ` FROM link s WHERE $1 = ANY(s.tags) ORDER BY s.id DESC LIMIT $2;`
+
+	rows, err := store.db.Query(ctx, query, tag, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*model.Link
+
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// tags keeps the NOT NULL tags column an empty array for untagged links.
+func tags(link *model.Link) []string {
+	if link.Tags == nil {
+		return []string{}
+	}
+
+	return link.Tags
+}
+
+// ConsumeClick atomically spends one click of a limited link and returns the clicks left.
+func (store *LinkStorage) ConsumeClick(ctx context.Context, domain, token string) (int64, error) {
+	query := `UPDATE link SET clicks_left = clicks_left - 1 WHERE domain = $1 AND token = $2 AND clicks_left > 0 RETURNING clicks_left;`
+
+	var left int64
+
+	err := store.db.QueryRow(ctx, query, domain, token).Scan(&left)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, apierror.ErrLinkExhausted
+		}
+		return 0, err
+	}
+
+	return left, nil
+}
+
+// ClicksLeft returns the clicks a limited link has left without spending one.
+func (store *LinkStorage) ClicksLeft(ctx context.Context, domain, token string) (int64, error) {
+	query := `SELECT clicks_left FROM link WHERE domain = $1 AND token = $2;`
+
+	var left int64
+
+	err := store.db.QueryRow(ctx, query, domain, token).Scan(&left)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, apierror.ErrLinkNotFound
+		}
+		return 0, err
+	}
+
+	return left, nil
+}
+
+// CountClick counts a visit of a link and returns its visits so far.
+func (store *LinkStorage) CountClick(ctx context.Context, domain, token string) (int64, error) {
+	query := `UPDATE link SET clicks = clicks + 1 WHERE domain = $1 AND token = $2 RETURNING clicks;`
+
+	var clicks int64
+
+	err := store.db.QueryRow(ctx, query, domain, token).Scan(&clicks)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, apierror.ErrLinkNotFound
+		}
+		return 0, err
+	}
+
+	return clicks, nil
+}
+
+// RecordVariant counts a visit served the variant at index variant of a split link.
+func (store *LinkStorage) RecordVariant(ctx context.Context, domain, token string, variant int) error {
+	query := `INSERT INTO link_variant (domain, token, variant, hits) VALUES ($1, $2, $3, 1) ON CONFLICT (domain, token, variant) DO UPDATE SET hits = link_variant.hits + 1;`
+
+	_, err := store.db.Exec(ctx, query, domain, token, variant)
+
+	return err
+}
+
+// VariantHits returns the visits served each variant of a split link, by index.
+// Variants never served are left out.
+func (store *LinkStorage) VariantHits(ctx context.Context, domain, token string) (map[int]int64, error) {
+	query := `SELECT variant, hits FROM link_variant WHERE domain = $1 AND token = $2;`
+
+	rows, err := store.db.Query(ctx, query, domain, token)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := make(map[int]int64)
+
+	for rows.Next() {
+		var (
+			variant int
+			n       int64
+		)
+
+		if err := rows.Scan(&variant, &n); err != nil {
+			return nil, err
+		}
+
+		hits[variant] = n
+	}
+
+	return hits, rows.Err()
+}
+
+// StartRecalculation deletes expired links every interval and sends the
+// deleted ones, identified by domain and token, to deleted.
+func (store *LinkStorage) StartRecalculation(interval time.Duration, deleted chan []*model.Link) {
+	query := `DELETE FROM link WHERE expires_at < $1 RETURNING domain, token`
 	ticker := time.NewTicker(interval)
 
 	go func() {
@@ -68,15 +303,15 @@ func (store *LinkStorage) StartRecalculation(interval time.Duration, deleted cha
 				continue
 			}
 
-			var del []string
+			var del []*model.Link
 
 			for rows.Next() {
-				var deletedToken string
-				if err := rows.Scan(&deletedToken); err != nil {
+				deletedLink := &model.Link{}
+				if err := rows.Scan(&deletedLink.Domain, &deletedLink.Token); err != nil {
 					continue
 				}
 
-				del = append(del, deletedToken)
+				del = append(del, deletedLink)
 			}
 			deleted <- del
 		}
@@ -0,0 +1,102 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/postgres"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with a database holding build/schema, e.g. the one of docker-compose:
+//
+//	DB_HOST=localhost DB_PORT=5432 DB_USER=... DB_PASSWORD=... DB_NAME=... go test -tags integration ./internal/repository/postgres/
+func newIntegrationStorage(t *testing.T) *LinkStorage {
+	t.Helper()
+
+	host := os.Getenv("DB_HOST")
+	if host == "" {
+		t.Skip("DB_HOST is not set")
+	}
+
+	port, err := strconv.Atoi(os.Getenv("DB_PORT"))
+	require.NoError(t, err, "DB_PORT")
+
+	pg, err := postgres.New(host, os.Getenv("DB_USER"), os.Getenv("DB_PASSWORD"), os.Getenv("DB_NAME"), port,
+		postgres.MaxPoolSize(16),
+	)
+	require.NoError(t, err)
+	t.Cleanup(pg.Close)
+
+	return NewLinkStorage(pg.Pool)
+}
+
+func TestLinkStorage_ConsumeClick_Concurrent(t *testing.T) {
+	repo := newIntegrationStorage(t)
+
+	const (
+		budget   = 5
+		visitors = 50
+	)
+
+	token := "it" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	err := repo.StoreLink(context.TODO(), &model.Link{
+		OriginalLink: "https://www.example.com",
+		Token:        token,
+		CreatedAt:    time.Now(),
+		ExpiresAt:    time.Now().Add(time.Hour),
+		MaxClicks:    budget,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = repo.db.Exec(context.Background(), `DELETE FROM link WHERE domain = $1 AND token = $2;`, "", token)
+	})
+
+	var (
+		wg        sync.WaitGroup
+		served    atomic.Int64
+		exhausted atomic.Int64
+	)
+
+	for i := 0; i < visitors; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := repo.ConsumeClick(context.TODO(), "", token)
+
+			switch {
+			case err == nil:
+				served.Add(1)
+			case errors.Is(err, apierror.ErrLinkExhausted):
+				exhausted.Add(1)
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int64(budget), served.Load(), "every click of the budget must be served exactly once")
+	assert.Equal(t, int64(visitors-budget), exhausted.Load())
+
+	left, err := repo.ClicksLeft(context.TODO(), "", token)
+	require.NoError(t, err)
+	assert.Zero(t, left)
+}
@@ -10,37 +10,60 @@ import (
 	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
 
 	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
-	"github.com/CodeMaster482/ShortLinkAPI/internal/utils"
 
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/pashagolub/pgxmock"
 	"github.com/stretchr/testify/assert"
 )
 
 const (
-	getLinkByToken    = `SELECT s.original_link, s.token, s.expires_at FROM link s WHERE s.token = $1;`
-	getLinkByFullLink = `SELECT s.original_link, s.token, s.expires_at FROM link s WHERE s.original_link = $1;`
-	addLink           = `INSERT INTO link (original, short, expiration_time) VALUES ($1, $2, $3);`
+	getLinkByToken = `SELECT s.original_link, s.canonical_link, s.token, s.domain, s.expires_at, s.active_from, s.fallback_link, s.password_hash, s.max_clicks, s.rules, s.variants, s.forward_path, s.forward_query, s.redirect_code, s.interstitial, s.interstitial_delay, s.title, s.description, s.tags, s.notes, s.utm, s.page, s.open_graph, s.created_at FROM link s WHERE s.domain = $1 AND s.token = $2;`
+	addLink        = `INSERT INTO link (original_link, canonical_link, token, domain, expires_at, active_from, fallback_link, password_hash, max_clicks, clicks_left, rules, variants, forward_path, forward_query, redirect_code, interstitial, interstitial_delay, title, description, tags, notes, utm, open_graph, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23);`
+	consumeClick   = `UPDATE link SET clicks_left = clicks_left - 1 WHERE domain = $1 AND token = $2 AND clicks_left > 0 RETURNING clicks_left;`
+	updateMetadata = `UPDATE link SET title = $3, description = $4, tags = $5, notes = $6, open_graph = $7 WHERE domain = $1 AND token = $2;`
+	listLinksByTag = `SELECT s.original_link, s.canonical_link, s.token, s.domain, s.expires_at, s.active_from, s.fallback_link, s.password_hash, s.max_clicks, s.rules, s.variants, s.forward_path, s.forward_query, s.redirect_code, s.interstitial, s.interstitial_delay, s.title, s.description, s.tags, s.notes, s.utm, s.page, s.open_graph, s.created_at FROM link s WHERE $1 = ANY(s.tags) ORDER BY s.id DESC LIMIT $2;`
+	storePage      = `UPDATE link SET page = $3 WHERE domain = $1 AND token = $2;`
+	deleteLink     = `DELETE FROM link WHERE domain = $1 AND token = $2;`
+	countClick     = `UPDATE link SET clicks = clicks + 1 WHERE domain = $1 AND token = $2 RETURNING clicks;`
+	clicksLeft     = `SELECT clicks_left FROM link WHERE domain = $1 AND token = $2;`
+	variantHits    = `SELECT variant, hits FROM link_variant WHERE domain = $1 AND token = $2;`
+	recordVariant  = `INSERT INTO link_variant (domain, token, variant, hits) VALUES ($1, $2, $3, 1) ON CONFLICT (domain, token, variant) DO UPDATE SET hits = link_variant.hits + 1;`
 )
 
 func TestPostgreSQLRepository_StoreLink(t *testing.T) {
 	timeLink := time.Now().Add(24 * time.Hour)
 	testCases := []struct {
-		name           string
-		longUrl        string
-		shortUrl       string
-		expirationTime time.Time
-		link           model.Link
-		expectQuery    string
-		expectArgs     []interface{}
-		expectError    error
+		name        string
+		link        model.Link
+		expectQuery string
+		expectError error
 	}{
 		{
 			name: "Valid case",
 			link: model.Link{
-				OriginalLink: "http://example.com",
-				Token:        "abc123",
-				ExpiresAt:    timeLink,
+				OriginalLink:  "http://Example.com/",
+				CanonicalLink: "http://example.com/",
+				Token:         "abc123",
+				CreatedAt:     timeLink.Add(-24 * time.Hour),
+				ExpiresAt:     timeLink,
+				ActiveFrom:    timeLink.Add(-time.Hour),
+				FallbackLink:  "http://example.com/soon",
+				PasswordHash:  "$2a$10$hash",
+				MaxClicks:     3,
+				Rules:         []model.Rule{{OS: "ios", Target: "https://apps.apple.com/app/id1"}},
+				Variants: []model.Variant{
+					{Target: "http://example.com/a", Weight: 3},
+					{Target: "http://example.com/b", Weight: 1},
+				},
+				ForwardPath:       true,
+				ForwardQuery:      model.QueryOverride,
+				Interstitial:      true,
+				InterstitialDelay: 5,
+				Title:             "Example",
+				Tags:              []string{"docs"},
+				UTM:               model.UTM{Source: "newsletter"},
+				OpenGraph:         model.OpenGraph{Image: "https://example.com/card.png"},
 			},
 			expectQuery: addLink,
 			expectError: nil,
@@ -69,19 +92,17 @@ func TestPostgreSQLRepository_StoreLink(t *testing.T) {
 
 			escapedQuery := regexp.QuoteMeta(tc.expectQuery)
 
-			mock.ExpectExec(escapedQuery).
-				WithArgs(tc.longUrl, tc.shortUrl, tc.expirationTime).
-				WillReturnResult(pgxmock.NewResult("INSERT", 1)).
-				WillReturnError(tc.expectError)
-
-			err := repo.StoreLink(
-				context.TODO(),
-				&model.Link{
-					OriginalLink: tc.longUrl,
-					Token:        tc.shortUrl,
-					ExpiresAt:    tc.expirationTime,
-				},
-			)
+			exec := mock.ExpectExec(escapedQuery).
+				WithArgs(tc.link.OriginalLink, tc.link.CanonicalLink, tc.link.Token, tc.link.Domain, tc.link.ExpiresAt, tc.link.ActiveFrom, tc.link.FallbackLink, tc.link.PasswordHash, tc.link.MaxClicks, tc.link.Rules, tc.link.Variants, tc.link.ForwardPath, tc.link.ForwardQuery,
+					tc.link.RedirectCode, tc.link.Interstitial, tc.link.InterstitialDelay, tc.link.Title, tc.link.Description, tags(&tc.link), tc.link.Notes, tc.link.UTM, tc.link.OpenGraph, tc.link.CreatedAt)
+
+			if tc.expectError != nil {
+				exec.WillReturnError(tc.expectError)
+			} else {
+				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			err := repo.StoreLink(context.TODO(), &tc.link)
 
 			if tc.expectError != nil {
 				assert.EqualError(t, err, tc.expectError.Error())
@@ -106,13 +127,29 @@ func TestLinkStorage_GetLink(t *testing.T) {
 		{
 			name:  "Valid case",
 			token: "abc123",
-			rows: pgxmock.NewRows([]string{"original_link", "token", "expires_at"}).
-				AddRow("www.youtube.com", "short", "2012-01-10T00:00:00Z"),
+			rows: pgxmock.NewRows([]string{"original_link", "canonical_link", "token", "domain", "expires_at", "active_from", "fallback_link", "password_hash", "max_clicks", "rules", "variants", "forward_path", "forward_query", "redirect_code", "interstitial", "interstitial_delay", "title", "description", "tags", "notes", "utm", "page", "open_graph", "created_at"}).
+				AddRow("https://www.YouTube.com", "https://www.youtube.com/", "short", "go.example.com",
+					time.Date(2012, time.January, 10, 0, 0, 0, 0, time.UTC), time.Date(2012, time.January, 9, 0, 0, 0, 0, time.UTC),
+					"", "", int64(0), []model.Rule{{Country: "DE", Target: "https://www.youtube.de/"}}, []model.Variant(nil), true, model.QueryAppend, 301, false, 0,
+					"YouTube", "", []string{"video"}, "", model.UTM{Campaign: "launch"}, model.Page{Title: "YouTube"}, model.OpenGraph{Title: "Watch"}, time.Date(2012, time.January, 8, 0, 0, 0, 0, time.UTC)),
 			expectError: nil,
 			result: &model.Link{
-				OriginalLink: "www.youtube.com",
-				Token:        "short",
-				ExpiresAt:    time.Date(2012, time.January, 10, 0, 0, 0, 0, time.UTC),
+				OriginalLink:  "https://www.YouTube.com",
+				CanonicalLink: "https://www.youtube.com/",
+				Token:         "short",
+				Domain:        "go.example.com",
+				ExpiresAt:     time.Date(2012, time.January, 10, 0, 0, 0, 0, time.UTC),
+				ActiveFrom:    time.Date(2012, time.January, 9, 0, 0, 0, 0, time.UTC),
+				Rules:         []model.Rule{{Country: "DE", Target: "https://www.youtube.de/"}},
+				ForwardPath:   true,
+				ForwardQuery:  model.QueryAppend,
+				RedirectCode:  301,
+				Title:         "YouTube",
+				Tags:          []string{"video"},
+				UTM:           model.UTM{Campaign: "launch"},
+				Page:          model.Page{Title: "YouTube"},
+				OpenGraph:     model.OpenGraph{Title: "Watch"},
+				CreatedAt:     time.Date(2012, time.January, 8, 0, 0, 0, 0, time.UTC),
 			},
 		},
 		{
@@ -145,14 +182,18 @@ func TestLinkStorage_GetLink(t *testing.T) {
 				db: mock,
 			}
 
-			escapedQuery := regexp.QuoteMeta("SELECT s.original_link, s.token, s.expires_at FROM link s WHERE s.token = $1")
+			escapedQuery := regexp.QuoteMeta(getLinkByToken)
 
-			mock.ExpectQuery(escapedQuery).
-				WithArgs(tc.token).
-				WillReturnRows(tc.rows).
-				WillReturnError(tc.errorPgx)
+			query := mock.ExpectQuery(escapedQuery).
+				WithArgs("go.example.com", tc.token)
+
+			if tc.errorPgx != nil {
+				query.WillReturnError(tc.errorPgx)
+			} else {
+				query.WillReturnRows(tc.rows)
+			}
 
-			result, err := repo.GetLink(context.Background(), tc.token)
+			result, err := repo.GetLink(context.Background(), "go.example.com", tc.token)
 
 			if tc.expectError != nil {
 				assert.EqualError(t, err, tc.expectError.Error())
@@ -167,6 +208,453 @@ func TestLinkStorage_GetLink(t *testing.T) {
 	}
 }
 
+func TestLinkStorage_ConsumeClick(t *testing.T) {
+	testCases := []struct {
+		name         string
+		rows         *pgxmock.Rows
+		errorPgx     error
+		expectedLeft int64
+		expectError  error
+	}{
+		{
+			name:         "Click spent",
+			rows:         pgxmock.NewRows([]string{"clicks_left"}).AddRow(int64(2)),
+			expectedLeft: 2,
+		},
+		{
+			name:        "Budget exhausted",
+			errorPgx:    pgx.ErrNoRows,
+			expectError: apierror.ErrLinkExhausted,
+		},
+		{
+			name:        "Error case",
+			errorPgx:    errors.New("mock error"),
+			expectError: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mock, mockErr := pgxmock.NewPool()
+
+			if mockErr != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", mockErr)
+			}
+
+			repo := &LinkStorage{
+				db: mock,
+			}
+
+			query := mock.ExpectQuery(regexp.QuoteMeta(consumeClick)).
+				WithArgs("", "abc123")
+
+			if tc.errorPgx != nil {
+				query.WillReturnError(tc.errorPgx)
+			} else {
+				query.WillReturnRows(tc.rows)
+			}
+
+			left, err := repo.ConsumeClick(context.Background(), "", "abc123")
+
+			if tc.expectError != nil {
+				assert.EqualError(t, err, tc.expectError.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedLeft, left)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLinkStorage_ClicksLeft(t *testing.T) {
+	testCases := []struct {
+		name         string
+		rows         *pgxmock.Rows
+		errorPgx     error
+		expectedLeft int64
+		expectError  error
+	}{
+		{
+			name:         "Clicks left",
+			rows:         pgxmock.NewRows([]string{"clicks_left"}).AddRow(int64(3)),
+			expectedLeft: 3,
+		},
+		{
+			name:        "Not found",
+			errorPgx:    pgx.ErrNoRows,
+			expectError: apierror.ErrLinkNotFound,
+		},
+		{
+			name:        "Error case",
+			errorPgx:    errors.New("mock error"),
+			expectError: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mock, mockErr := pgxmock.NewPool()
+
+			if mockErr != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", mockErr)
+			}
+
+			repo := &LinkStorage{
+				db: mock,
+			}
+
+			query := mock.ExpectQuery(regexp.QuoteMeta(clicksLeft)).
+				WithArgs("", "abc123")
+
+			if tc.errorPgx != nil {
+				query.WillReturnError(tc.errorPgx)
+			} else {
+				query.WillReturnRows(tc.rows)
+			}
+
+			left, err := repo.ClicksLeft(context.Background(), "", "abc123")
+
+			if tc.expectError != nil {
+				assert.EqualError(t, err, tc.expectError.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedLeft, left)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLinkStorage_CountClick(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rows           *pgxmock.Rows
+		errorPgx       error
+		expectedClicks int64
+		expectError    error
+	}{
+		{
+			name:           "Click counted",
+			rows:           pgxmock.NewRows([]string{"clicks"}).AddRow(int64(3)),
+			expectedClicks: 3,
+		},
+		{
+			name:        "Link not found",
+			errorPgx:    pgx.ErrNoRows,
+			expectError: apierror.ErrLinkNotFound,
+		},
+		{
+			name:        "Error case",
+			errorPgx:    errors.New("mock error"),
+			expectError: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mock, mockErr := pgxmock.NewPool()
+
+			if mockErr != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", mockErr)
+			}
+
+			repo := &LinkStorage{
+				db: mock,
+			}
+
+			query := mock.ExpectQuery(regexp.QuoteMeta(countClick)).
+				WithArgs("", "abc123")
+
+			if tc.errorPgx != nil {
+				query.WillReturnError(tc.errorPgx)
+			} else {
+				query.WillReturnRows(tc.rows)
+			}
+
+			clicks, err := repo.CountClick(context.Background(), "", "abc123")
+
+			if tc.expectError != nil {
+				assert.EqualError(t, err, tc.expectError.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedClicks, clicks)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLinkStorage_VariantHits(t *testing.T) {
+	testCases := []struct {
+		name         string
+		rows         *pgxmock.Rows
+		errorPgx     error
+		expectedHits map[int]int64
+		expectError  error
+	}{
+		{
+			name:         "Hits read",
+			rows:         pgxmock.NewRows([]string{"variant", "hits"}).AddRow(0, int64(7)).AddRow(2, int64(1)),
+			expectedHits: map[int]int64{0: 7, 2: 1},
+		},
+		{
+			name:         "No hits yet",
+			rows:         pgxmock.NewRows([]string{"variant", "hits"}),
+			expectedHits: map[int]int64{},
+		},
+		{
+			name:        "Error case",
+			errorPgx:    errors.New("mock error"),
+			expectError: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mock, mockErr := pgxmock.NewPool()
+
+			if mockErr != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", mockErr)
+			}
+
+			repo := &LinkStorage{
+				db: mock,
+			}
+
+			query := mock.ExpectQuery(regexp.QuoteMeta(variantHits)).
+				WithArgs("", "abc123")
+
+			if tc.errorPgx != nil {
+				query.WillReturnError(tc.errorPgx)
+			} else {
+				query.WillReturnRows(tc.rows)
+			}
+
+			hits, err := repo.VariantHits(context.Background(), "", "abc123")
+
+			if tc.expectError != nil {
+				assert.EqualError(t, err, tc.expectError.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedHits, hits)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLinkStorage_RecordVariant(t *testing.T) {
+	testCases := []struct {
+		name        string
+		errorPgx    error
+		expectError error
+	}{
+		{
+			name: "Hit recorded",
+		},
+		{
+			name:        "Error case",
+			errorPgx:    errors.New("mock error"),
+			expectError: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mock, mockErr := pgxmock.NewPool()
+
+			if mockErr != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", mockErr)
+			}
+
+			repo := &LinkStorage{
+				db: mock,
+			}
+
+			exec := mock.ExpectExec(regexp.QuoteMeta(recordVariant)).
+				WithArgs("", "abc123", 1)
+
+			if tc.errorPgx != nil {
+				exec.WillReturnError(tc.errorPgx)
+			} else {
+				exec.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			err := repo.RecordVariant(context.Background(), "", "abc123", 1)
+
+			if tc.expectError != nil {
+				assert.EqualError(t, err, tc.expectError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLinkStorage_UpdateMetadata(t *testing.T) {
+	link := &model.Link{Token: "abc123", Title: "Docs", Notes: "for the launch", OpenGraph: model.OpenGraph{Title: "Read the docs"}}
+
+	testCases := []struct {
+		name        string
+		result      pgconn.CommandTag
+		errorPgx    error
+		expectError error
+	}{
+		{
+			name:   "Updated",
+			result: pgxmock.NewResult("UPDATE", 1),
+		},
+		{
+			name:        "Not found",
+			result:      pgxmock.NewResult("UPDATE", 0),
+			expectError: apierror.ErrLinkNotFound,
+		},
+		{
+			name:        "Error case",
+			errorPgx:    errors.New("mock error"),
+			expectError: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mock, mockErr := pgxmock.NewPool()
+
+			if mockErr != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", mockErr)
+			}
+
+			repo := &LinkStorage{
+				db: mock,
+			}
+
+			exec := mock.ExpectExec(regexp.QuoteMeta(updateMetadata)).
+				WithArgs("", "abc123", "Docs", "", []string{}, "for the launch", model.OpenGraph{Title: "Read the docs"})
+
+			if tc.errorPgx != nil {
+				exec.WillReturnError(tc.errorPgx)
+			} else {
+				exec.WillReturnResult(tc.result)
+			}
+
+			err := repo.UpdateMetadata(context.Background(), link)
+
+			if tc.expectError != nil {
+				assert.EqualError(t, err, tc.expectError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLinkStorage_StorePage(t *testing.T) {
+	t.Parallel()
+
+	mock, mockErr := pgxmock.NewPool()
+	if mockErr != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", mockErr)
+	}
+
+	repo := &LinkStorage{
+		db: mock,
+	}
+
+	page := model.Page{Title: "Example", Image: "https://example.com/card.png"}
+
+	mock.ExpectExec(regexp.QuoteMeta(storePage)).
+		WithArgs("", "abc123", page).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(regexp.QuoteMeta(storePage)).
+		WithArgs("", "gone", page).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	assert.NoError(t, repo.StorePage(context.Background(), "", "abc123", page))
+	assert.ErrorIs(t, repo.StorePage(context.Background(), "", "gone", page), apierror.ErrLinkNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLinkStorage_DeleteLink(t *testing.T) {
+	t.Parallel()
+
+	mock, mockErr := pgxmock.NewPool()
+	if mockErr != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", mockErr)
+	}
+
+	repo := &LinkStorage{
+		db: mock,
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(deleteLink)).
+		WithArgs("go.example.com", "abc123").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteLink)).
+		WithArgs("", "gone").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec(regexp.QuoteMeta(deleteLink)).
+		WithArgs("", "abc123").
+		WillReturnError(errors.New("connection reset"))
+
+	assert.NoError(t, repo.DeleteLink(context.Background(), "go.example.com", "abc123"))
+	assert.ErrorIs(t, repo.DeleteLink(context.Background(), "", "gone"), apierror.ErrLinkNotFound)
+	assert.EqualError(t, repo.DeleteLink(context.Background(), "", "abc123"), "connection reset")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLinkStorage_ListLinksByTag(t *testing.T) {
+	t.Parallel()
+
+	mock, mockErr := pgxmock.NewPool()
+	if mockErr != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", mockErr)
+	}
+
+	repo := &LinkStorage{
+		db: mock,
+	}
+
+	expires := time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"original_link", "canonical_link", "token", "domain", "expires_at", "active_from", "fallback_link", "password_hash", "max_clicks", "rules", "variants", "forward_path", "forward_query", "redirect_code", "interstitial", "interstitial_delay", "title", "description", "tags", "notes", "utm", "page", "open_graph", "created_at"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(listLinksByTag)).
+		WithArgs("docs", 10).
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow("https://example.com/b", "", "bbb", "", expires, time.Time{}, "", "", int64(0), []model.Rule(nil), []model.Variant(nil), false, model.QueryMode(""), 0, false, 0, "B", "", []string{"docs"}, "", model.UTM{}, model.Page{}, model.OpenGraph{}, expires.AddDate(-1, 0, 0)).
+			AddRow("https://example.com/a", "", "aaa", "", expires, time.Time{}, "", "", int64(0), []model.Rule(nil), []model.Variant(nil), false, model.QueryMode(""), 0, false, 0, "A", "", []string{"docs", "api"}, "", model.UTM{}, model.Page{}, model.OpenGraph{}, expires.AddDate(-1, 0, 0)))
+
+	links, err := repo.ListLinksByTag(context.Background(), "docs", 10)
+	assert.NoError(t, err)
+	assert.Len(t, links, 2)
+	assert.Equal(t, "bbb", links[0].Token)
+	assert.Equal(t, []string{"docs", "api"}, links[1].Tags)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestStartRecalculation(t *testing.T) {
 	t.Parallel()
 
@@ -181,30 +669,26 @@ func TestStartRecalculation(t *testing.T) {
 	}
 
 	// Set up mock expectations
-	query := regexp.QuoteMeta("DELETE FROM link WHERE expires_at < $1 RETURNING token")
+	query := regexp.QuoteMeta("DELETE FROM link WHERE expires_at < $1 RETURNING domain, token")
 	mock.ExpectQuery(query).
-		WithArgs(utils.CurrentTimeString()).
-		WillReturnRows(pgxmock.NewRows([]string{"token"}).AddRow("deleted_token"))
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{"domain", "token"}).AddRow("", "deleted_token"))
 
-	// Set up channel for receiving deleted tokens
-	deleted := make(chan []string)
-	defer close(deleted)
+	// Set up channel for receiving deleted links
+	deleted := make(chan []*model.Link)
 
 	// Start recalculation goroutine
-	go store.StartRecalculation(time.Second, deleted)
-
-	// Wait for some time to allow the goroutine to execute
-	time.Sleep(5 * time.Second)
+	store.StartRecalculation(10*time.Millisecond, deleted)
 
 	// Check if the expected token is received on the channel
 	select {
 	case deletedTokens := <-deleted:
 		// Check if the expected token is received
-		if len(deletedTokens) != 1 || deletedTokens[0] != "deleted_token" {
+		if len(deletedTokens) != 1 || deletedTokens[0].Token != "deleted_token" {
 			t.Errorf("unexpected deleted tokens: %v", deletedTokens)
 		}
-	default:
-		t.Error("no token received on the channel")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no token received on the channel")
 	}
 
 	// Ensure all expectations are met
@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// WebhookStorage keeps webhooks and the queue of their deliveries.
+type WebhookStorage struct {
+	db DBConn
+}
+
+func NewWebhookStorage(db DBConn) *WebhookStorage {
+	return &WebhookStorage{db}
+}
+
+// deliveryColumns are the columns scanDelivery reads, in order.
+const deliveryColumns = `id, webhook_id, event, payload, attempts, next_attempt, last_error, dead, created_at`
+
+func scanDelivery(row pgx.Row) (*model.WebhookDelivery, error) {
+	var (
+		delivery model.WebhookDelivery
+		payload  string
+	)
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.Event,
+		&payload,
+		&delivery.Attempts,
+		&delivery.NextAttempt,
+		&delivery.LastError,
+		&delivery.Dead,
+		&delivery.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Payload = []byte(payload)
+
+	return &delivery, nil
+}
+
+func scanDeliveries(rows pgx.Rows) ([]*model.WebhookDelivery, error) {
+	defer rows.Close()
+
+	var deliveries []*model.WebhookDelivery
+
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// StoreWebhook stores a new webhook.
+func (store *WebhookStorage) StoreWebhook(ctx context.Context, hook *model.Webhook) error {
+	query := `INSERT INTO webhook (id, url, secret, events, click_thresholds, created_at) VALUES ($1, $2, $3, $4, $5, $6);`
+
+	events := make([]string, len(hook.Events))
+	for i, event := range hook.Events {
+		events[i] = string(event)
+	}
+
+	thresholds := hook.ClickThresholds
+	if thresholds == nil {
+		thresholds = []int64{}
+	}
+
+	_, err := store.db.Exec(ctx, query, hook.ID, hook.URL, hook.Secret, events, thresholds, hook.CreatedAt)
+
+	return err
+}
+
+// ListWebhooks returns every webhook, oldest first.
+func (store *WebhookStorage) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	query := `SELECT id, url, secret, events, click_thresholds, created_at FROM webhook ORDER BY created_at;`
+
+	rows, err := store.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hooks []*model.Webhook
+
+	for rows.Next() {
+		var (
+			hook   model.Webhook
+			events []string
+		)
+
+		if err := rows.Scan(&hook.ID, &hook.URL, &hook.Secret, &events, &hook.ClickThresholds, &hook.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			hook.Events = append(hook.Events, model.WebhookEvent(event))
+		}
+
+		hooks = append(hooks, &hook)
+	}
+
+	return hooks, rows.Err()
+}
+
+// DeleteWebhook deletes a webhook along with its deliveries.
+func (store *WebhookStorage) DeleteWebhook(ctx context.Context, id string) error {
+	query := `DELETE FROM webhook WHERE id = $1;`
+
+	tag, err := store.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apierror.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// EnqueueDelivery queues a delivery to be sent at its next attempt.
+func (store *WebhookStorage) EnqueueDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	query := `INSERT INTO webhook_delivery (` + deliveryColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`
+
+	_, err := store.db.Exec(ctx, query,
+		delivery.ID,
+		delivery.WebhookID,
+		delivery.Event,
+		string(delivery.Payload),
+		delivery.Attempts,
+		delivery.NextAttempt,
+		delivery.LastError,
+		delivery.Dead,
+		delivery.CreatedAt,
+	)
+
+	return err
+}
+
+// ClaimDeliveries returns up to limit deliveries due at now and holds them
+// back until until, so other workers skip them while they are sent. Claimed
+// deliveries neither deleted nor updated by then are claimed again.
+func (store *WebhookStorage) ClaimDeliveries(ctx context.Context, now, until time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	query := `UPDATE webhook_delivery SET next_attempt = $2 WHERE id IN (SELECT id FROM webhook_delivery WHERE NOT dead AND next_attempt <= $1 ORDER BY next_attempt LIMIT $3 FOR UPDATE SKIP LOCKED) RETURNING ` + deliveryColumns + `;`
+
+	rows, err := store.db.Query(ctx, query, now, until, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDeliveries(rows)
+}
+
+// DeleteDelivery takes a delivery off the queue.
+func (store *WebhookStorage) DeleteDelivery(ctx context.Context, id string) error {
+	query := `DELETE FROM webhook_delivery WHERE id = $1;`
+
+	_, err := store.db.Exec(ctx, query, id)
+
+	return err
+}
+
+// UpdateDelivery stores the attempts of a delivery and when to try it
+// next, or moves it to the dead letters once it is dead.
+func (store *WebhookStorage) UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	query := `UPDATE webhook_delivery SET attempts = $2, next_attempt = $3, last_error = $4, dead = $5 WHERE id = $1;`
+
+	_, err := store.db.Exec(ctx, query, delivery.ID, delivery.Attempts, delivery.NextAttempt, delivery.LastError, delivery.Dead)
+
+	return err
+}
+
+// ListDeadDeliveries returns up to limit deliveries of a webhook given up on, newest first.
+func (store *WebhookStorage) ListDeadDeliveries(ctx context.Context, webhookID string, limit int) ([]*model.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_delivery WHERE webhook_id = $1 AND dead ORDER BY created_at DESC LIMIT $2;`
+
+	rows, err := store.db.Query(ctx, query, webhookID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDeliveries(rows)
+}
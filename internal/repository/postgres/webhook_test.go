@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/jackc/pgconn"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	deleteWebhook    = `DELETE FROM webhook WHERE id = $1;`
+	claimDeliveries  = `UPDATE webhook_delivery SET next_attempt = $2 WHERE id IN (SELECT id FROM webhook_delivery WHERE NOT dead AND next_attempt <= $1 ORDER BY next_attempt LIMIT $3 FOR UPDATE SKIP LOCKED) RETURNING id, webhook_id, event, payload, attempts, next_attempt, last_error, dead, created_at;`
+	updateDelivery   = `UPDATE webhook_delivery SET attempts = $2, next_attempt = $3, last_error = $4, dead = $5 WHERE id = $1;`
+	listWebhooksRows = `SELECT id, url, secret, events, click_thresholds, created_at FROM webhook ORDER BY created_at;`
+)
+
+func TestWebhookStorage_DeleteWebhook(t *testing.T) {
+	testCases := []struct {
+		name        string
+		result      pgconn.CommandTag
+		errorPgx    error
+		expectError error
+	}{
+		{
+			name:   "Deleted",
+			result: pgxmock.NewResult("DELETE", 1),
+		},
+		{
+			name:        "Not found",
+			result:      pgxmock.NewResult("DELETE", 0),
+			expectError: apierror.ErrWebhookNotFound,
+		},
+		{
+			name:        "Error case",
+			errorPgx:    errors.New("mock error"),
+			expectError: errors.New("mock error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mock, mockErr := pgxmock.NewPool()
+
+			if mockErr != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", mockErr)
+			}
+
+			repo := NewWebhookStorage(mock)
+
+			exec := mock.ExpectExec(regexp.QuoteMeta(deleteWebhook)).WithArgs("hook")
+			if tc.errorPgx != nil {
+				exec.WillReturnError(tc.errorPgx)
+			} else {
+				exec.WillReturnResult(tc.result)
+			}
+
+			err := repo.DeleteWebhook(context.Background(), "hook")
+
+			if tc.expectError != nil {
+				assert.EqualError(t, err, tc.expectError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookStorage_ListWebhooks(t *testing.T) {
+	t.Parallel()
+
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	repo := NewWebhookStorage(mock)
+	created := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(listWebhooksRows)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "url", "secret", "events", "click_thresholds", "created_at"}).
+			AddRow("hook", "https://example.com/hook", "secret", []string{"link.created", "link.expired"}, []int64{100}, created))
+
+	hooks, err := repo.ListWebhooks(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.Webhook{{
+		ID:              "hook",
+		URL:             "https://example.com/hook",
+		Secret:          "secret",
+		Events:          []model.WebhookEvent{model.WebhookLinkCreated, model.WebhookLinkExpired},
+		ClickThresholds: []int64{100},
+		CreatedAt:       created,
+	}}, hooks)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookStorage_ClaimDeliveries(t *testing.T) {
+	t.Parallel()
+
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	repo := NewWebhookStorage(mock)
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	until := now.Add(time.Minute)
+
+	mock.ExpectQuery(regexp.QuoteMeta(claimDeliveries)).
+		WithArgs(now, until, 10).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "webhook_id", "event", "payload", "attempts", "next_attempt", "last_error", "dead", "created_at"}).
+			AddRow("delivery", "hook", model.WebhookLinkCreated, `{"id":"delivery"}`, 1, until, "timeout", false, now))
+
+	deliveries, err := repo.ClaimDeliveries(context.Background(), now, until, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.WebhookDelivery{{
+		ID:          "delivery",
+		WebhookID:   "hook",
+		Event:       model.WebhookLinkCreated,
+		Payload:     []byte(`{"id":"delivery"}`),
+		Attempts:    1,
+		NextAttempt: until,
+		LastError:   "timeout",
+		CreatedAt:   now,
+	}}, deliveries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookStorage_UpdateDelivery(t *testing.T) {
+	t.Parallel()
+
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	repo := NewWebhookStorage(mock)
+	delivery := &model.WebhookDelivery{
+		ID:          "delivery",
+		Attempts:    5,
+		NextAttempt: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		LastError:   "503 Service Unavailable",
+		Dead:        true,
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(updateDelivery)).
+		WithArgs(delivery.ID, delivery.Attempts, delivery.NextAttempt, delivery.LastError, delivery.Dead).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	assert.NoError(t, repo.UpdateDelivery(context.Background(), delivery))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,14 +4,70 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
 	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/mailru/easyjson"
 )
 
+// consumeClickScript decrements the click budget only while it is positive.
+// It returns the clicks left or -1 once the budget is exhausted.
+var consumeClickScript = redis.NewScript(`
+local left = tonumber(redis.call('GET', KEYS[1]))
+if left == nil or left <= 0 then
+	return -1
+end
+return redis.call('DECR', KEYS[1])
+`)
+
+// recordVariantScript counts a variant hit in a hash that expires with the link.
+var recordVariantScript = redis.NewScript(`
+local hits = redis.call('HINCRBY', KEYS[1], ARGV[1], 1)
+if hits == 1 then
+	local ttl = redis.call('PTTL', KEYS[2])
+	if ttl > 0 then
+		redis.call('PEXPIRE', KEYS[1], ttl)
+	end
+end
+return hits
+`)
+
+// countClickScript counts a visit in a counter that expires with the link.
+// It returns -1 when there is no such link.
+var countClickScript = redis.NewScript(`
+local ttl = redis.call('PTTL', KEYS[2])
+if ttl == -2 then
+	return -1
+end
+local clicks = redis.call('INCR', KEYS[1])
+if clicks == 1 and ttl > 0 then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return clicks
+`)
+
+// sweepExpiredScript takes up to ARGV[2] links expiring at or before ARGV[1]
+// out of the expiry index and returns their keys.
+var sweepExpiredScript = redis.NewScript(`
+local keys = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+if #keys > 0 then
+	redis.call('ZREM', KEYS[1], unpack(keys))
+end
+return keys
+`)
+
+// expiryKey ranks the keys of all links by expiry, so that expired links
+// can be told apart from links that never existed once Redis drops them.
+const expiryKey = "links:expiry"
+
+// sweepBatch bounds the expired links taken out of the index at once.
+const sweepBatch = 1000
+
 type LinkRedisStorage struct {
 	Client *redis.Client
 }
@@ -20,8 +76,8 @@ func NewLinkStorage(cli *redis.Client) *LinkRedisStorage {
 	return &LinkRedisStorage{cli}
 }
 
-func (r *LinkRedisStorage) GetLink(ctx context.Context, token string) (*model.Link, error) {
-	fullLink, err := r.Client.Get(ctx, token).Result()
+func (r *LinkRedisStorage) GetLink(ctx context.Context, domain, token string) (*model.Link, error) {
+	value, err := r.Client.Get(ctx, linkKey(domain, token)).Bytes()
 	if err != nil {
 		if errors.Is(err, redis.Nil) { /* err == redis.Nil */
 			return nil, apierror.ErrLinkNotFound
@@ -30,28 +86,359 @@ func (r *LinkRedisStorage) GetLink(ctx context.Context, token string) (*model.Li
 		return nil, err
 	}
 
-	return &model.Link{
-		OriginalLink: fullLink,
-		Token:        token,
-	}, nil
+	// Keys written before links were stored as JSON hold the bare original URL.
+	if len(value) == 0 || value[0] != '{' {
+		return &model.Link{
+			OriginalLink: string(value),
+			Token:        token,
+		}, nil
+	}
+
+	link := &model.Link{}
+	if err := easyjson.Unmarshal(value, link); err != nil {
+		return nil, fmt.Errorf("error decoding link %s: %w", token, err)
+	}
+
+	link.Token = token
+	link.Domain = domain
+
+	return link, nil
 }
 
 func (r *LinkRedisStorage) StoreLink(ctx context.Context, link *model.Link) error {
-	err := r.Client.Set(ctx, link.Token, link.OriginalLink, 0).Err()
+	value, err := easyjson.Marshal(link)
+	if err != nil {
+		return err
+	}
+
+	key := linkKey(link.Domain, link.Token)
+
+	err = r.Client.Set(ctx, key, value, 0).Err()
 	if err != nil {
 		return err
 	}
 
 	duration := time.Until(link.ExpiresAt)
 
-	err = r.Client.Expire(ctx, link.Token, duration).Err()
+	err = r.Client.Expire(ctx, key, duration).Err()
 	if err != nil {
 		return fmt.Errorf("error setting expiration time for switch %s: %w", link.Token, err)
 	}
 
+	member := &redis.Z{Score: float64(link.ExpiresAt.UnixMilli()), Member: key}
+	if err := r.Client.ZAdd(ctx, expiryKey, member).Err(); err != nil {
+		return fmt.Errorf("error indexing expiry of %s: %w", link.Token, err)
+	}
+
+	if link.Limited() {
+		err = r.Client.Set(ctx, clicksKey(key), link.MaxClicks, duration).Err()
+		if err != nil {
+			return fmt.Errorf("error storing click budget for %s: %w", link.Token, err)
+		}
+	}
+
+	return r.indexTags(ctx, key, link, nil)
+}
+
+// UpdateMetadata stores the title, description, tags, notes and
+// Open Graph overrides of link,
+// keeping the rest of the stored link and its expiry.
+func (r *LinkRedisStorage) UpdateMetadata(ctx context.Context, link *model.Link) error {
+	var previous []string
+
+	stored, err := r.rewrite(ctx, link.Domain, link.Token, func(stored *model.Link) {
+		previous = stored.Tags
+
+		stored.Title = link.Title
+		stored.Description = link.Description
+		stored.Tags = link.Tags
+		stored.Notes = link.Notes
+		stored.OpenGraph = link.OpenGraph
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.indexTags(ctx, linkKey(link.Domain, link.Token), stored, previous)
+}
+
+// DeleteLink deletes a link along with its click counters and
+// takes it out of the expiry and tag indexes.
+func (r *LinkRedisStorage) DeleteLink(ctx context.Context, domain, token string) error {
+	link, err := r.GetLink(ctx, domain, token)
+	if err != nil {
+		return err
+	}
+
+	key := linkKey(domain, token)
+
+	deleted, err := r.Client.Del(ctx, key, clicksKey(key), countKey(key), variantsKey(key)).Result()
+	if err != nil {
+		return err
+	}
+
+	// The link expired or was deleted by someone else in the meantime.
+	if deleted == 0 {
+		return apierror.ErrLinkNotFound
+	}
+
+	if err := r.Client.ZRem(ctx, expiryKey, key).Err(); err != nil {
+		return fmt.Errorf("error unindexing expiry of %s: %w", token, err)
+	}
+
+	return r.indexTags(ctx, key, &model.Link{Token: token}, link.Tags)
+}
+
+// StorePage stores what the destination page of a link advertises.
+func (r *LinkRedisStorage) StorePage(ctx context.Context, domain, token string, page model.Page) error {
+	_, err := r.rewrite(ctx, domain, token, func(stored *model.Link) {
+		stored.Page = page
+	})
+
+	return err
+}
+
+// rewrite applies change to the stored link, keeping its expiry.
+func (r *LinkRedisStorage) rewrite(ctx context.Context, domain, token string, change func(*model.Link)) (*model.Link, error) {
+	stored, err := r.GetLink(ctx, domain, token)
+	if err != nil {
+		return nil, err
+	}
+
+	change(stored)
+
+	value, err := easyjson.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Client.SetArgs(ctx, linkKey(domain, token), value, redis.SetArgs{Mode: "XX", KeepTTL: true}).Err()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, apierror.ErrLinkNotFound
+		}
+
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// ListLinksByTag returns up to limit links tagged with tag, newest first.
+func (r *LinkRedisStorage) ListLinksByTag(ctx context.Context, tag string, limit int) ([]*model.Link, error) {
+	index := tagKey(tag)
+
+	// Links are ranked by expiry, so the expired ones are at the bottom.
+	err := r.Client.ZRemRangeByScore(ctx, index, "-inf", strconv.FormatInt(time.Now().Unix(), 10)).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := r.Client.ZRevRange(ctx, index, 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*model.Link, 0, len(keys))
+
+	for _, key := range keys {
+		domain, token := splitLinkKey(key)
+
+		link, err := r.GetLink(ctx, domain, token)
+		if err != nil {
+			if errors.Is(err, apierror.ErrLinkNotFound) {
+				r.Client.ZRem(ctx, index, key)
+				continue
+			}
+
+			return nil, err
+		}
+
+		links = append(links, link)
+	}
+
+	return links, nil
+}
+
+// indexTags files the link stored under key in the index of each of its tags
+// and takes it out of the indexes of the previous tags it no longer has.
+func (r *LinkRedisStorage) indexTags(ctx context.Context, key string, link *model.Link, previous []string) error {
+	current := make(map[string]struct{}, len(link.Tags))
+
+	for _, tag := range link.Tags {
+		current[tag] = struct{}{}
+
+		member := &redis.Z{Score: float64(link.ExpiresAt.Unix()), Member: key}
+		if err := r.Client.ZAdd(ctx, tagKey(tag), member).Err(); err != nil {
+			return fmt.Errorf("error indexing tag %s of %s: %w", tag, link.Token, err)
+		}
+	}
+
+	for _, tag := range previous {
+		if _, ok := current[tag]; ok {
+			continue
+		}
+
+		if err := r.Client.ZRem(ctx, tagKey(tag), key).Err(); err != nil {
+			return fmt.Errorf("error unindexing tag %s of %s: %w", tag, link.Token, err)
+		}
+	}
+
 	return nil
 }
 
-func (r *LinkRedisStorage) StartRecalculation(interval time.Duration, deleted chan []string) {
-	return
+// ConsumeClick atomically spends one click of a limited link and returns the clicks left.
+func (r *LinkRedisStorage) ConsumeClick(ctx context.Context, domain, token string) (int64, error) {
+	left, err := consumeClickScript.Run(ctx, r.Client, []string{clicksKey(linkKey(domain, token))}).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	if left < 0 {
+		return 0, apierror.ErrLinkExhausted
+	}
+
+	return left, nil
+}
+
+// ClicksLeft returns the clicks a limited link has left without spending one.
+// Links whose budget is gone have none left.
+func (r *LinkRedisStorage) ClicksLeft(ctx context.Context, domain, token string) (int64, error) {
+	left, err := r.Client.Get(ctx, clicksKey(linkKey(domain, token))).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return left, nil
+}
+
+// CountClick counts a visit of a link and returns its visits so far.
+func (r *LinkRedisStorage) CountClick(ctx context.Context, domain, token string) (int64, error) {
+	key := linkKey(domain, token)
+
+	clicks, err := countClickScript.Run(ctx, r.Client, []string{countKey(key), key}).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	if clicks < 0 {
+		return 0, apierror.ErrLinkNotFound
+	}
+
+	return clicks, nil
+}
+
+// RecordVariant counts a visit served the variant at index variant of a split link.
+func (r *LinkRedisStorage) RecordVariant(ctx context.Context, domain, token string, variant int) error {
+	key := linkKey(domain, token)
+
+	return recordVariantScript.Run(ctx, r.Client, []string{variantsKey(key), key}, variant).Err()
+}
+
+// VariantHits returns the visits served each variant of a split link, by index.
+// Variants never served are left out.
+func (r *LinkRedisStorage) VariantHits(ctx context.Context, domain, token string) (map[int]int64, error) {
+	fields, err := r.Client.HGetAll(ctx, variantsKey(linkKey(domain, token))).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make(map[int]int64, len(fields))
+
+	for field, value := range fields {
+		variant, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		hits[variant] = n
+	}
+
+	return hits, nil
+}
+
+// linkKey keeps links of the default domain under their bare token,
+// the key they had before links were split by domain.
+func linkKey(domain, token string) string {
+	if domain == "" {
+		return token
+	}
+
+	return domain + "/" + token
+}
+
+// splitLinkKey is the inverse of linkKey.
+func splitLinkKey(key string) (domain, token string) {
+	if domain, token, ok := strings.Cut(key, "/"); ok {
+		return domain, token
+	}
+
+	return "", key
+}
+
+func tagKey(tag string) string {
+	return "tag:" + tag
+}
+
+func clicksKey(key string) string {
+	return key + ":clicks"
+}
+
+func countKey(key string) string {
+	return key + ":count"
+}
+
+func variantsKey(key string) string {
+	return key + ":variants"
+}
+
+// StartRecalculation sends the links expired since the last sweep,
+// identified by domain and token, to deleted every interval.
+// Redis drops the links themselves by their TTL.
+func (r *LinkRedisStorage) StartRecalculation(interval time.Duration, deleted chan []*model.Link) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		for {
+			<-ticker.C
+
+			// Links left in the index by a failed sweep are picked up by the next one.
+			del, _ := r.sweepExpired(context.Background(), time.Now())
+			if len(del) > 0 {
+				deleted <- del
+			}
+		}
+	}()
+}
+
+// sweepExpired takes the links expired by now out of the expiry index
+// and returns them. Each expired link is returned by one sweep only,
+// even when several instances share the database.
+func (r *LinkRedisStorage) sweepExpired(ctx context.Context, now time.Time) ([]*model.Link, error) {
+	var del []*model.Link
+
+	for {
+		keys, err := sweepExpiredScript.Run(ctx, r.Client, []string{expiryKey}, now.UnixMilli(), sweepBatch).StringSlice()
+		if err != nil {
+			return del, err
+		}
+
+		for _, key := range keys {
+			domain, token := splitLinkKey(key)
+			del = append(del, &model.Link{Domain: domain, Token: token})
+		}
+
+		if len(keys) < sweepBatch {
+			return del, nil
+		}
+	}
 }
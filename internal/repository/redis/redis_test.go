@@ -2,13 +2,17 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
 	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/go-redis/redis/v8"
 	"github.com/go-redis/redismock/v8"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +21,7 @@ import (
 const (
 	testURL   = "https://www.example.com"
 	testToken = "short"
+	testJSON  = `{"original_link":"https://www.example.com","canonical_link":"https://www.example.com/","token":"short","created_at":"0001-01-01T00:00:00Z","expires_at":"2100-01-10T00:00:00Z","active_from":"0001-01-01T00:00:00Z"}`
 )
 
 func TestStoreLink(t *testing.T) {
@@ -28,20 +33,20 @@ func TestStoreLink(t *testing.T) {
 	}
 
 	token := testToken
-	longURL := testURL
-	expirationTime := time.Now().Add(1 * time.Hour)
+	expirationTime := time.Date(2100, time.January, 10, 0, 0, 0, 0, time.UTC)
+	link := &model.Link{
+		OriginalLink:  testURL,
+		CanonicalLink: testURL + "/",
+		ShortLink:     "http://localhost:8080/url/" + token,
+		Token:         token,
+		ExpiresAt:     expirationTime,
+	}
 
-	mock.ExpectSet(token, longURL, 0).SetVal(token)
+	mock.ExpectSet(token, []byte(testJSON), 0).SetVal(token)
 	mock.ExpectExpire(token, time.Until(expirationTime)).SetVal(true)
+	mock.ExpectZAdd(expiryKey, &redis.Z{Score: float64(expirationTime.UnixMilli()), Member: token}).SetVal(1)
 
-	err := repo.StoreLink(
-		context.TODO(),
-		&model.Link{
-			OriginalLink: longURL,
-			Token:        token,
-			ExpiresAt:    expirationTime,
-		},
-	)
+	err := repo.StoreLink(context.TODO(), link)
 
 	assert.Nil(t, err, "Expected no error, got %v", err)
 	assert.NoError(t, mock.ExpectationsWereMet(), "Expectations were not met")
@@ -60,7 +65,7 @@ func TestSaveLink_SetError(t *testing.T) {
 	expirationTime := time.Now().Add(1 * time.Hour)
 
 	expectedError := fmt.Errorf("set error")
-	mock.ExpectSet(token, longURL, 0).SetErr(expectedError)
+	mock.Regexp().ExpectSet(token, `.*`, 0).SetErr(expectedError)
 
 	err := repo.StoreLink(
 		context.TODO(),
@@ -83,13 +88,37 @@ func TestGetLink_Success(t *testing.T) {
 	}
 
 	url := testToken
-	longURL := testURL
-	mock.ExpectGet(url).SetVal(longURL)
+	mock.ExpectGet(url).SetVal(testJSON)
+
+	result, err := repo.GetLink(context.TODO(), "", url)
+
+	expected := &model.Link{
+		OriginalLink:  testURL,
+		CanonicalLink: testURL + "/",
+		Token:         testToken,
+		ExpiresAt:     time.Date(2100, time.January, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	assert.Nil(t, err, "Expected no error, got %v", err)
+	assert.Equal(t, expected, result, "Expected link %v, got %v", expected, result)
+
+	assert.NoError(t, mock.ExpectationsWereMet(), "Expectations were not met")
+}
+
+func TestGetLink_Legacy(t *testing.T) {
+	t.Parallel()
+	mockClient, mock := redismock.NewClientMock()
+
+	repo := &LinkRedisStorage{
+		Client: mockClient,
+	}
 
-	result, err := repo.GetLink(context.TODO(), url)
+	mock.ExpectGet(testToken).SetVal(testURL)
+
+	result, err := repo.GetLink(context.TODO(), "", testToken)
 
 	assert.Nil(t, err, "Expected no error, got %v", err)
-	assert.Equal(t, longURL, result, "Expected long URL %s, got %s", longURL, result)
+	assert.Equal(t, &model.Link{OriginalLink: testURL, Token: testToken}, result)
 
 	assert.NoError(t, mock.ExpectationsWereMet(), "Expectations were not met")
 }
@@ -106,10 +135,10 @@ func TestGetLink_NotFound(t *testing.T) {
 	expectedError := redis.Nil
 	mock.ExpectGet(token).SetErr(expectedError)
 
-	result, err := repo.GetLink(context.TODO(), token)
+	result, err := repo.GetLink(context.TODO(), "", token)
 
 	assert.Error(t, err, "Expected an error")
-	assert.Equal(t, result, "", "Expected %s, got %s", result, "")
+	assert.Nil(t, result, "Expected no link, got %v", result)
 
 	assert.IsType(t, apierror.ErrLinkNotFound, err, "Expected error type to be NoSuchLink")
 	assert.Equal(t, apierror.ErrLinkNotFound.Error(), err.Error(), "Expected error message %q, got %q", fmt.Sprintf("No such url link: %v", expectedError), err.Error())
@@ -129,11 +158,352 @@ func TestGetUrl_Error(t *testing.T) {
 	expectedError := fmt.Errorf("something went wrong")
 	mock.ExpectGet(url).SetErr(expectedError)
 
-	result, err := repo.GetLink(context.TODO(), url)
+	result, err := repo.GetLink(context.TODO(), "", url)
 
 	assert.Error(t, err, "Expected an error")
-	assert.Equal(t, result, "", "Expected %s, got %s", result, "")
+	assert.Nil(t, result, "Expected no link, got %v", result)
 	assert.Equal(t, expectedError, err, "Expected %v, got %v", expectedError, err)
 
 	assert.NoError(t, mock.ExpectationsWereMet(), "Expectations were not met")
 }
+
+func TestConsumeClick_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewLinkStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+
+	const (
+		budget   = 5
+		visitors = 50
+	)
+
+	err := repo.StoreLink(context.TODO(), &model.Link{
+		OriginalLink: testURL,
+		Token:        testToken,
+		ExpiresAt:    time.Now().Add(time.Hour),
+		MaxClicks:    budget,
+	})
+	assert.NoError(t, err)
+
+	var (
+		wg        sync.WaitGroup
+		served    atomic.Int64
+		exhausted atomic.Int64
+	)
+
+	for i := 0; i < visitors; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := repo.ConsumeClick(context.TODO(), "", testToken)
+
+			switch {
+			case err == nil:
+				served.Add(1)
+			case errors.Is(err, apierror.ErrLinkExhausted):
+				exhausted.Add(1)
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int64(budget), served.Load(), "every click of the budget must be served exactly once")
+	assert.Equal(t, int64(visitors-budget), exhausted.Load())
+}
+
+func TestConsumeClick_Missing(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewLinkStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+
+	_, err := repo.ConsumeClick(context.TODO(), "", "missing")
+	assert.ErrorIs(t, err, apierror.ErrLinkExhausted)
+}
+
+func TestClicksLeft(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewLinkStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+
+	err := repo.StoreLink(context.TODO(), &model.Link{
+		OriginalLink: testURL,
+		Token:        testToken,
+		ExpiresAt:    time.Now().Add(time.Hour),
+		MaxClicks:    2,
+	})
+	assert.NoError(t, err)
+
+	left, err := repo.ClicksLeft(context.TODO(), "", testToken)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), left)
+
+	_, err = repo.ConsumeClick(context.TODO(), "", testToken)
+	assert.NoError(t, err)
+
+	left, err = repo.ClicksLeft(context.TODO(), "", testToken)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), left, "reading the budget must not spend a click")
+
+	left, err = repo.ClicksLeft(context.TODO(), "", "missing")
+	assert.NoError(t, err)
+	assert.Zero(t, left)
+}
+
+func TestCountClick(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewLinkStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+
+	err := repo.StoreLink(context.TODO(), &model.Link{
+		OriginalLink: testURL,
+		Token:        testToken,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	})
+	assert.NoError(t, err)
+
+	for want := int64(1); want <= 3; want++ {
+		clicks, err := repo.CountClick(context.TODO(), "", testToken)
+		assert.NoError(t, err)
+		assert.Equal(t, want, clicks)
+	}
+
+	assert.Greater(t, server.TTL(countKey(testToken)), time.Duration(0), "the counter must expire with the link")
+
+	_, err = repo.CountClick(context.TODO(), "", "missing")
+	assert.ErrorIs(t, err, apierror.ErrLinkNotFound)
+	assert.False(t, server.Exists(countKey("missing")))
+}
+
+func TestRecordVariant(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewLinkStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+
+	link := &model.Link{
+		OriginalLink: testURL,
+		Token:        testToken,
+		ExpiresAt:    time.Now().Add(time.Hour),
+		Variants: []model.Variant{
+			{Target: "https://www.example.com/a", Weight: 1},
+			{Target: "https://www.example.com/b", Weight: 1},
+		},
+	}
+	assert.NoError(t, repo.StoreLink(context.TODO(), link))
+
+	assert.NoError(t, repo.RecordVariant(context.TODO(), "", testToken, 1))
+	assert.NoError(t, repo.RecordVariant(context.TODO(), "", testToken, 1))
+	assert.NoError(t, repo.RecordVariant(context.TODO(), "", testToken, 0))
+
+	assert.Equal(t, "1", server.HGet(variantsKey(testToken), "0"))
+	assert.Equal(t, "2", server.HGet(variantsKey(testToken), "1"))
+	assert.Equal(t, server.TTL(testToken), server.TTL(variantsKey(testToken)))
+
+	hits, err := repo.VariantHits(context.TODO(), "", testToken)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int64{0: 1, 1: 2}, hits)
+
+	hits, err = repo.VariantHits(context.TODO(), "", "unvisited")
+	assert.NoError(t, err)
+	assert.Empty(t, hits)
+
+	stored, err := repo.GetLink(context.TODO(), "", testToken)
+	assert.NoError(t, err)
+	assert.Equal(t, link.Variants, stored.Variants)
+}
+
+func TestGetLink_Domain(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewLinkStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+
+	link := &model.Link{
+		OriginalLink: testURL,
+		Token:        testToken,
+		Domain:       "go.example.com",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+	assert.NoError(t, repo.StoreLink(context.TODO(), link))
+
+	stored, err := repo.GetLink(context.TODO(), "go.example.com", testToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "go.example.com", stored.Domain)
+	assert.Equal(t, testURL, stored.OriginalLink)
+
+	_, err = repo.GetLink(context.TODO(), "", testToken)
+	assert.ErrorIs(t, err, apierror.ErrLinkNotFound)
+}
+
+func TestUpdateMetadata_Tags(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewLinkStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+
+	link := &model.Link{
+		OriginalLink: testURL,
+		Token:        testToken,
+		Domain:       "go.example.com",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		Tags:         []string{"docs", "launch"},
+	}
+	assert.NoError(t, repo.StoreLink(context.TODO(), link))
+
+	other := &model.Link{OriginalLink: testURL, Token: "other", ExpiresAt: time.Now().Add(2 * time.Hour), Tags: []string{"docs"}}
+	assert.NoError(t, repo.StoreLink(context.TODO(), other))
+
+	links, err := repo.ListLinksByTag(context.TODO(), "docs", 10)
+	assert.NoError(t, err)
+	assert.Len(t, links, 2)
+	assert.Equal(t, "other", links[0].Token)
+	assert.Equal(t, "go.example.com", links[1].Domain)
+
+	ttl := server.TTL(linkKey(link.Domain, link.Token))
+
+	update := *link
+	update.Title = "Docs"
+	update.Tags = []string{"docs"}
+	assert.NoError(t, repo.UpdateMetadata(context.TODO(), &update))
+
+	stored, err := repo.GetLink(context.TODO(), link.Domain, link.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, "Docs", stored.Title)
+	assert.Equal(t, testURL, stored.OriginalLink)
+	assert.Equal(t, ttl, server.TTL(linkKey(link.Domain, link.Token)))
+
+	links, err = repo.ListLinksByTag(context.TODO(), "launch", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+
+	// Links deleted behind the index's back are dropped from it.
+	server.Del("other")
+
+	links, err = repo.ListLinksByTag(context.TODO(), "docs", 10)
+	assert.NoError(t, err)
+	assert.Len(t, links, 1)
+	assert.Equal(t, testToken, links[0].Token)
+
+	missing := &model.Link{Token: "missing"}
+	assert.ErrorIs(t, repo.UpdateMetadata(context.TODO(), missing), apierror.ErrLinkNotFound)
+}
+
+func TestStorePage(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewLinkStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+
+	link := &model.Link{OriginalLink: testURL, Token: testToken, ExpiresAt: time.Now().Add(time.Hour)}
+	assert.NoError(t, repo.StoreLink(context.TODO(), link))
+
+	page := model.Page{Title: "Example", Favicon: "https://www.example.com/favicon.ico"}
+	assert.NoError(t, repo.StorePage(context.TODO(), "", testToken, page))
+
+	stored, err := repo.GetLink(context.TODO(), "", testToken)
+	assert.NoError(t, err)
+	assert.Equal(t, page, stored.Page)
+	assert.Equal(t, testURL, stored.OriginalLink)
+	assert.True(t, server.TTL(testToken) > 0)
+
+	assert.ErrorIs(t, repo.StorePage(context.TODO(), "", "missing", page), apierror.ErrLinkNotFound)
+}
+
+func TestStartRecalculation(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewLinkStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+
+	expiring := &model.Link{OriginalLink: testURL, Token: testToken, Domain: "go.example.com", ExpiresAt: time.Now().Add(50 * time.Millisecond)}
+	assert.NoError(t, repo.StoreLink(context.TODO(), expiring))
+
+	lasting := &model.Link{OriginalLink: testURL, Token: "other", ExpiresAt: time.Now().Add(time.Hour)}
+	assert.NoError(t, repo.StoreLink(context.TODO(), lasting))
+
+	deleted := make(chan []*model.Link, 1)
+	repo.StartRecalculation(10*time.Millisecond, deleted)
+
+	select {
+	case links := <-deleted:
+		assert.Equal(t, []*model.Link{{Domain: "go.example.com", Token: testToken}}, links)
+	case <-time.After(2 * time.Second):
+		t.Fatal("expired link was not swept")
+	}
+
+	// Every expired link is reported once.
+	select {
+	case links := <-deleted:
+		t.Fatalf("unexpected sweep: %v", links)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSweepExpired_Batches(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewLinkStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	for i := 0; i < sweepBatch+1; i++ {
+		_, err := server.ZAdd(expiryKey, float64(expiresAt.UnixMilli()), fmt.Sprintf("token%d", i))
+		assert.NoError(t, err)
+	}
+
+	links, err := repo.sweepExpired(context.TODO(), time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+
+	links, err = repo.sweepExpired(context.TODO(), expiresAt)
+	assert.NoError(t, err)
+	assert.Len(t, links, sweepBatch+1)
+
+	members, err := server.ZMembers(expiryKey)
+	assert.ErrorIs(t, err, miniredis.ErrKeyNotFound)
+	assert.Empty(t, members)
+}
+
+func TestDeleteLink(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewLinkStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+
+	link := &model.Link{
+		OriginalLink: testURL,
+		Token:        testToken,
+		Domain:       "go.example.com",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		MaxClicks:    5,
+		Tags:         []string{"docs"},
+	}
+	assert.NoError(t, repo.StoreLink(context.TODO(), link))
+
+	_, err := repo.CountClick(context.TODO(), link.Domain, link.Token)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.RecordVariant(context.TODO(), link.Domain, link.Token, 0))
+
+	assert.NoError(t, repo.DeleteLink(context.TODO(), link.Domain, link.Token))
+
+	_, err = repo.GetLink(context.TODO(), link.Domain, link.Token)
+	assert.ErrorIs(t, err, apierror.ErrLinkNotFound)
+	assert.Empty(t, server.Keys(), "the link, its counters and indexes must all be gone")
+
+	// Deleted links are not reported as expired later on.
+	links, err := repo.sweepExpired(context.TODO(), link.ExpiresAt)
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+
+	assert.ErrorIs(t, repo.DeleteLink(context.TODO(), link.Domain, link.Token), apierror.ErrLinkNotFound)
+}
@@ -0,0 +1,275 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/mailru/easyjson"
+)
+
+// webhooksKey is the set of the IDs of all webhooks.
+const webhooksKey = "webhook:ids"
+
+// queueKey ranks the pending deliveries by their next attempt.
+const queueKey = "webhook:queue"
+
+// claimDeliveriesScript moves the deliveries due at ARGV[1] to ARGV[2] in the
+// queue and returns their IDs, at most ARGV[3] of them.
+var claimDeliveriesScript = redis.NewScript(`
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[3])
+for _, id in ipairs(ids) do
+	redis.call('ZADD', KEYS[1], ARGV[2], id)
+end
+return ids
+`)
+
+// WebhookStorage keeps webhooks and the queue of their deliveries.
+type WebhookStorage struct {
+	Client *redis.Client
+}
+
+func NewWebhookStorage(cli *redis.Client) *WebhookStorage {
+	return &WebhookStorage{cli}
+}
+
+// StoreWebhook stores a new webhook.
+func (r *WebhookStorage) StoreWebhook(ctx context.Context, hook *model.Webhook) error {
+	value, err := easyjson.Marshal(hook)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, webhookKey(hook.ID), value, 0)
+		pipe.SAdd(ctx, webhooksKey, hook.ID)
+
+		return nil
+	})
+
+	return err
+}
+
+// ListWebhooks returns every webhook, oldest first.
+func (r *WebhookStorage) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	ids, err := r.Client.SMembers(ctx, webhooksKey).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = webhookKey(id)
+	}
+
+	values, err := r.Client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	hooks := make([]*model.Webhook, 0, len(values))
+
+	for i, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		hook := &model.Webhook{}
+		if err := easyjson.Unmarshal([]byte(s), hook); err != nil {
+			return nil, fmt.Errorf("error decoding webhook %s: %w", ids[i], err)
+		}
+
+		hooks = append(hooks, hook)
+	}
+
+	// Sets come in no particular order.
+	sort.Slice(hooks, func(i, j int) bool {
+		return hooks[i].CreatedAt.Before(hooks[j].CreatedAt)
+	})
+
+	return hooks, nil
+}
+
+// DeleteWebhook deletes a webhook along with its dead letters. Its pending
+// deliveries are dropped by the worker claiming them.
+func (r *WebhookStorage) DeleteWebhook(ctx context.Context, id string) error {
+	deleted, err := r.Client.Del(ctx, webhookKey(id)).Result()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return apierror.ErrWebhookNotFound
+	}
+
+	dead, err := r.Client.ZRange(ctx, deadKey(id), 0, -1).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{deadKey(id)}
+	for _, deliveryID := range dead {
+		keys = append(keys, deliveryKey(deliveryID))
+	}
+
+	_, err = r.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SRem(ctx, webhooksKey, id)
+		pipe.Del(ctx, keys...)
+
+		return nil
+	})
+
+	return err
+}
+
+// EnqueueDelivery queues a delivery to be sent at its next attempt.
+func (r *WebhookStorage) EnqueueDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	value, err := easyjson.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, deliveryKey(delivery.ID), value, 0)
+		pipe.ZAdd(ctx, queueKey, &redis.Z{Score: score(delivery.NextAttempt), Member: delivery.ID})
+
+		return nil
+	})
+
+	return err
+}
+
+// ClaimDeliveries returns up to limit deliveries due at now and holds them
+// back until until, so other workers skip them while they are sent. Claimed
+// deliveries neither deleted nor updated by then are claimed again.
+func (r *WebhookStorage) ClaimDeliveries(ctx context.Context, now, until time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	ids, err := claimDeliveriesScript.Run(ctx, r.Client, []string{queueKey},
+		strconv.FormatFloat(score(now), 'f', -1, 64),
+		strconv.FormatFloat(score(until), 'f', -1, 64),
+		limit,
+	).StringSlice()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	deliveries := make([]*model.WebhookDelivery, 0, len(ids))
+
+	for _, id := range ids {
+		value, err := r.Client.Get(ctx, deliveryKey(id)).Bytes()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				r.Client.ZRem(ctx, queueKey, id)
+				continue
+			}
+
+			return nil, err
+		}
+
+		delivery := &model.WebhookDelivery{}
+		if err := easyjson.Unmarshal(value, delivery); err != nil {
+			return nil, fmt.Errorf("error decoding delivery %s: %w", id, err)
+		}
+
+		delivery.NextAttempt = until
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// DeleteDelivery takes a delivery off the queue.
+func (r *WebhookStorage) DeleteDelivery(ctx context.Context, id string) error {
+	_, err := r.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, queueKey, id)
+		pipe.Del(ctx, deliveryKey(id))
+
+		return nil
+	})
+
+	return err
+}
+
+// UpdateDelivery stores the attempts of a delivery and when to try it
+// next, or moves it to the dead letters once it is dead.
+func (r *WebhookStorage) UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	value, err := easyjson.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, deliveryKey(delivery.ID), value, 0)
+
+		if delivery.Dead {
+			pipe.ZRem(ctx, queueKey, delivery.ID)
+			pipe.ZAdd(ctx, deadKey(delivery.WebhookID), &redis.Z{Score: score(delivery.CreatedAt), Member: delivery.ID})
+		} else {
+			pipe.ZAdd(ctx, queueKey, &redis.Z{Score: score(delivery.NextAttempt), Member: delivery.ID})
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// ListDeadDeliveries returns up to limit deliveries of a webhook given up on, newest first.
+func (r *WebhookStorage) ListDeadDeliveries(ctx context.Context, webhookID string, limit int) ([]*model.WebhookDelivery, error) {
+	ids, err := r.Client.ZRevRange(ctx, deadKey(webhookID), 0, int64(limit)-1).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = deliveryKey(id)
+	}
+
+	values, err := r.Client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*model.WebhookDelivery, 0, len(values))
+
+	for i, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		delivery := &model.WebhookDelivery{}
+		if err := easyjson.Unmarshal([]byte(s), delivery); err != nil {
+			return nil, fmt.Errorf("error decoding delivery %s: %w", ids[i], err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// score ranks deliveries by time in milliseconds.
+func score(t time.Time) float64 {
+	return float64(t.UnixMilli())
+}
+
+func webhookKey(id string) string {
+	return "webhook:" + id
+}
+
+func deliveryKey(id string) string {
+	return "webhook:delivery:" + id
+}
+
+func deadKey(webhookID string) string {
+	return "webhook:" + webhookID + ":dead"
+}
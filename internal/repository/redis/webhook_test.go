@@ -0,0 +1,96 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookStorage_Webhooks(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewWebhookStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+	ctx := context.TODO()
+
+	created := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	first := &model.Webhook{ID: "a", URL: "https://example.com/a", Secret: "s", Events: []model.WebhookEvent{model.WebhookLinkCreated}, CreatedAt: created}
+	second := &model.Webhook{ID: "b", URL: "https://example.com/b", Secret: "s", ClickThresholds: []int64{10}, CreatedAt: created.Add(time.Hour)}
+
+	require.NoError(t, repo.StoreWebhook(ctx, second))
+	require.NoError(t, repo.StoreWebhook(ctx, first))
+
+	hooks, err := repo.ListWebhooks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Webhook{first, second}, hooks)
+
+	require.NoError(t, repo.DeleteWebhook(ctx, "a"))
+	assert.ErrorIs(t, repo.DeleteWebhook(ctx, "a"), apierror.ErrWebhookNotFound)
+
+	hooks, err = repo.ListWebhooks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Webhook{second}, hooks)
+}
+
+func TestWebhookStorage_Deliveries(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	repo := NewWebhookStorage(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+	ctx := context.TODO()
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	due := &model.WebhookDelivery{ID: "due", WebhookID: "hook", Event: model.WebhookLinkCreated, Payload: []byte(`{"id":"due"}`), NextAttempt: now, CreatedAt: now}
+	later := &model.WebhookDelivery{ID: "later", WebhookID: "hook", Event: model.WebhookLinkCreated, Payload: []byte(`{"id":"later"}`), NextAttempt: now.Add(time.Hour), CreatedAt: now}
+
+	require.NoError(t, repo.EnqueueDelivery(ctx, due))
+	require.NoError(t, repo.EnqueueDelivery(ctx, later))
+
+	until := now.Add(time.Minute)
+
+	claimed, err := repo.ClaimDeliveries(ctx, now, until, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, "due", claimed[0].ID)
+	assert.JSONEq(t, `{"id":"due"}`, string(claimed[0].Payload))
+	assert.True(t, until.Equal(claimed[0].NextAttempt))
+
+	claimed, err = repo.ClaimDeliveries(ctx, now, until, 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimed, "claimed deliveries are held back")
+
+	claimed, err = repo.ClaimDeliveries(ctx, until, until.Add(time.Minute), 10)
+	require.NoError(t, err)
+	assert.Len(t, claimed, 1, "deliveries are claimed again once held back long enough")
+
+	due.Attempts = 5
+	due.LastError = "503 Service Unavailable"
+	due.Dead = true
+	require.NoError(t, repo.UpdateDelivery(ctx, due))
+
+	claimed, err = repo.ClaimDeliveries(ctx, now.Add(2*time.Hour), now.Add(3*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, "later", claimed[0].ID, "dead deliveries are not claimed")
+
+	require.NoError(t, repo.DeleteDelivery(ctx, "later"))
+	assert.False(t, server.Exists(deliveryKey("later")))
+
+	dead, err := repo.ListDeadDeliveries(ctx, "hook", 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 5, dead[0].Attempts)
+	assert.Equal(t, "503 Service Unavailable", dead[0].LastError)
+
+	require.NoError(t, repo.StoreWebhook(ctx, &model.Webhook{ID: "hook"}))
+	require.NoError(t, repo.DeleteWebhook(ctx, "hook"))
+	assert.False(t, server.Exists(deliveryKey("due")), "dead letters go with their webhook")
+}
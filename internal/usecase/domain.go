@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+)
+
+// brandedDomains maps the host of every branded base URL to the prefix of its short links.
+func brandedDomains(baseURLs []string) map[string]string {
+	domains := make(map[string]string, len(baseURLs))
+
+	for _, baseURL := range baseURLs {
+		u, err := url.Parse(baseURL)
+		if err != nil || u.Host == "" {
+			continue
+		}
+
+		domains[strings.ToLower(u.Host)] = strings.TrimSuffix(baseURL, "/") + "/"
+	}
+
+	return domains
+}
+
+// domain maps the host a request came in on to the domain its links are stored under.
+// Hosts that are not branded domains resolve links of the default domain.
+func (service *LinkService) domain(host string) string {
+	host = strings.ToLower(host)
+	if _, ok := service.domains[host]; ok {
+		return host
+	}
+
+	return ""
+}
+
+// creationDomain checks the domain a link is requested on. Empty means the default domain.
+func (service *LinkService) creationDomain(domain string) (string, error) {
+	if domain == "" {
+		return "", nil
+	}
+
+	domain = strings.ToLower(domain)
+	if _, ok := service.domains[domain]; !ok {
+		return "", apierror.InvalidFieldError("domain", fmt.Errorf("unknown domain %q", domain))
+	}
+
+	return domain, nil
+}
+
+func (service *LinkService) prefix(domain string) string {
+	if prefix, ok := service.domains[domain]; ok {
+		return prefix
+	}
+
+	return service.shortlinkPrefix
+}
+
+func (service *LinkService) shortLink(link *model.Link) string {
+	return service.prefix(link.Domain) + link.Token
+}
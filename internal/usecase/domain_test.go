@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	mock_usecase "github.com/CodeMaster482/ShortLinkAPI/internal/usecase/mocks"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkService_GetFullLink_Domain(t *testing.T) {
+	t.Parallel()
+
+	branded := &model.Link{OriginalLink: "https://example.com/branded", Token: "token_____", Domain: "go.example.com"}
+	standard := &model.Link{OriginalLink: "https://example.com/default", Token: "token_____"}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "go.example.com", "token_____").Return(branded, nil)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "token_____").Return(standard, nil).Times(2)
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		shortlinkPrefix: prefix,
+		domains:         brandedDomains([]string{"https://Go.Example.com/"}),
+	}
+
+	redirect, err := usecase.GetFullLink(context.TODO(), "GO.example.com", "token_____", nil)
+	require.NoError(t, err)
+	require.Equal(t, branded.OriginalLink, redirect.Target)
+
+	// The service's own host and unknown hosts resolve links of the default domain.
+	for _, host := range []string{"localhost:8080", "other.example.com"} {
+		redirect, err = usecase.GetFullLink(context.TODO(), host, "token_____", nil)
+		require.NoError(t, err)
+		require.Equal(t, standard.OriginalLink, redirect.Target)
+	}
+}
+
+func TestLinkService_CreateShortLink_Domain(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockGen := mock_usecase.NewMockGenerator(ctrl)
+
+	var stored *model.Link
+	mockGen.EXPECT().GenerateShortURL(gomock.Any()).Return("token_____")
+	mockRepo.EXPECT().GetLink(gomock.Any(), "go.example.com", "token_____").Return(nil, apierror.ErrLinkNotFound)
+	mockRepo.EXPECT().StoreLink(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, link *model.Link) error {
+		stored = link
+		return nil
+	})
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		generator:       mockGen,
+		shortlinkPrefix: prefix,
+		domains:         brandedDomains([]string{"https://go.example.com"}),
+	}
+
+	link, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:   "https://example.com/landing",
+		Domain: "go.example.com",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "https://go.example.com/token_____", link.ShortLink)
+	require.Equal(t, "go.example.com", stored.Domain)
+
+	_, err = usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:   "https://example.com/landing",
+		Domain: "evil.example.com",
+	})
+	require.ErrorIs(t, err, apierror.ErrBadRequest)
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/eventbus"
+)
+
+var errNoEventBus = errors.New("no event bus configured")
+
+// EventBus hands link events to watchers, keeping the recent ones for
+// watchers resuming from a cursor.
+type EventBus interface {
+	Publish(event *model.LinkEvent) string
+	Watch(ctx context.Context, cursor string) (<-chan eventbus.Event[*model.LinkEvent], error)
+}
+
+// WatchLinks returns the link events published after cursor. An empty cursor
+// starts with the next event. The channel is closed when ctx is done or the
+// watcher falls behind the events kept; it may resume from the cursor of the
+// last event received. Events are shared between watchers and must not be
+// modified.
+func (service *LinkService) WatchLinks(ctx context.Context, cursor string) (<-chan *model.LinkEvent, error) {
+	if service.events == nil {
+		return nil, apierror.InternalError(errNoEventBus)
+	}
+
+	published, err := service.events.Watch(ctx, cursor)
+	switch {
+	case errors.Is(err, eventbus.ErrInvalidCursor):
+		return nil, apierror.InvalidFieldError("cursor", err)
+	case errors.Is(err, eventbus.ErrCursorExpired):
+		return nil, apierror.NewAPIError(apierror.ErrCursorExpired, err)
+	case err != nil:
+		return nil, apierror.InternalError(err)
+	}
+
+	events := make(chan *model.LinkEvent)
+
+	go func() {
+		defer close(events)
+
+		for e := range published {
+			event := *e.Value
+			event.Cursor = e.Cursor
+
+			select {
+			case events <- &event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// publish tells watchers and webhooks that link changed.
+func (service *LinkService) publish(eventType model.LinkEventType, link *model.Link) {
+	service.notify(webhookEvents[eventType], link, 0)
+
+	if service.events == nil {
+		return
+	}
+
+	event := &model.LinkEvent{
+		Type:   eventType,
+		Domain: link.Domain,
+		Token:  link.Token,
+		Time:   time.Now(),
+	}
+
+	if eventType != model.LinkExpired && eventType != model.LinkDeleted {
+		listed := *link
+		event.Link = service.listed(&listed)
+	}
+
+	service.events.Publish(event)
+}
+
+// expire publishes the links the repository sweeps once they expired.
+func (service *LinkService) expire(deleted <-chan []*model.Link) {
+	for links := range deleted {
+		for _, link := range links {
+			service.publish(model.LinkExpired, link)
+		}
+	}
+}
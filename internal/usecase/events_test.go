@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	mock_usecase "github.com/CodeMaster482/ShortLinkAPI/internal/usecase/mocks"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/eventbus"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkService_WatchLinks(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockGen := mock_usecase.NewMockGenerator(ctrl)
+	bus := eventbus.New[*model.LinkEvent]()
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		generator:       mockGen,
+		shortlinkPrefix: prefix,
+		events:          bus,
+	}
+
+	mockGen.EXPECT().GenerateShortURL(gomock.Any()).Return("created___")
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "created___").Return(nil, apierror.ErrLinkNotFound)
+	mockRepo.EXPECT().StoreLink(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "protected_").
+		Return(&model.Link{Token: "protected_", OriginalLink: "https://example.com/secret", PasswordHash: "hash"}, nil)
+	mockRepo.EXPECT().UpdateMetadata(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "deleted___").
+		Return(&model.Link{Token: "deleted___", OriginalLink: "https://example.com/old"}, nil)
+	mockRepo.EXPECT().DeleteLink(gomock.Any(), "", "deleted___").Return(nil)
+
+	start := bus.Publish(&model.LinkEvent{})
+
+	created, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{Link: "https://example.com/docs"})
+	require.NoError(t, err)
+
+	title := "Secret"
+	_, err = usecase.UpdateLink(context.TODO(), "", "protected_", &dto.UpdateLinkRequest{Title: &title})
+	require.NoError(t, err)
+
+	require.NoError(t, usecase.DeleteLink(context.TODO(), "", "deleted___"))
+
+	deleted := make(chan []*model.Link)
+	go usecase.expire(deleted)
+	deleted <- []*model.Link{{Domain: "go.example.com", Token: "expired___"}}
+	close(deleted)
+
+	var events []*model.LinkEvent
+
+	watch := func(cursor string, n int) error {
+		events = nil
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		watched, err := usecase.WatchLinks(ctx, cursor)
+		if err != nil {
+			return err
+		}
+
+		for event := range watched {
+			events = append(events, event)
+			if len(events) == n {
+				return nil
+			}
+		}
+
+		return ctx.Err()
+	}
+
+	require.NoError(t, watch(start, 4))
+
+	require.Equal(t, model.LinkCreated, events[0].Type)
+	require.Equal(t, "created___", events[0].Token)
+	require.Equal(t, "https://example.com/docs", events[0].Link.OriginalLink)
+	require.Equal(t, created.ShortLink, events[0].Link.ShortLink)
+
+	require.Equal(t, model.LinkUpdated, events[1].Type)
+	require.Equal(t, "Secret", events[1].Link.Title)
+	require.Empty(t, events[1].Link.OriginalLink)
+
+	require.Equal(t, model.LinkDeleted, events[2].Type)
+	require.Equal(t, "deleted___", events[2].Token)
+	require.Nil(t, events[2].Link)
+
+	require.Equal(t, model.LinkExpired, events[3].Type)
+	require.Equal(t, "go.example.com", events[3].Domain)
+	require.Nil(t, events[3].Link)
+
+	// Resuming picks up after the cursor of the event seen last.
+	require.NoError(t, watch(events[0].Cursor, 3))
+	require.Equal(t, model.LinkUpdated, events[0].Type)
+
+	require.ErrorIs(t, watch("garbage", 1), apierror.ErrBadRequest)
+	require.ErrorIs(t, watch("0-1", 1), apierror.ErrCursorExpired)
+
+	_, err = (&LinkService{}).WatchLinks(context.TODO(), "")
+	require.ErrorIs(t, err, apierror.ErrInternalServer)
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+)
+
+// forward carries the path and query string of visit over to dest as configured for link.
+// The result always stays on the scheme and host of dest.
+func forward(dest string, link *model.Link, visit *model.Visit) string {
+	if visit == nil || !link.Forwards() {
+		return dest
+	}
+
+	u, err := url.Parse(dest)
+	if err != nil || u.Opaque != "" {
+		return dest
+	}
+
+	if link.ForwardPath {
+		if p := cleanPath(visit.Path); p != "" {
+			u.Path = strings.TrimSuffix(u.Path, "/") + p
+			u.RawPath = ""
+		}
+	}
+
+	if len(visit.Query) > 0 {
+		switch link.ForwardQuery {
+		case model.QueryAppend:
+			query := u.Query()
+			for key, values := range visit.Query {
+				for _, value := range values {
+					query.Add(key, value)
+				}
+			}
+
+			u.RawQuery = query.Encode()
+		case model.QueryOverride:
+			query := u.Query()
+			for key, values := range visit.Query {
+				query[key] = values
+			}
+
+			u.RawQuery = query.Encode()
+		}
+	}
+
+	return u.String()
+}
+
+// cleanPath resolves dot segments and repeated slashes so the forwarded path
+// can neither climb above the destination path nor read as a host.
+func cleanPath(p string) string {
+	if p == "" || p == "/" {
+		return ""
+	}
+
+	cleaned := path.Clean("/" + p)
+	if cleaned == "/" {
+		return ""
+	}
+
+	if strings.HasSuffix(p, "/") {
+		cleaned += "/"
+	}
+
+	return cleaned
+}
+
+func validQueryMode(mode model.QueryMode) error {
+	switch mode {
+	case "", model.QueryDrop, model.QueryAppend, model.QueryOverride:
+		return nil
+	default:
+		return apierror.InvalidFieldError("forward_query", fmt.Errorf("unknown query mode %q", mode))
+	}
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForward(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		dest     string
+		link     model.Link
+		visit    *model.Visit
+		expected string
+	}{
+		{
+			name:     "Forwarding off",
+			dest:     "https://example.com/base",
+			visit:    &model.Visit{Path: "/docs", Query: url.Values{"ref": {"x"}}},
+			expected: "https://example.com/base",
+		},
+		{
+			name:     "Path",
+			dest:     "https://example.com/base/",
+			link:     model.Link{ForwardPath: true},
+			visit:    &model.Visit{Path: "/docs/page", Query: url.Values{"ref": {"x"}}},
+			expected: "https://example.com/base/docs/page",
+		},
+		{
+			name:     "Path keeps trailing slash",
+			dest:     "https://example.com",
+			link:     model.Link{ForwardPath: true},
+			visit:    &model.Visit{Path: "/docs/"},
+			expected: "https://example.com/docs/",
+		},
+		{
+			name:     "Dot segments stay below the destination",
+			dest:     "https://example.com/base",
+			link:     model.Link{ForwardPath: true},
+			visit:    &model.Visit{Path: "/../../admin"},
+			expected: "https://example.com/base/admin",
+		},
+		{
+			name:     "Slashes cannot switch host",
+			dest:     "https://example.com",
+			link:     model.Link{ForwardPath: true},
+			visit:    &model.Visit{Path: "//evil.com/x"},
+			expected: "https://example.com/evil.com/x",
+		},
+		{
+			name:     "Encoded characters stay escaped",
+			dest:     "https://example.com/base",
+			link:     model.Link{ForwardPath: true},
+			visit:    &model.Visit{Path: "/a b/c?d"},
+			expected: "https://example.com/base/a%20b/c%3Fd",
+		},
+		{
+			name:     "Append query",
+			dest:     "https://example.com/?ref=link&a=1",
+			link:     model.Link{ForwardQuery: model.QueryAppend},
+			visit:    &model.Visit{Query: url.Values{"ref": {"x"}, "b": {"2"}}},
+			expected: "https://example.com/?a=1&b=2&ref=link&ref=x",
+		},
+		{
+			name:     "Override query",
+			dest:     "https://example.com/?ref=link&a=1",
+			link:     model.Link{ForwardQuery: model.QueryOverride},
+			visit:    &model.Visit{Query: url.Values{"ref": {"x"}}},
+			expected: "https://example.com/?a=1&ref=x",
+		},
+		{
+			name:     "Drop query",
+			dest:     "https://example.com/?ref=link",
+			link:     model.Link{ForwardPath: true, ForwardQuery: model.QueryDrop},
+			visit:    &model.Visit{Path: "/docs", Query: url.Values{"ref": {"x"}}},
+			expected: "https://example.com/docs?ref=link",
+		},
+		{
+			name:     "No visit",
+			dest:     "https://example.com/",
+			link:     model.Link{ForwardPath: true, ForwardQuery: model.QueryAppend},
+			expected: "https://example.com/",
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.expected, forward(test.dest, &test.link, test.visit))
+		})
+	}
+}
+
+func TestLinkService_CreateShortLink_QueryMode(t *testing.T) {
+	t.Parallel()
+
+	usecase := LinkService{}
+
+	_, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:         "https://example.com/docs",
+		ForwardQuery: "merge",
+	})
+	require.ErrorIs(t, err, apierror.ErrBadRequest)
+}
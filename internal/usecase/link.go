@@ -2,80 +2,579 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/CodeMaster482/ShortLinkAPI/config"
 	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
 	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
 	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/unfurl"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	minLinkTTL     = time.Minute
+	maxLinkTTL     = 365 * 24 * time.Hour
+	minAliasLength = 3
+	maxAliasLength = 64
 )
 
 type LinkRepository interface {
-	GetLink(ctx context.Context, token string) (*model.Link, error)
+	GetLink(ctx context.Context, domain, token string) (*model.Link, error)
 	StoreLink(ctx context.Context, link *model.Link) error
-	StartRecalculation(interval time.Duration, deleted chan []string)
+	ConsumeClick(ctx context.Context, domain, token string) (int64, error)
+	ClicksLeft(ctx context.Context, domain, token string) (int64, error)
+	CountClick(ctx context.Context, domain, token string) (int64, error)
+	RecordVariant(ctx context.Context, domain, token string, variant int) error
+	VariantHits(ctx context.Context, domain, token string) (map[int]int64, error)
+	UpdateMetadata(ctx context.Context, link *model.Link) error
+	DeleteLink(ctx context.Context, domain, token string) error
+	StorePage(ctx context.Context, domain, token string, page model.Page) error
+	ListLinksByTag(ctx context.Context, tag string, limit int) ([]*model.Link, error)
+	StartRecalculation(interval time.Duration, deleted chan []*model.Link)
 }
 
 type Generator interface {
 	GenerateShortURL(url string) string
 }
 
+type URLChecker interface {
+	Check(ctx context.Context, u *url.URL) error
+}
+
+type Canonicalizer interface {
+	Canonicalize(u *url.URL) (*url.URL, error)
+}
+
+type GeoLocator interface {
+	Country(ip net.IP) (string, error)
+}
+
+type AttemptThrottle interface {
+	Blocked(key string) bool
+	Fail(key string)
+	Reset(key string)
+}
+
+type QRCache interface {
+	Get(key string) ([]byte, bool)
+	Add(key string, image []byte)
+}
+
+type PageFetcher interface {
+	Fetch(ctx context.Context, rawURL string) (*unfurl.Page, error)
+}
+
+// PageQueue takes new links whose destination page should be fetched.
+type PageQueue interface {
+	Enqueue(link *model.Link) bool
+}
+
+// WebhookRepository keeps webhooks and the queue of their deliveries.
+type WebhookRepository interface {
+	StoreWebhook(ctx context.Context, hook *model.Webhook) error
+	ListWebhooks(ctx context.Context) ([]*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	EnqueueDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+	ClaimDeliveries(ctx context.Context, now, until time.Time, limit int) ([]*model.WebhookDelivery, error)
+	DeleteDelivery(ctx context.Context, id string) error
+	UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+	ListDeadDeliveries(ctx context.Context, webhookID string, limit int) ([]*model.WebhookDelivery, error)
+}
+
+// WebhookNotifier queues requests to the webhooks subscribed to link events.
+type WebhookNotifier interface {
+	Notify(payload *model.WebhookPayload)
+	// CountsClicks reports whether any webhook waits for a number of clicks.
+	CountsClicks() bool
+}
+
 type LinkService struct {
 	repository      LinkRepository
 	generator       Generator
+	checker         URLChecker
+	canonicalizer   Canonicalizer
+	attempts        AttemptThrottle
+	geo             GeoLocator
+	qrCodes         QRCache
+	utmPresets      map[string]model.UTM
+	pages           PageQueue
+	events          EventBus
+	webhooks        WebhookNotifier
 	shortlinkPrefix string
-	expiration      time.Time
+	domains         map[string]string
+	alphabet        string
+	tokenLength     int
+	ttl             time.Duration
+	now             func() time.Time
 }
 
-func (service *LinkService) GetFullLink(ctx context.Context, token string) (string, error) {
-	link, err := service.repository.GetLink(ctx, token)
+// GetFullLink resolves token to the redirect for visit. Links that are not active yet
+// fail with apierror.ErrLinkNotActive and redirect to their fallback link, if any.
+// The domain is the host the link was requested on.
+func (service *LinkService) GetFullLink(ctx context.Context, domain, token string, visit *model.Visit) (*model.Redirect, error) {
+	link, err := service.lookup(ctx, service.domain(domain), token)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if !link.Active(service.clock()) {
+		return notActive(link)
 	}
 
-	return link.OriginalLink, nil
+	if link.Protected() {
+		return nil, apierror.NewAPIError(apierror.ErrPasswordRequired, nil)
+	}
+
+	return service.redirect(ctx, link, visit)
+}
+
+// UnlockLink resolves a password-protected link. Failed attempts are throttled per token.
+func (service *LinkService) UnlockLink(ctx context.Context, domain, token, password string, visit *model.Visit) (*model.Redirect, error) {
+	domain = service.domain(domain)
+	key := domain + "/" + token
+
+	if service.attempts != nil && service.attempts.Blocked(key) {
+		return nil, apierror.NewAPIError(apierror.ErrTooManyAttempts, nil)
+	}
+
+	link, err := service.lookup(ctx, domain, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !link.Active(service.clock()) {
+		return notActive(link)
+	}
+
+	if !link.Protected() {
+		return service.redirect(ctx, link, visit)
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(password))
+	if err != nil {
+		if service.attempts != nil {
+			service.attempts.Fail(key)
+		}
+
+		return nil, apierror.NewAPIError(apierror.ErrPasswordInvalid, nil)
+	}
+
+	if service.attempts != nil {
+		service.attempts.Reset(key)
+	}
+
+	return service.redirect(ctx, link, visit)
+}
+
+// redirect spends a click of a limited link before handing out its destination.
+func (service *LinkService) redirect(ctx context.Context, link *model.Link, visit *model.Visit) (*model.Redirect, error) {
+	if link.Limited() {
+		if _, err := service.repository.ConsumeClick(ctx, link.Domain, link.Token); err != nil {
+			if errors.Is(err, apierror.ErrLinkExhausted) {
+				return nil, apierror.NewAPIError(apierror.ErrLinkExhausted, nil)
+			}
+
+			return nil, err
+		}
+	}
+
+	if service.webhooks != nil && service.webhooks.CountsClicks() {
+		service.countClick(ctx, link)
+	}
+
+	code := link.RedirectCode
+	if code == 0 {
+		code = http.StatusFound
+	}
+
+	return &model.Redirect{
+		Target:       withUTM(forward(service.target(ctx, link, visit), link, visit), link.UTM),
+		Code:         code,
+		Interstitial: link.Interstitial,
+		Delay:        link.InterstitialDelay,
+		ExpiresAt:    link.ExpiresAt,
+		Cacheable:    link.Static(),
+		Sticky:       link.Split(),
+	}, nil
+}
+
+// notActive sends visits of a link that is not active yet to its fallback, if any.
+func notActive(link *model.Link) (*model.Redirect, error) {
+	err := apierror.NewAPIError(apierror.ErrLinkNotActive, nil)
+	if link.FallbackLink == "" {
+		return nil, err
+	}
+
+	return &model.Redirect{Target: link.FallbackLink, Code: http.StatusFound}, err
+}
+
+// PreviewLink returns the link behind token without resolving it, so its
+// destination can be shown before visiting. Protected links stay hidden;
+// links that are not active yet or have no clicks left come without
+// their destination.
+func (service *LinkService) PreviewLink(ctx context.Context, domain, token string) (*model.Link, error) {
+	link, err := service.lookup(ctx, service.domain(domain), token)
+	if err != nil {
+		return nil, err
+	}
+
+	if link.Protected() {
+		return nil, apierror.NewAPIError(apierror.ErrPasswordRequired, nil)
+	}
+
+	// Previews spend no click, so they must not reveal what a visit could not reach.
+	exhausted, err := service.exhausted(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	if exhausted || !link.Active(service.clock()) {
+		conceal(link)
+	}
+
+	link.ShortLink = service.shortLink(link)
+
+	return link, nil
+}
+
+// exhausted reports whether a limited link has no clicks left.
+func (service *LinkService) exhausted(ctx context.Context, link *model.Link) (bool, error) {
+	if !link.Limited() {
+		return false, nil
+	}
+
+	left, err := service.repository.ClicksLeft(ctx, link.Domain, link.Token)
+	if err != nil {
+		return false, err
+	}
+
+	return left <= 0, nil
+}
+
+// conceal drops everything a link tells about its destinations. Variants
+// keep their weights and hits.
+func conceal(link *model.Link) {
+	link.OriginalLink = ""
+	link.CanonicalLink = ""
+	link.FallbackLink = ""
+	link.Rules = nil
+	link.Page = model.Page{}
+
+	if link.Split() {
+		variants := make([]model.Variant, len(link.Variants))
+		for i, v := range link.Variants {
+			variants[i] = model.Variant{Weight: v.Weight, Hits: v.Hits}
+		}
+
+		link.Variants = variants
+	}
 }
 
 func (service *LinkService) CreateShortLink(ctx context.Context, linkRequest *dto.CreateLinkRequest) (*model.Link, error) {
-	_, err := url.ParseRequestURI(linkRequest.Link)
+	u, err := service.destination(ctx, linkRequest.Link)
 	if err != nil {
-		return nil, apierror.NewAPIError(apierror.ErrURLNotValid, err)
+		return nil, err
+	}
+
+	canonical := linkRequest.Link
+	if service.canonicalizer != nil {
+		canonical = u.String()
+	}
+
+	if linkRequest.FallbackLink != "" {
+		if _, err := service.destination(ctx, linkRequest.FallbackLink); err != nil {
+			return nil, err
+		}
+	}
+
+	rules, err := service.rules(ctx, linkRequest.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := service.variants(ctx, linkRequest.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := service.creationDomain(linkRequest.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validQueryMode(linkRequest.ForwardQuery); err != nil {
+		return nil, err
+	}
+
+	if err := validRedirect(linkRequest); err != nil {
+		return nil, err
+	}
+
+	tags, err := validMetadata(linkRequest.Title, linkRequest.Description, linkRequest.Notes, linkRequest.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	utm, err := service.utm(linkRequest.UTMPreset, linkRequest.UTM)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validOpenGraph(linkRequest.OpenGraph); err != nil {
+		return nil, err
+	}
+
+	if linkRequest.MaxClicks < 0 {
+		return nil, apierror.InvalidFieldError("max_clicks", fmt.Errorf("must not be negative"))
 	}
 
-	token := service.generator.GenerateShortURL(linkRequest.Link)
+	ttl, err := service.lifetime(linkRequest.TTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.validAlias(linkRequest.Alias); err != nil {
+		return nil, err
+	}
+
+	createdAt := service.clock()
+	expiresAt := createdAt.Add(ttl)
+
+	if !linkRequest.ActiveFrom.IsZero() && !linkRequest.ActiveFrom.Before(expiresAt) {
+		return nil, apierror.InvalidFieldError("active_from", fmt.Errorf("link expires before it becomes active"))
+	}
+
+	var passwordHash string
+	if linkRequest.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(linkRequest.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, apierror.NewAPIError(apierror.ErrBadRequest, err)
+		}
+
+		passwordHash = string(hash)
+	}
 
-	link, _ := service.repository.GetLink(ctx, token)
+	// Equivalent URLs share the canonical form and therefore the token.
+	// Links with their own settings are never shared, so their token is salted.
+	var salt string
+	if customized(linkRequest) {
+		salt, err = randomSalt()
+		if err != nil {
+			return nil, apierror.InternalError(err)
+		}
+	}
+
+	token := linkRequest.Alias
+	if token == "" {
+		token = service.generator.GenerateShortURL(canonical + salt)
+	}
+
+	link, _ := service.repository.GetLink(ctx, domain, token)
 	if link != nil {
-		link.ShortLink = service.shortlinkPrefix + link.Token
+		if linkRequest.Alias != "" {
+			return nil, apierror.NewAPIError(apierror.ErrAliasTaken, nil)
+		}
+
+		link.ShortLink = service.shortLink(link)
 
 		return link, nil
 	}
 
 	link = &model.Link{
-		OriginalLink: linkRequest.Link,
-		Token:        token,
-		ExpiresAt:    service.expiration,
-		ShortLink:    fmt.Sprintf(service.shortlinkPrefix + token),
+		OriginalLink:      linkRequest.Link,
+		CanonicalLink:     canonical,
+		Token:             token,
+		Domain:            domain,
+		CreatedAt:         createdAt,
+		ExpiresAt:         expiresAt,
+		ActiveFrom:        linkRequest.ActiveFrom,
+		FallbackLink:      linkRequest.FallbackLink,
+		ShortLink:         service.prefix(domain) + token,
+		PasswordHash:      passwordHash,
+		MaxClicks:         linkRequest.MaxClicks,
+		Rules:             rules,
+		Variants:          variants,
+		ForwardPath:       linkRequest.ForwardPath,
+		ForwardQuery:      linkRequest.ForwardQuery,
+		RedirectCode:      linkRequest.RedirectCode,
+		Interstitial:      linkRequest.Interstitial,
+		InterstitialDelay: linkRequest.InterstitialDelay,
+		Title:             linkRequest.Title,
+		Description:       linkRequest.Description,
+		Tags:              tags,
+		Notes:             linkRequest.Notes,
+		UTM:               utm,
+		OpenGraph:         linkRequest.OpenGraph,
 	}
 	if err := service.repository.StoreLink(ctx, link); err != nil {
 		return nil, err
 	}
 
+	service.publish(model.LinkCreated, link)
+
+	// Destinations behind a password are not fetched, so nothing about them is stored.
+	if service.pages != nil && !link.Protected() {
+		service.pages.Enqueue(link)
+	}
+
 	return link, nil
 }
 
-func NewLinkService(cfg *config.Config, repo LinkRepository, strGenerator Generator) *LinkService {
-	deleteChan := make(chan []string)
-	prefix := fmt.Sprintf("http://%s:%d/url/", cfg.Service.Host, cfg.Service.Port)
+// destination parses and screens a URL links may redirect to.
+// The result is in canonical form when a canonicalizer is configured.
+func (service *LinkService) destination(ctx context.Context, rawURL string) (*url.URL, error) {
+	u, err := url.ParseRequestURI(rawURL)
+	if err != nil {
+		return nil, apierror.NewAPIError(apierror.ErrURLNotValid, err)
+	}
 
-	repo.StartRecalculation(time.Duration(cfg.Service.RecalculationInterval)*time.Hour, deleteChan)
+	if service.canonicalizer != nil {
+		u, err = service.canonicalizer.Canonicalize(u)
+		if err != nil {
+			return nil, apierror.NewAPIError(apierror.ErrURLNotValid, err)
+		}
+	}
 
-	return &LinkService{
+	if service.checker != nil {
+		if err := service.checker.Check(ctx, u); err != nil {
+			return nil, apierror.NewAPIError(apierror.ErrURLForbidden, err)
+		}
+	}
+
+	if u.Host == "" {
+		return nil, apierror.NewAPIError(apierror.ErrURLNotValid, fmt.Errorf("no host in %q", rawURL))
+	}
+
+	return u, nil
+}
+
+// lifetime checks the lifetime in seconds a link asks for.
+// Links asking for none get the default lifetime.
+func (service *LinkService) lifetime(seconds int64) (time.Duration, error) {
+	if seconds == 0 {
+		return service.ttl, nil
+	}
+
+	ttl := time.Duration(seconds) * time.Second
+	if seconds < 0 || ttl < minLinkTTL || ttl > maxLinkTTL {
+		return 0, apierror.InvalidFieldError("ttl", fmt.Errorf("must be between %d and %d seconds", minLinkTTL/time.Second, maxLinkTTL/time.Second))
+	}
+
+	return ttl, nil
+}
+
+// validAlias checks a token asked for in place of a generated one. Aliases
+// are never as long as generated tokens, so neither can take the other's
+// place, and only use characters lookup accepts.
+func (service *LinkService) validAlias(alias string) error {
+	if alias == "" {
+		return nil
+	}
+
+	n := utf8.RuneCountInString(alias)
+
+	switch {
+	case n < minAliasLength || n > maxAliasLength:
+		return apierror.InvalidFieldError("alias", fmt.Errorf("must have %d to %d characters", minAliasLength, maxAliasLength))
+	case n == service.tokenLength:
+		return apierror.InvalidFieldError("alias", fmt.Errorf("must not have %d characters, as generated tokens do", n))
+	case service.alphabet != "" && strings.IndexFunc(alias, service.foreign) >= 0:
+		return apierror.InvalidFieldError("alias", fmt.Errorf("must only use the characters %q", service.alphabet))
+	}
+
+	return nil
+}
+
+// customized reports whether the request asks for settings of its own.
+func customized(r *dto.CreateLinkRequest) bool {
+	return r.Password != "" || r.TTL != 0 || r.MaxClicks > 0 || !r.ActiveFrom.IsZero() || r.FallbackLink != "" ||
+		len(r.Rules) > 0 || len(r.Variants) > 0 || r.ForwardPath || r.ForwardQuery != "" ||
+		r.RedirectCode != 0 || r.Interstitial || r.Title != "" || r.Description != "" || len(r.Tags) > 0 ||
+		r.Notes != "" || r.UTMPreset != "" || !r.UTM.Empty() || r.OpenGraph.IsDefined()
+}
+
+func randomSalt() (string, error) {
+	id, err := randomID()
+	if err != nil {
+		return "", err
+	}
+
+	return "#" + id, nil
+}
+
+// randomID returns 128 random bits in hex.
+func randomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// clock returns the current time; tests stop it with the now field.
+func (service *LinkService) clock() time.Time {
+	if service.now == nil {
+		return time.Now()
+	}
+
+	return service.now()
+}
+
+// lookup loads the link of token. Tokens with characters the generator does
+// not use are not looked up: the repository may keep other data under such
+// keys, as Redis does with <token>:clicks and tag:<tag>. Without an alphabet
+// every token is looked up.
+func (service *LinkService) lookup(ctx context.Context, domain, token string) (*model.Link, error) {
+	if service.alphabet != "" && strings.IndexFunc(token, service.foreign) >= 0 {
+		return nil, apierror.ErrLinkNotFound
+	}
+
+	return service.repository.GetLink(ctx, domain, token)
+}
+
+// foreign reports whether r is not in the alphabet of generated tokens.
+func (service *LinkService) foreign(r rune) bool {
+	return !strings.ContainsRune(service.alphabet, r)
+}
+
+func NewLinkService(cfg *config.Config, repo LinkRepository, strGenerator Generator, opts ...Option) *LinkService {
+	prefix := fmt.Sprintf("http://%s:%d/", cfg.Service.Host, cfg.Service.Port)
+	if cfg.Service.BaseURL != "" {
+		prefix = strings.TrimSuffix(cfg.Service.BaseURL, "/") + "/"
+	}
+
+	service := &LinkService{
 		repository:      repo,
 		generator:       strGenerator,
 		shortlinkPrefix: prefix,
-		expiration:      time.Now().Add(time.Duration(24) * time.Hour), //TODO: cfg add
+		domains:         brandedDomains(cfg.Service.Domains),
+		alphabet:        cfg.LinkGen.Alphabet,
+		tokenLength:     cfg.LinkGen.Length,
+		utmPresets:      utmPresets(cfg.UTM.Presets),
+		ttl:             time.Duration(24) * time.Hour, //TODO: cfg add
+		now:             time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(service)
 	}
+
+	deleted := make(chan []*model.Link)
+	repo.StartRecalculation(time.Duration(cfg.Service.RecalculationInterval)*time.Hour, deleted)
+
+	go service.expire(deleted)
+
+	return service
 }
@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"fmt"
 	"testing"
 	"time"
 
@@ -10,9 +9,13 @@ import (
 	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
 	mock_usecase "github.com/CodeMaster482/ShortLinkAPI/internal/usecase/mocks"
 	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/throttle"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/urlcanon"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/urlcheck"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var (
@@ -42,7 +45,7 @@ func TestLinkService_GetOriginalLink(t *testing.T) {
 				linkReturned := &model.Link{
 					OriginalLink: link,
 				}
-				repository.EXPECT().GetLink(gomock.Any(), gomock.Any()).Return(linkReturned, nil)
+				repository.EXPECT().GetLink(gomock.Any(), gomock.Any(), gomock.Any()).Return(linkReturned, nil)
 			},
 		},
 		{
@@ -52,7 +55,7 @@ func TestLinkService_GetOriginalLink(t *testing.T) {
 			mockBehaviour: func(repository *mock_usecase.MockLinkRepository,
 				generator *mock_usecase.MockGenerator,
 				token, link string) {
-				repository.EXPECT().GetLink(gomock.Any(), gomock.Any()).Return(nil, apierror.NotFoundError())
+				repository.EXPECT().GetLink(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, apierror.NotFoundError())
 			},
 		},
 	}
@@ -76,14 +79,14 @@ func TestLinkService_GetOriginalLink(t *testing.T) {
 				shortlinkPrefix: prefix,
 			}
 
-			link, err := usecase.GetFullLink(context.TODO(), test.token)
+			link, err := usecase.GetFullLink(context.TODO(), "", test.token, nil)
 			if test.expectedError != nil {
 				require.ErrorAs(t, err, &test.expectedError)
 				return
 			}
 
 			require.NoError(t, err)
-			require.Equal(t, test.expectedLink, link)
+			require.Equal(t, test.expectedLink, link.Target)
 		})
 	}
 }
@@ -98,6 +101,7 @@ func TestLinkService_CreateShortLink(t *testing.T) {
 
 		mockBehaviour func(repository *mock_usecase.MockLinkRepository,
 			generator *mock_usecase.MockGenerator,
+			checker *mock_usecase.MockURLChecker,
 			dto *dto.CreateLinkRequest, link *model.Link)
 	}{
 		{
@@ -111,9 +115,10 @@ func TestLinkService_CreateShortLink(t *testing.T) {
 				ExpiresAt:    time.Now(),
 				ShortLink:    prefix + "qwerty123_",
 			},
-			mockBehaviour: func(repository *mock_usecase.MockLinkRepository, generator *mock_usecase.MockGenerator, dto *dto.CreateLinkRequest, link *model.Link) {
-				repository.EXPECT().GetLinkByOriginal(gomock.Any(), dto.Link).Return(nil, nil)
-				generator.EXPECT().GenerateShortURL(gomock.Any()).Return(link.Token).AnyTimes()
+			mockBehaviour: func(repository *mock_usecase.MockLinkRepository, generator *mock_usecase.MockGenerator, checker *mock_usecase.MockURLChecker, dto *dto.CreateLinkRequest, link *model.Link) {
+				checker.EXPECT().Check(gomock.Any(), gomock.Any()).Return(nil)
+				generator.EXPECT().GenerateShortURL(dto.Link).Return(link.Token).AnyTimes()
+				repository.EXPECT().GetLink(gomock.Any(), "", link.Token).Return(nil, apierror.ErrLinkNotFound)
 				repository.EXPECT().StoreLink(gomock.Any(), gomock.Any()).Return(nil)
 			},
 		}, {
@@ -122,8 +127,28 @@ func TestLinkService_CreateShortLink(t *testing.T) {
 				Link: "bag",
 			},
 			expectedLink:  nil,
-			expectedError: apierror.NewAPIError(apierror.ErrURLNotValid, fmt.Errorf("empty URL")),
-			mockBehaviour: func(repository *mock_usecase.MockLinkRepository, generator *mock_usecase.MockGenerator, dto *dto.CreateLinkRequest, link *model.Link) {
+			expectedError: apierror.ErrURLNotValid,
+			mockBehaviour: func(repository *mock_usecase.MockLinkRepository, generator *mock_usecase.MockGenerator, checker *mock_usecase.MockURLChecker, dto *dto.CreateLinkRequest, link *model.Link) {
+			},
+		}, {
+			name: "forbidden url",
+			dto: &dto.CreateLinkRequest{
+				Link: "javascript:alert(1)",
+			},
+			expectedLink:  nil,
+			expectedError: apierror.ErrURLForbidden,
+			mockBehaviour: func(repository *mock_usecase.MockLinkRepository, generator *mock_usecase.MockGenerator, checker *mock_usecase.MockURLChecker, dto *dto.CreateLinkRequest, link *model.Link) {
+				checker.EXPECT().Check(gomock.Any(), gomock.Any()).Return(urlcheck.ErrSchemeNotAllowed)
+			},
+		}, {
+			name: "no host",
+			dto: &dto.CreateLinkRequest{
+				Link: "http:///path",
+			},
+			expectedLink:  nil,
+			expectedError: apierror.ErrURLNotValid,
+			mockBehaviour: func(repository *mock_usecase.MockLinkRepository, generator *mock_usecase.MockGenerator, checker *mock_usecase.MockURLChecker, dto *dto.CreateLinkRequest, link *model.Link) {
+				checker.EXPECT().Check(gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
 	}
@@ -137,18 +162,20 @@ func TestLinkService_CreateShortLink(t *testing.T) {
 
 			mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
 			mockGenerator := mock_usecase.NewMockGenerator(ctrl)
+			mockChecker := mock_usecase.NewMockURLChecker(ctrl)
 
-			test.mockBehaviour(mockRepo, mockGenerator, test.dto, test.expectedLink)
+			test.mockBehaviour(mockRepo, mockGenerator, mockChecker, test.dto, test.expectedLink)
 
 			usecase := LinkService{
 				repository:      mockRepo,
 				generator:       mockGenerator,
+				checker:         mockChecker,
 				shortlinkPrefix: prefix,
 			}
 
 			link, err := usecase.CreateShortLink(context.TODO(), test.dto)
 			if test.expectedError != nil {
-				require.ErrorAs(t, err, &test.expectedError)
+				require.ErrorIs(t, err, test.expectedError)
 				return
 			}
 
@@ -163,3 +190,473 @@ func TestLinkService_CreateShortLink(t *testing.T) {
 		})
 	}
 }
+
+func TestLinkService_CreateShortLink_Canonical(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockGenerator := mock_usecase.NewMockGenerator(ctrl)
+
+	canonical := "https://example.com/a"
+	stored := &model.Link{
+		OriginalLink:  "https://Example.com/a/",
+		CanonicalLink: canonical,
+		Token:         "qwerty123_",
+		ShortLink:     prefix + "qwerty123_",
+	}
+
+	gomock.InOrder(
+		mockGenerator.EXPECT().GenerateShortURL(canonical).Return(stored.Token),
+		mockRepo.EXPECT().GetLink(gomock.Any(), "", stored.Token).Return(nil, apierror.ErrLinkNotFound),
+		mockRepo.EXPECT().StoreLink(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, link *model.Link) error {
+			require.False(t, link.CreatedAt.IsZero())
+			require.Equal(t, link.CreatedAt, link.ExpiresAt)
+
+			link.CreatedAt, link.ExpiresAt = time.Time{}, time.Time{}
+			require.Equal(t, stored, link)
+
+			return nil
+		}),
+		mockGenerator.EXPECT().GenerateShortURL(canonical).Return(stored.Token),
+		mockRepo.EXPECT().GetLink(gomock.Any(), "", stored.Token).Return(stored, nil),
+	)
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		generator:       mockGenerator,
+		canonicalizer:   urlcanon.New(urlcanon.StripParams("utm_*")),
+		shortlinkPrefix: prefix,
+	}
+
+	first, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{Link: "https://Example.com/a/"})
+	require.NoError(t, err)
+
+	second, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{Link: "https://example.com:443/a?utm_source=x"})
+	require.NoError(t, err)
+
+	require.Equal(t, prefix+stored.Token, first.ShortLink)
+	require.Equal(t, first.ShortLink, second.ShortLink)
+	require.Equal(t, "https://Example.com/a/", second.OriginalLink)
+}
+
+func TestLinkService_UnlockLink(t *testing.T) {
+	t.Parallel()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	protected := &model.Link{
+		OriginalLink: "https://intranet.example.com/doc",
+		Token:        "qwerty123_",
+		PasswordHash: string(hash),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", protected.Token).Return(protected, nil).AnyTimes()
+
+	usecase := LinkService{
+		repository: mockRepo,
+		attempts:   throttle.New(throttle.MaxFailures(2)),
+	}
+
+	_, err = usecase.GetFullLink(context.TODO(), "", protected.Token, nil)
+	require.ErrorIs(t, err, apierror.ErrPasswordRequired)
+
+	link, err := usecase.UnlockLink(context.TODO(), "", protected.Token, "secret", nil)
+	require.NoError(t, err)
+	require.Equal(t, protected.OriginalLink, link.Target)
+
+	_, err = usecase.UnlockLink(context.TODO(), "", protected.Token, "guess-1", nil)
+	require.ErrorIs(t, err, apierror.ErrPasswordInvalid)
+
+	_, err = usecase.UnlockLink(context.TODO(), "", protected.Token, "guess-2", nil)
+	require.ErrorIs(t, err, apierror.ErrPasswordInvalid)
+
+	_, err = usecase.UnlockLink(context.TODO(), "", protected.Token, "secret", nil)
+	require.ErrorIs(t, err, apierror.ErrTooManyAttempts)
+}
+
+func TestLinkService_CreateShortLink_Password(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockGenerator := mock_usecase.NewMockGenerator(ctrl)
+
+	var stored *model.Link
+
+	mockGenerator.EXPECT().GenerateShortURL(gomock.Not("https://example.com")).Return("protected_")
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "protected_").Return(nil, apierror.ErrLinkNotFound)
+	mockRepo.EXPECT().StoreLink(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, link *model.Link) error {
+		stored = link
+		return nil
+	})
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		generator:       mockGenerator,
+		shortlinkPrefix: prefix,
+	}
+
+	link, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:     "https://example.com",
+		Password: "secret",
+	})
+	require.NoError(t, err)
+	require.Equal(t, prefix+"protected_", link.ShortLink)
+	require.True(t, stored.Protected())
+	require.NotContains(t, stored.PasswordHash, "secret")
+	require.NoError(t, bcrypt.CompareHashAndPassword([]byte(stored.PasswordHash), []byte("secret")))
+}
+
+func TestLinkService_GetFullLink_MaxClicks(t *testing.T) {
+	t.Parallel()
+
+	limited := &model.Link{
+		OriginalLink: "https://example.com/reset?code=42",
+		Token:        "qwerty123_",
+		MaxClicks:    1,
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", limited.Token).Return(limited, nil).Times(2)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().ConsumeClick(gomock.Any(), "", limited.Token).Return(int64(0), nil),
+		mockRepo.EXPECT().ConsumeClick(gomock.Any(), "", limited.Token).Return(int64(0), apierror.ErrLinkExhausted),
+	)
+
+	usecase := LinkService{
+		repository: mockRepo,
+	}
+
+	link, err := usecase.GetFullLink(context.TODO(), "", limited.Token, nil)
+	require.NoError(t, err)
+	require.Equal(t, limited.OriginalLink, link.Target)
+
+	_, err = usecase.GetFullLink(context.TODO(), "", limited.Token, nil)
+	require.ErrorIs(t, err, apierror.ErrLinkExhausted)
+}
+
+func TestLinkService_GetFullLink_MaxClicksProtected(t *testing.T) {
+	t.Parallel()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	limited := &model.Link{
+		OriginalLink: "https://example.com/reset?code=42",
+		Token:        "qwerty123_",
+		PasswordHash: string(hash),
+		MaxClicks:    1,
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", limited.Token).Return(limited, nil).AnyTimes()
+	mockRepo.EXPECT().ConsumeClick(gomock.Any(), "", limited.Token).Return(int64(0), nil).Times(1)
+
+	usecase := LinkService{
+		repository: mockRepo,
+	}
+
+	// Neither the password prompt nor a wrong password may burn a click.
+	_, err = usecase.GetFullLink(context.TODO(), "", limited.Token, nil)
+	require.ErrorIs(t, err, apierror.ErrPasswordRequired)
+
+	_, err = usecase.UnlockLink(context.TODO(), "", limited.Token, "guess", nil)
+	require.ErrorIs(t, err, apierror.ErrPasswordInvalid)
+
+	link, err := usecase.UnlockLink(context.TODO(), "", limited.Token, "secret", nil)
+	require.NoError(t, err)
+	require.Equal(t, limited.OriginalLink, link.Target)
+}
+
+func TestLinkService_GetFullLink_NotActive(t *testing.T) {
+	t.Parallel()
+
+	scheduled := &model.Link{
+		OriginalLink: "https://example.com/sale",
+		Token:        "scheduled_",
+		ActiveFrom:   time.Now().Add(time.Hour),
+		FallbackLink: "https://example.com/soon",
+	}
+	started := &model.Link{
+		OriginalLink: "https://example.com/sale",
+		Token:        "started___",
+		ActiveFrom:   time.Now().Add(-time.Hour),
+		FallbackLink: "https://example.com/soon",
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", scheduled.Token).Return(scheduled, nil).Times(2)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", started.Token).Return(started, nil)
+
+	usecase := LinkService{
+		repository: mockRepo,
+	}
+
+	link, err := usecase.GetFullLink(context.TODO(), "", scheduled.Token, nil)
+	require.ErrorIs(t, err, apierror.ErrLinkNotActive)
+	require.Equal(t, scheduled.FallbackLink, link.Target)
+
+	link, err = usecase.UnlockLink(context.TODO(), "", scheduled.Token, "", nil)
+	require.ErrorIs(t, err, apierror.ErrLinkNotActive)
+	require.Equal(t, scheduled.FallbackLink, link.Target)
+
+	link, err = usecase.GetFullLink(context.TODO(), "", started.Token, nil)
+	require.NoError(t, err)
+	require.Equal(t, started.OriginalLink, link.Target)
+}
+
+func TestLinkService_ForeignToken(t *testing.T) {
+	t.Parallel()
+
+	link := &model.Link{OriginalLink: "https://example.com", Token: "abc_123"}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", link.Token).Return(link, nil)
+
+	usecase := LinkService{
+		repository: mockRepo,
+		alphabet:   "abcdefghijklmnopqrstuvwxyz0123456789_",
+	}
+
+	redirect, err := usecase.GetFullLink(context.TODO(), "", link.Token, nil)
+	require.NoError(t, err)
+	require.Equal(t, link.OriginalLink, redirect.Target)
+
+	for _, token := range []string{"abc:clicks", "abc:count", "tag:docs", "webhook:ids", "go.example.com/abc"} {
+		_, err := usecase.GetFullLink(context.TODO(), "", token, nil)
+		require.ErrorIs(t, err, apierror.ErrLinkNotFound, token)
+
+		_, err = usecase.UnlockLink(context.TODO(), "", token, "secret", nil)
+		require.ErrorIs(t, err, apierror.ErrLinkNotFound, token)
+
+		_, err = usecase.PreviewLink(context.TODO(), "", token)
+		require.ErrorIs(t, err, apierror.ErrLinkNotFound, token)
+	}
+}
+
+func TestLinkService_CreateShortLink_Schedule(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockGen := mock_usecase.NewMockGenerator(ctrl)
+
+	var stored *model.Link
+	mockGen.EXPECT().GenerateShortURL(gomock.Any()).Return("scheduled_")
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "scheduled_").Return(nil, apierror.ErrLinkNotFound)
+	mockRepo.EXPECT().StoreLink(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, link *model.Link) error {
+		stored = link
+		return nil
+	})
+
+	// The service has been up for two days: the schedule is checked against
+	// the expiry of the new link, not against the one of the first links.
+	now := time.Now().Add(48 * time.Hour)
+	usecase := LinkService{
+		repository:      mockRepo,
+		generator:       mockGen,
+		shortlinkPrefix: prefix,
+		ttl:             24 * time.Hour,
+		now:             func() time.Time { return now },
+	}
+
+	activeFrom := now.Add(time.Hour)
+	link, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:         "https://example.com/sale",
+		ActiveFrom:   activeFrom,
+		FallbackLink: "https://example.com/soon",
+	})
+	require.NoError(t, err)
+	require.Equal(t, activeFrom, stored.ActiveFrom)
+	require.Equal(t, "https://example.com/soon", stored.FallbackLink)
+	require.Equal(t, now, stored.CreatedAt)
+	require.Equal(t, now.Add(24*time.Hour), link.ExpiresAt)
+
+	_, err = usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:       "https://example.com/sale",
+		ActiveFrom: now.Add(25 * time.Hour),
+	})
+	require.ErrorIs(t, err, apierror.ErrBadRequest)
+
+	_, err = usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:         "https://example.com/sale",
+		FallbackLink: "not a url",
+	})
+	require.ErrorIs(t, err, apierror.ErrURLNotValid)
+}
+
+func TestLinkService_CreateShortLink_AliasAndTTL(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockGen := mock_usecase.NewMockGenerator(ctrl)
+
+	var stored *model.Link
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "spring_sale").Return(nil, apierror.ErrLinkNotFound)
+	mockRepo.EXPECT().StoreLink(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, link *model.Link) error {
+		stored = link
+		return nil
+	})
+
+	now := time.Now()
+	usecase := LinkService{
+		repository:      mockRepo,
+		generator:       mockGen,
+		shortlinkPrefix: prefix,
+		alphabet:        "abcdefghijklmnopqrstuvwxyz0123456789_",
+		tokenLength:     10,
+		ttl:             24 * time.Hour,
+		now:             func() time.Time { return now },
+	}
+
+	link, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:  "https://example.com/sale",
+		Alias: "spring_sale",
+		TTL:   3600,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "spring_sale", stored.Token)
+	require.Equal(t, prefix+"spring_sale", link.ShortLink)
+	require.Equal(t, now.Add(time.Hour), stored.ExpiresAt)
+
+	// Taken aliases are not handed out again, not even for the same URL.
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "spring_sale").Return(stored, nil)
+
+	_, err = usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{Link: "https://example.com/sale", Alias: "spring_sale"})
+	require.ErrorIs(t, err, apierror.ErrAliasTaken)
+
+	testCases := []struct {
+		name    string
+		request dto.CreateLinkRequest
+		field   string
+	}{
+		{name: "alias as long as generated tokens", request: dto.CreateLinkRequest{Alias: "spring1234"}, field: "alias"},
+		{name: "alias out of the alphabet", request: dto.CreateLinkRequest{Alias: "Spring_Sale"}, field: "alias"},
+		{name: "short alias", request: dto.CreateLinkRequest{Alias: "ab"}, field: "alias"},
+		{name: "short ttl", request: dto.CreateLinkRequest{TTL: 59}, field: "ttl"},
+		{name: "long ttl", request: dto.CreateLinkRequest{TTL: int64(maxLinkTTL/time.Second) + 1}, field: "ttl"},
+		{name: "negative ttl", request: dto.CreateLinkRequest{TTL: -3600}, field: "ttl"},
+	}
+
+	for _, tc := range testCases {
+		tc.request.Link = "https://example.com/sale"
+
+		_, err := usecase.CreateShortLink(context.TODO(), &tc.request)
+		require.ErrorIs(t, err, apierror.ErrBadRequest, tc.name)
+
+		var (
+			apiErr   *apierror.APIError
+			fieldErr *apierror.FieldError
+		)
+
+		require.ErrorAs(t, err, &apiErr, tc.name)
+		require.ErrorAs(t, apiErr.Internal(), &fieldErr, tc.name)
+		require.Equal(t, tc.field, fieldErr.Field, tc.name)
+	}
+}
+
+func TestLinkService_PreviewLink(t *testing.T) {
+	t.Parallel()
+
+	public := &model.Link{OriginalLink: "https://example.com/docs", Token: "public____"}
+	protected := &model.Link{OriginalLink: "https://example.com/secret", Token: "protected_", PasswordHash: "$2a$10$hash"}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", public.Token).Return(public, nil)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", protected.Token).Return(protected, nil)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "missing___").Return(nil, apierror.ErrLinkNotFound)
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		shortlinkPrefix: prefix,
+	}
+
+	link, err := usecase.PreviewLink(context.TODO(), "", public.Token)
+	require.NoError(t, err)
+	require.Equal(t, public.OriginalLink, link.OriginalLink)
+	require.Equal(t, prefix+public.Token, link.ShortLink)
+
+	_, err = usecase.PreviewLink(context.TODO(), "", protected.Token)
+	require.ErrorIs(t, err, apierror.ErrPasswordRequired)
+
+	_, err = usecase.PreviewLink(context.TODO(), "", "missing___")
+	require.ErrorIs(t, err, apierror.ErrLinkNotFound)
+}
+
+func TestLinkService_PreviewLink_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	scheduled := &model.Link{
+		OriginalLink: "https://example.com/sale",
+		Token:        "scheduled_",
+		ActiveFrom:   now.Add(time.Hour),
+		FallbackLink: "https://example.com/soon",
+		Title:        "Sale",
+		Page:         model.Page{Title: "Secret sale"},
+	}
+	burned := &model.Link{OriginalLink: "https://example.com/once", Token: "burned____", MaxClicks: 1}
+	limited := &model.Link{OriginalLink: "https://example.com/twice", Token: "limited___", MaxClicks: 2}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", scheduled.Token).Return(scheduled, nil)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", burned.Token).Return(burned, nil)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", limited.Token).Return(limited, nil)
+	mockRepo.EXPECT().ClicksLeft(gomock.Any(), "", burned.Token).Return(int64(0), nil)
+	mockRepo.EXPECT().ClicksLeft(gomock.Any(), "", limited.Token).Return(int64(1), nil)
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		shortlinkPrefix: prefix,
+		now:             func() time.Time { return now },
+	}
+
+	link, err := usecase.PreviewLink(context.TODO(), "", scheduled.Token)
+	require.NoError(t, err)
+	require.Empty(t, link.OriginalLink)
+	require.Empty(t, link.Page.Title)
+	require.Equal(t, "Sale", link.Title)
+	require.Equal(t, prefix+scheduled.Token, link.ShortLink)
+
+	link, err = usecase.PreviewLink(context.TODO(), "", burned.Token)
+	require.NoError(t, err)
+	require.Empty(t, link.OriginalLink)
+
+	link, err = usecase.PreviewLink(context.TODO(), "", limited.Token)
+	require.NoError(t, err)
+	require.Equal(t, limited.OriginalLink, link.OriginalLink)
+}
@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/CodeMaster482/ShortLinkAPI/config"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+)
+
+const (
+	maxTitleLength       = 200
+	maxDescriptionLength = 1000
+	maxNotesLength       = 4000
+	maxTags              = 16
+	maxTagLength         = 64
+	maxListedLinks       = 100
+)
+
+// UpdateLink changes the title, description, tags, notes and
+// Open Graph overrides of a link.
+func (service *LinkService) UpdateLink(ctx context.Context, domain, token string, request *dto.UpdateLinkRequest) (*model.Link, error) {
+	link, err := service.lookup(ctx, service.domain(domain), token)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Title != nil {
+		link.Title = *request.Title
+	}
+
+	if request.Description != nil {
+		link.Description = *request.Description
+	}
+
+	if request.Notes != nil {
+		link.Notes = *request.Notes
+	}
+
+	if request.Tags != nil {
+		link.Tags = *request.Tags
+	}
+
+	if request.OpenGraph != nil {
+		link.OpenGraph = *request.OpenGraph
+	}
+
+	link.Tags, err = validMetadata(link.Title, link.Description, link.Notes, link.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validOpenGraph(link.OpenGraph); err != nil {
+		return nil, err
+	}
+
+	if err := service.repository.UpdateMetadata(ctx, link); err != nil {
+		return nil, err
+	}
+
+	service.publish(model.LinkUpdated, link)
+
+	if err := service.variantHits(ctx, link); err != nil {
+		return nil, err
+	}
+
+	return service.listed(link), nil
+}
+
+// DeleteLink deletes a link for good. Its token may be handed out again.
+func (service *LinkService) DeleteLink(ctx context.Context, domain, token string) error {
+	link, err := service.lookup(ctx, service.domain(domain), token)
+	if err != nil {
+		return err
+	}
+
+	if err := service.repository.DeleteLink(ctx, link.Domain, link.Token); err != nil {
+		return err
+	}
+
+	service.publish(model.LinkDeleted, link)
+
+	return nil
+}
+
+// ListLinks returns the most recent links tagged with tag.
+func (service *LinkService) ListLinks(ctx context.Context, tag string) ([]*model.Link, error) {
+	tag = normalizeTag(tag)
+	if tag == "" {
+		return nil, apierror.InvalidFieldError("tag", fmt.Errorf("tag is required"))
+	}
+
+	links, err := service.repository.ListLinksByTag(ctx, tag, maxListedLinks)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, link := range links {
+		if err := service.variantHits(ctx, link); err != nil {
+			return nil, err
+		}
+
+		links[i] = service.listed(link)
+	}
+
+	return links, nil
+}
+
+// listed prepares link to be shown to its owner. As with previews,
+// the destinations of protected links stay hidden.
+func (service *LinkService) listed(link *model.Link) *model.Link {
+	link.ShortLink = service.shortLink(link)
+
+	if link.Protected() {
+		conceal(link)
+	}
+
+	return link
+}
+
+// validMetadata checks the lengths of the descriptive fields of a link
+// and returns its tags normalized.
+func validMetadata(title, description, notes string, tags []string) ([]string, error) {
+	switch {
+	case utf8.RuneCountInString(title) > maxTitleLength:
+		return nil, apierror.InvalidFieldError("title", fmt.Errorf("title is longer than %d characters", maxTitleLength))
+	case utf8.RuneCountInString(description) > maxDescriptionLength:
+		return nil, apierror.InvalidFieldError("description", fmt.Errorf("description is longer than %d characters", maxDescriptionLength))
+	case utf8.RuneCountInString(notes) > maxNotesLength:
+		return nil, apierror.InvalidFieldError("notes", fmt.Errorf("notes are longer than %d characters", maxNotesLength))
+	}
+
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, apierror.InvalidFieldError("tags", fmt.Errorf("tags must have 1 to %d characters", maxTagLength))
+		}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > maxTags {
+		return nil, apierror.InvalidFieldError("tags", fmt.Errorf("at most %d tags are allowed", maxTags))
+	}
+
+	return normalized, nil
+}
+
+// validOpenGraph checks the Open Graph overrides of a link.
+// The image is shown by the unfurling client, so it has to be an absolute web URL.
+func validOpenGraph(og model.OpenGraph) error {
+	switch {
+	case utf8.RuneCountInString(og.Title) > maxTitleLength:
+		return apierror.InvalidFieldError("open_graph.title", fmt.Errorf("open graph title is longer than %d characters", maxTitleLength))
+	case utf8.RuneCountInString(og.Description) > maxDescriptionLength:
+		return apierror.InvalidFieldError("open_graph.description", fmt.Errorf("open graph description is longer than %d characters", maxDescriptionLength))
+	}
+
+	if og.Image == "" {
+		return nil
+	}
+
+	u, err := url.ParseRequestURI(og.Image)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apierror.InvalidFieldError("open_graph.image", fmt.Errorf("open graph image must be an http(s) URL"))
+	}
+
+	return nil
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// utm merges the parameters given with a link over those of the named preset.
+func (service *LinkService) utm(preset string, params model.UTM) (model.UTM, error) {
+	if preset == "" {
+		return params, nil
+	}
+
+	defaults, ok := service.utmPresets[preset]
+	if !ok {
+		return model.UTM{}, apierror.InvalidFieldError("utm_preset", fmt.Errorf("unknown utm preset %q", preset))
+	}
+
+	return params.Merge(defaults), nil
+}
+
+func utmPresets(presets map[string]config.UTMPreset) map[string]model.UTM {
+	utms := make(map[string]model.UTM, len(presets))
+
+	for name, preset := range presets {
+		utms[name] = model.UTM{
+			Source:   preset.Source,
+			Medium:   preset.Medium,
+			Campaign: preset.Campaign,
+			Term:     preset.Term,
+			Content:  preset.Content,
+		}
+	}
+
+	return utms
+}
+
+// withUTM adds the UTM parameters of a link to dest. Parameters dest already
+// carries, from the link itself or the visit, are left alone.
+func withUTM(dest string, utm model.UTM) string {
+	if utm.Empty() {
+		return dest
+	}
+
+	u, err := url.Parse(dest)
+	if err != nil || u.Opaque != "" {
+		return dest
+	}
+
+	query := u.Query()
+	for name, values := range utm.Params() {
+		if !query.Has(name) {
+			query[name] = values
+		}
+	}
+
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	mock_usecase "github.com/CodeMaster482/ShortLinkAPI/internal/usecase/mocks"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidMetadata(t *testing.T) {
+	t.Parallel()
+
+	tags, err := validMetadata("Title", "", "", []string{" Docs", "docs", "API "})
+	require.NoError(t, err)
+	require.Equal(t, []string{"docs", "api"}, tags)
+
+	tooMany := make([]string, maxTags+1)
+	for i := range tooMany {
+		tooMany[i] = strings.Repeat("t", i+1)
+	}
+
+	testCases := []struct {
+		name  string
+		title string
+		notes string
+		tags  []string
+	}{
+		{name: "Long title", title: strings.Repeat("x", maxTitleLength+1)},
+		{name: "Long notes", notes: strings.Repeat("x", maxNotesLength+1)},
+		{name: "Blank tag", tags: []string{" "}},
+		{name: "Long tag", tags: []string{strings.Repeat("t", maxTagLength+1)}},
+		{name: "Too many tags", tags: tooMany},
+	}
+
+	for _, tc := range testCases {
+		_, err := validMetadata(tc.title, "", tc.notes, tc.tags)
+		require.ErrorIs(t, err, apierror.ErrBadRequest, tc.name)
+	}
+}
+
+func TestValidOpenGraph(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validOpenGraph(model.OpenGraph{}))
+	require.NoError(t, validOpenGraph(model.OpenGraph{Title: "Docs", Image: "https://example.com/card.png"}))
+
+	testCases := []struct {
+		name string
+		og   model.OpenGraph
+	}{
+		{name: "Long title", og: model.OpenGraph{Title: strings.Repeat("x", maxTitleLength+1)}},
+		{name: "Long description", og: model.OpenGraph{Description: strings.Repeat("x", maxDescriptionLength+1)}},
+		{name: "Relative image", og: model.OpenGraph{Image: "/card.png"}},
+		{name: "Script image", og: model.OpenGraph{Image: "javascript:alert(1)"}},
+	}
+
+	for _, tc := range testCases {
+		require.ErrorIs(t, validOpenGraph(tc.og), apierror.ErrBadRequest, tc.name)
+	}
+}
+
+func TestWithUTM(t *testing.T) {
+	t.Parallel()
+
+	utm := model.UTM{Source: "newsletter", Medium: "email"}
+
+	require.Equal(t, "https://example.com/a?utm_medium=email&utm_source=newsletter", withUTM("https://example.com/a", utm))
+	require.Equal(t, "https://example.com/a?utm_medium=email&utm_source=ads", withUTM("https://example.com/a?utm_source=ads", utm))
+	require.Equal(t, "https://example.com/a", withUTM("https://example.com/a", model.UTM{}))
+}
+
+func TestLinkService_CreateShortLink_Metadata(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockGen := mock_usecase.NewMockGenerator(ctrl)
+
+	var stored *model.Link
+	mockGen.EXPECT().GenerateShortURL(gomock.Any()).Return("tagged____")
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "tagged____").Return(nil, apierror.ErrLinkNotFound)
+	mockRepo.EXPECT().StoreLink(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, link *model.Link) error {
+		stored = link
+		return nil
+	})
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		generator:       mockGen,
+		shortlinkPrefix: prefix,
+		utmPresets:      map[string]model.UTM{"newsletter": {Source: "newsletter", Medium: "email"}},
+	}
+
+	_, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:      "https://example.com/landing",
+		Title:     "Landing",
+		Tags:      []string{"Launch"},
+		Notes:     "spring campaign",
+		UTMPreset: "newsletter",
+		UTM:       model.UTM{Medium: "print", Campaign: "spring"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Landing", stored.Title)
+	require.Equal(t, []string{"launch"}, stored.Tags)
+	require.Equal(t, model.UTM{Source: "newsletter", Medium: "print", Campaign: "spring"}, stored.UTM)
+
+	_, err = usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:      "https://example.com/landing",
+		UTMPreset: "unknown",
+	})
+	require.ErrorIs(t, err, apierror.ErrBadRequest)
+}
+
+func TestLinkService_GetFullLink_UTM(t *testing.T) {
+	t.Parallel()
+
+	link := &model.Link{
+		OriginalLink: "https://example.com/landing?ref=qr",
+		Token:        "tagged____",
+		UTM:          model.UTM{Source: "newsletter"},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", link.Token).Return(link, nil)
+
+	usecase := LinkService{repository: mockRepo}
+
+	redirect, err := usecase.GetFullLink(context.TODO(), "", link.Token, nil)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/landing?ref=qr&utm_source=newsletter", redirect.Target)
+}
+
+func TestLinkService_UpdateLink(t *testing.T) {
+	t.Parallel()
+
+	link := &model.Link{OriginalLink: "https://example.com", Token: "tagged____", Title: "Old", Notes: "keep"}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", link.Token).Return(link, nil).Times(2)
+	mockRepo.EXPECT().UpdateMetadata(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updated *model.Link) error {
+		require.Equal(t, "New", updated.Title)
+		require.Equal(t, "keep", updated.Notes)
+		require.Equal(t, []string{"docs"}, updated.Tags)
+
+		return nil
+	})
+
+	usecase := LinkService{repository: mockRepo, shortlinkPrefix: prefix}
+
+	title := "New"
+	tags := []string{"Docs"}
+
+	updated, err := usecase.UpdateLink(context.TODO(), "", link.Token, &dto.UpdateLinkRequest{Title: &title, Tags: &tags})
+	require.NoError(t, err)
+	require.Equal(t, prefix+link.Token, updated.ShortLink)
+
+	long := strings.Repeat("x", maxDescriptionLength+1)
+
+	_, err = usecase.UpdateLink(context.TODO(), "", link.Token, &dto.UpdateLinkRequest{Description: &long})
+	require.ErrorIs(t, err, apierror.ErrBadRequest)
+}
+
+func TestLinkService_DeleteLink(t *testing.T) {
+	t.Parallel()
+
+	link := &model.Link{OriginalLink: "https://example.com", Token: "deleted___", Domain: "go.example.com"}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "go.example.com", link.Token).Return(link, nil).Times(2)
+	mockRepo.EXPECT().DeleteLink(gomock.Any(), "go.example.com", link.Token).Return(nil)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "go.example.com", "missing___").Return(nil, apierror.ErrLinkNotFound)
+	mockRepo.EXPECT().DeleteLink(gomock.Any(), "go.example.com", link.Token).Return(errors.New("db down"))
+
+	mockWebhooks := mock_usecase.NewMockWebhookNotifier(ctrl)
+	mockWebhooks.EXPECT().Notify(gomock.Any()).Do(func(payload *model.WebhookPayload) {
+		require.Equal(t, model.WebhookLinkDeleted, payload.Event)
+		require.Equal(t, "go.example.com", payload.Domain)
+		require.Equal(t, link.Token, payload.Token)
+		require.Empty(t, payload.Link)
+	})
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		webhooks:        mockWebhooks,
+		shortlinkPrefix: prefix,
+		domains:         brandedDomains([]string{"https://go.example.com"}),
+	}
+
+	require.NoError(t, usecase.DeleteLink(context.TODO(), "go.example.com", link.Token))
+	require.ErrorIs(t, usecase.DeleteLink(context.TODO(), "go.example.com", "missing___"), apierror.ErrLinkNotFound)
+	require.EqualError(t, usecase.DeleteLink(context.TODO(), "go.example.com", link.Token), "db down")
+}
+
+func TestLinkService_ListLinks(t *testing.T) {
+	t.Parallel()
+
+	public := &model.Link{OriginalLink: "https://example.com/a", Token: "public____", Tags: []string{"docs"}}
+	protected := &model.Link{
+		OriginalLink:  "https://example.com/b",
+		CanonicalLink: "https://example.com/b",
+		FallbackLink:  "https://example.com/soon",
+		Token:         "protected_",
+		Tags:          []string{"docs"},
+		PasswordHash:  "hash",
+		Rules:         []model.Rule{{Country: "DE", Target: "https://example.com/de"}},
+		Page:          model.Page{Title: "Secret page"},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().ListLinksByTag(gomock.Any(), "docs", maxListedLinks).Return([]*model.Link{public, protected}, nil)
+
+	usecase := LinkService{repository: mockRepo, shortlinkPrefix: prefix}
+
+	links, err := usecase.ListLinks(context.TODO(), " Docs ")
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	require.Equal(t, "https://example.com/a", links[0].OriginalLink)
+	require.Equal(t, &model.Link{
+		ShortLink:    prefix + protected.Token,
+		Token:        protected.Token,
+		Tags:         []string{"docs"},
+		PasswordHash: "hash",
+	}, links[1], "destinations of protected links stay hidden")
+
+	_, err = usecase.ListLinks(context.TODO(), "")
+	require.ErrorIs(t, err, apierror.ErrBadRequest)
+}
+
+func TestLinkService_ListLinks_VariantHits(t *testing.T) {
+	t.Parallel()
+
+	variants := func() []model.Variant {
+		return []model.Variant{
+			{Target: "https://example.com/a", Weight: 3},
+			{Target: "https://example.com/b", Weight: 1},
+		}
+	}
+
+	split := &model.Link{OriginalLink: "https://example.com/a", Token: "split_____", Tags: []string{"docs"}, Variants: variants()}
+	protected := &model.Link{OriginalLink: "https://example.com/a", Token: "protected_", Tags: []string{"docs"}, Variants: variants(), PasswordHash: "hash"}
+	static := &model.Link{OriginalLink: "https://example.com/c", Token: "static____", Tags: []string{"docs"}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().ListLinksByTag(gomock.Any(), "docs", maxListedLinks).Return([]*model.Link{split, protected, static}, nil)
+	mockRepo.EXPECT().VariantHits(gomock.Any(), "", split.Token).Return(map[int]int64{0: 7, 1: 2}, nil)
+	mockRepo.EXPECT().VariantHits(gomock.Any(), "", protected.Token).Return(map[int]int64{1: 4}, nil)
+
+	usecase := LinkService{repository: mockRepo, shortlinkPrefix: prefix}
+
+	links, err := usecase.ListLinks(context.TODO(), "docs")
+	require.NoError(t, err)
+	require.Len(t, links, 3)
+	require.Equal(t, []model.Variant{
+		{Target: "https://example.com/a", Weight: 3, Hits: 7},
+		{Target: "https://example.com/b", Weight: 1, Hits: 2},
+	}, links[0].Variants)
+	require.Equal(t, []model.Variant{{Weight: 3}, {Weight: 1, Hits: 4}}, links[1].Variants, "destinations of protected links stay hidden")
+	require.Empty(t, links[2].Variants)
+
+	mockRepo.EXPECT().ListLinksByTag(gomock.Any(), "docs", maxListedLinks).Return([]*model.Link{split}, nil)
+	mockRepo.EXPECT().VariantHits(gomock.Any(), "", split.Token).Return(nil, errors.New("redis down"))
+
+	_, err = usecase.ListLinks(context.TODO(), "docs")
+	require.Error(t, err)
+}
@@ -6,10 +6,13 @@ package mock_usecase
 
 import (
 	context "context"
+	net "net"
+	url "net/url"
 	reflect "reflect"
 	time "time"
 
 	model "github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	unfurl "github.com/CodeMaster482/ShortLinkAPI/pkg/unfurl"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -36,38 +39,111 @@ func (m *MockLinkRepository) EXPECT() *MockLinkRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ClicksLeft mocks base method.
+func (m *MockLinkRepository) ClicksLeft(ctx context.Context, domain, token string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClicksLeft", ctx, domain, token)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClicksLeft indicates an expected call of ClicksLeft.
+func (mr *MockLinkRepositoryMockRecorder) ClicksLeft(ctx, domain, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClicksLeft", reflect.TypeOf((*MockLinkRepository)(nil).ClicksLeft), ctx, domain, token)
+}
+
+// ConsumeClick mocks base method.
+func (m *MockLinkRepository) ConsumeClick(ctx context.Context, domain, token string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeClick", ctx, domain, token)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeClick indicates an expected call of ConsumeClick.
+func (mr *MockLinkRepositoryMockRecorder) ConsumeClick(ctx, domain, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeClick", reflect.TypeOf((*MockLinkRepository)(nil).ConsumeClick), ctx, domain, token)
+}
+
+// CountClick mocks base method.
+func (m *MockLinkRepository) CountClick(ctx context.Context, domain, token string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountClick", ctx, domain, token)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountClick indicates an expected call of CountClick.
+func (mr *MockLinkRepositoryMockRecorder) CountClick(ctx, domain, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClick", reflect.TypeOf((*MockLinkRepository)(nil).CountClick), ctx, domain, token)
+}
+
+// DeleteLink mocks base method.
+func (m *MockLinkRepository) DeleteLink(ctx context.Context, domain, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLink", ctx, domain, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLink indicates an expected call of DeleteLink.
+func (mr *MockLinkRepositoryMockRecorder) DeleteLink(ctx, domain, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLink", reflect.TypeOf((*MockLinkRepository)(nil).DeleteLink), ctx, domain, token)
+}
+
 // GetLink mocks base method.
-func (m *MockLinkRepository) GetLink(ctx context.Context, token string) (*model.Link, error) {
+func (m *MockLinkRepository) GetLink(ctx context.Context, domain, token string) (*model.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLink", ctx, token)
+	ret := m.ctrl.Call(m, "GetLink", ctx, domain, token)
 	ret0, _ := ret[0].(*model.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLink indicates an expected call of GetLink.
-func (mr *MockLinkRepositoryMockRecorder) GetLink(ctx, token interface{}) *gomock.Call {
+func (mr *MockLinkRepositoryMockRecorder) GetLink(ctx, domain, token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLink", reflect.TypeOf((*MockLinkRepository)(nil).GetLink), ctx, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLink", reflect.TypeOf((*MockLinkRepository)(nil).GetLink), ctx, domain, token)
 }
 
-// GetLinkByOriginal mocks base method.
-func (m *MockLinkRepository) GetLinkByOriginal(ctx context.Context, origLink string) (*model.Link, error) {
+// ListLinksByTag mocks base method.
+func (m *MockLinkRepository) ListLinksByTag(ctx context.Context, tag string, limit int) ([]*model.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLinkByOriginal", ctx, origLink)
-	ret0, _ := ret[0].(*model.Link)
+	ret := m.ctrl.Call(m, "ListLinksByTag", ctx, tag, limit)
+	ret0, _ := ret[0].([]*model.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLinkByOriginal indicates an expected call of GetLinkByOriginal.
-func (mr *MockLinkRepositoryMockRecorder) GetLinkByOriginal(ctx, origLink interface{}) *gomock.Call {
+// ListLinksByTag indicates an expected call of ListLinksByTag.
+func (mr *MockLinkRepositoryMockRecorder) ListLinksByTag(ctx, tag, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLinksByTag", reflect.TypeOf((*MockLinkRepository)(nil).ListLinksByTag), ctx, tag, limit)
+}
+
+// RecordVariant mocks base method.
+func (m *MockLinkRepository) RecordVariant(ctx context.Context, domain, token string, variant int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordVariant", ctx, domain, token, variant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordVariant indicates an expected call of RecordVariant.
+func (mr *MockLinkRepositoryMockRecorder) RecordVariant(ctx, domain, token, variant interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkByOriginal", reflect.TypeOf((*MockLinkRepository)(nil).GetLinkByOriginal), ctx, origLink)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordVariant", reflect.TypeOf((*MockLinkRepository)(nil).RecordVariant), ctx, domain, token, variant)
 }
 
 // StartRecalculation mocks base method.
-func (m *MockLinkRepository) StartRecalculation(interval time.Duration, deleted chan []string) {
+func (m *MockLinkRepository) StartRecalculation(interval time.Duration, deleted chan []*model.Link) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "StartRecalculation", interval, deleted)
 }
@@ -92,6 +168,49 @@ func (mr *MockLinkRepositoryMockRecorder) StoreLink(ctx, link interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreLink", reflect.TypeOf((*MockLinkRepository)(nil).StoreLink), ctx, link)
 }
 
+// StorePage mocks base method.
+func (m *MockLinkRepository) StorePage(ctx context.Context, domain, token string, page model.Page) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorePage", ctx, domain, token, page)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorePage indicates an expected call of StorePage.
+func (mr *MockLinkRepositoryMockRecorder) StorePage(ctx, domain, token, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePage", reflect.TypeOf((*MockLinkRepository)(nil).StorePage), ctx, domain, token, page)
+}
+
+// UpdateMetadata mocks base method.
+func (m *MockLinkRepository) UpdateMetadata(ctx context.Context, link *model.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMetadata", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMetadata indicates an expected call of UpdateMetadata.
+func (mr *MockLinkRepositoryMockRecorder) UpdateMetadata(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockLinkRepository)(nil).UpdateMetadata), ctx, link)
+}
+
+// VariantHits mocks base method.
+func (m *MockLinkRepository) VariantHits(ctx context.Context, domain, token string) (map[int]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VariantHits", ctx, domain, token)
+	ret0, _ := ret[0].(map[int]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VariantHits indicates an expected call of VariantHits.
+func (mr *MockLinkRepositoryMockRecorder) VariantHits(ctx, domain, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariantHits", reflect.TypeOf((*MockLinkRepository)(nil).VariantHits), ctx, domain, token)
+}
+
 // MockGenerator is a mock of Generator interface.
 type MockGenerator struct {
 	ctrl     *gomock.Controller
@@ -128,3 +247,489 @@ func (mr *MockGeneratorMockRecorder) GenerateShortURL(url interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateShortURL", reflect.TypeOf((*MockGenerator)(nil).GenerateShortURL), url)
 }
+
+// MockURLChecker is a mock of URLChecker interface.
+type MockURLChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLCheckerMockRecorder
+}
+
+// MockURLCheckerMockRecorder is the mock recorder for MockURLChecker.
+type MockURLCheckerMockRecorder struct {
+	mock *MockURLChecker
+}
+
+// NewMockURLChecker creates a new mock instance.
+func NewMockURLChecker(ctrl *gomock.Controller) *MockURLChecker {
+	mock := &MockURLChecker{ctrl: ctrl}
+	mock.recorder = &MockURLCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLChecker) EXPECT() *MockURLCheckerMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockURLChecker) Check(ctx context.Context, u *url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockURLCheckerMockRecorder) Check(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockURLChecker)(nil).Check), ctx, u)
+}
+
+// MockCanonicalizer is a mock of Canonicalizer interface.
+type MockCanonicalizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCanonicalizerMockRecorder
+}
+
+// MockCanonicalizerMockRecorder is the mock recorder for MockCanonicalizer.
+type MockCanonicalizerMockRecorder struct {
+	mock *MockCanonicalizer
+}
+
+// NewMockCanonicalizer creates a new mock instance.
+func NewMockCanonicalizer(ctrl *gomock.Controller) *MockCanonicalizer {
+	mock := &MockCanonicalizer{ctrl: ctrl}
+	mock.recorder = &MockCanonicalizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCanonicalizer) EXPECT() *MockCanonicalizerMockRecorder {
+	return m.recorder
+}
+
+// Canonicalize mocks base method.
+func (m *MockCanonicalizer) Canonicalize(u *url.URL) (*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Canonicalize", u)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Canonicalize indicates an expected call of Canonicalize.
+func (mr *MockCanonicalizerMockRecorder) Canonicalize(u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Canonicalize", reflect.TypeOf((*MockCanonicalizer)(nil).Canonicalize), u)
+}
+
+// MockGeoLocator is a mock of GeoLocator interface.
+type MockGeoLocator struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeoLocatorMockRecorder
+}
+
+// MockGeoLocatorMockRecorder is the mock recorder for MockGeoLocator.
+type MockGeoLocatorMockRecorder struct {
+	mock *MockGeoLocator
+}
+
+// NewMockGeoLocator creates a new mock instance.
+func NewMockGeoLocator(ctrl *gomock.Controller) *MockGeoLocator {
+	mock := &MockGeoLocator{ctrl: ctrl}
+	mock.recorder = &MockGeoLocatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeoLocator) EXPECT() *MockGeoLocatorMockRecorder {
+	return m.recorder
+}
+
+// Country mocks base method.
+func (m *MockGeoLocator) Country(ip net.IP) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Country", ip)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Country indicates an expected call of Country.
+func (mr *MockGeoLocatorMockRecorder) Country(ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Country", reflect.TypeOf((*MockGeoLocator)(nil).Country), ip)
+}
+
+// MockAttemptThrottle is a mock of AttemptThrottle interface.
+type MockAttemptThrottle struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttemptThrottleMockRecorder
+}
+
+// MockAttemptThrottleMockRecorder is the mock recorder for MockAttemptThrottle.
+type MockAttemptThrottleMockRecorder struct {
+	mock *MockAttemptThrottle
+}
+
+// NewMockAttemptThrottle creates a new mock instance.
+func NewMockAttemptThrottle(ctrl *gomock.Controller) *MockAttemptThrottle {
+	mock := &MockAttemptThrottle{ctrl: ctrl}
+	mock.recorder = &MockAttemptThrottleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttemptThrottle) EXPECT() *MockAttemptThrottleMockRecorder {
+	return m.recorder
+}
+
+// Blocked mocks base method.
+func (m *MockAttemptThrottle) Blocked(key string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Blocked", key)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Blocked indicates an expected call of Blocked.
+func (mr *MockAttemptThrottleMockRecorder) Blocked(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Blocked", reflect.TypeOf((*MockAttemptThrottle)(nil).Blocked), key)
+}
+
+// Fail mocks base method.
+func (m *MockAttemptThrottle) Fail(key string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Fail", key)
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockAttemptThrottleMockRecorder) Fail(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockAttemptThrottle)(nil).Fail), key)
+}
+
+// Reset mocks base method.
+func (m *MockAttemptThrottle) Reset(key string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Reset", key)
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockAttemptThrottleMockRecorder) Reset(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockAttemptThrottle)(nil).Reset), key)
+}
+
+// MockQRCache is a mock of QRCache interface.
+type MockQRCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockQRCacheMockRecorder
+}
+
+// MockQRCacheMockRecorder is the mock recorder for MockQRCache.
+type MockQRCacheMockRecorder struct {
+	mock *MockQRCache
+}
+
+// NewMockQRCache creates a new mock instance.
+func NewMockQRCache(ctrl *gomock.Controller) *MockQRCache {
+	mock := &MockQRCache{ctrl: ctrl}
+	mock.recorder = &MockQRCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQRCache) EXPECT() *MockQRCacheMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockQRCache) Add(key string, image []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Add", key, image)
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockQRCacheMockRecorder) Add(key, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockQRCache)(nil).Add), key, image)
+}
+
+// Get mocks base method.
+func (m *MockQRCache) Get(key string) ([]byte, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockQRCacheMockRecorder) Get(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockQRCache)(nil).Get), key)
+}
+
+// MockPageFetcher is a mock of PageFetcher interface.
+type MockPageFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPageFetcherMockRecorder
+}
+
+// MockPageFetcherMockRecorder is the mock recorder for MockPageFetcher.
+type MockPageFetcherMockRecorder struct {
+	mock *MockPageFetcher
+}
+
+// NewMockPageFetcher creates a new mock instance.
+func NewMockPageFetcher(ctrl *gomock.Controller) *MockPageFetcher {
+	mock := &MockPageFetcher{ctrl: ctrl}
+	mock.recorder = &MockPageFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPageFetcher) EXPECT() *MockPageFetcherMockRecorder {
+	return m.recorder
+}
+
+// Fetch mocks base method.
+func (m *MockPageFetcher) Fetch(ctx context.Context, rawURL string) (*unfurl.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fetch", ctx, rawURL)
+	ret0, _ := ret[0].(*unfurl.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fetch indicates an expected call of Fetch.
+func (mr *MockPageFetcherMockRecorder) Fetch(ctx, rawURL interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockPageFetcher)(nil).Fetch), ctx, rawURL)
+}
+
+// MockPageQueue is a mock of PageQueue interface.
+type MockPageQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockPageQueueMockRecorder
+}
+
+// MockPageQueueMockRecorder is the mock recorder for MockPageQueue.
+type MockPageQueueMockRecorder struct {
+	mock *MockPageQueue
+}
+
+// NewMockPageQueue creates a new mock instance.
+func NewMockPageQueue(ctrl *gomock.Controller) *MockPageQueue {
+	mock := &MockPageQueue{ctrl: ctrl}
+	mock.recorder = &MockPageQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPageQueue) EXPECT() *MockPageQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockPageQueue) Enqueue(link *model.Link) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", link)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockPageQueueMockRecorder) Enqueue(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockPageQueue)(nil).Enqueue), link)
+}
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDeliveries mocks base method.
+func (m *MockWebhookRepository) ClaimDeliveries(ctx context.Context, now, until time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeliveries", ctx, now, until, limit)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeliveries indicates an expected call of ClaimDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ClaimDeliveries(ctx, now, until, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ClaimDeliveries), ctx, now, until, limit)
+}
+
+// DeleteDelivery mocks base method.
+func (m *MockWebhookRepository) DeleteDelivery(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDelivery", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDelivery indicates an expected call of DeleteDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) DeleteDelivery(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).DeleteDelivery), ctx, id)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) DeleteWebhook(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).DeleteWebhook), ctx, id)
+}
+
+// EnqueueDelivery mocks base method.
+func (m *MockWebhookRepository) EnqueueDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueDelivery indicates an expected call of EnqueueDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) EnqueueDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).EnqueueDelivery), ctx, delivery)
+}
+
+// ListDeadDeliveries mocks base method.
+func (m *MockWebhookRepository) ListDeadDeliveries(ctx context.Context, webhookID string, limit int) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadDeliveries", ctx, webhookID, limit)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeadDeliveries indicates an expected call of ListDeadDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ListDeadDeliveries(ctx, webhookID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ListDeadDeliveries), ctx, webhookID, limit)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockWebhookRepository) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx)
+	ret0, _ := ret[0].([]*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockWebhookRepositoryMockRecorder) ListWebhooks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockWebhookRepository)(nil).ListWebhooks), ctx)
+}
+
+// StoreWebhook mocks base method.
+func (m *MockWebhookRepository) StoreWebhook(ctx context.Context, hook *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreWebhook", ctx, hook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreWebhook indicates an expected call of StoreWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) StoreWebhook(ctx, hook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).StoreWebhook), ctx, hook)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) UpdateDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).UpdateDelivery), ctx, delivery)
+}
+
+// MockWebhookNotifier is a mock of WebhookNotifier interface.
+type MockWebhookNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookNotifierMockRecorder
+}
+
+// MockWebhookNotifierMockRecorder is the mock recorder for MockWebhookNotifier.
+type MockWebhookNotifierMockRecorder struct {
+	mock *MockWebhookNotifier
+}
+
+// NewMockWebhookNotifier creates a new mock instance.
+func NewMockWebhookNotifier(ctrl *gomock.Controller) *MockWebhookNotifier {
+	mock := &MockWebhookNotifier{ctrl: ctrl}
+	mock.recorder = &MockWebhookNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookNotifier) EXPECT() *MockWebhookNotifierMockRecorder {
+	return m.recorder
+}
+
+// CountsClicks mocks base method.
+func (m *MockWebhookNotifier) CountsClicks() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountsClicks")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CountsClicks indicates an expected call of CountsClicks.
+func (mr *MockWebhookNotifierMockRecorder) CountsClicks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountsClicks", reflect.TypeOf((*MockWebhookNotifier)(nil).CountsClicks))
+}
+
+// Notify mocks base method.
+func (m *MockWebhookNotifier) Notify(payload *model.WebhookPayload) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Notify", payload)
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockWebhookNotifierMockRecorder) Notify(payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockWebhookNotifier)(nil).Notify), payload)
+}
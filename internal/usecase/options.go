@@ -0,0 +1,60 @@
+package usecase
+
+// Option -.
+type Option func(*LinkService)
+
+// WithURLChecker -.
+func WithURLChecker(checker URLChecker) Option {
+	return func(s *LinkService) {
+		s.checker = checker
+	}
+}
+
+// WithCanonicalizer -.
+func WithCanonicalizer(canonicalizer Canonicalizer) Option {
+	return func(s *LinkService) {
+		s.canonicalizer = canonicalizer
+	}
+}
+
+// WithAttemptThrottle -.
+func WithAttemptThrottle(attempts AttemptThrottle) Option {
+	return func(s *LinkService) {
+		s.attempts = attempts
+	}
+}
+
+// WithGeoLocator -.
+func WithGeoLocator(geo GeoLocator) Option {
+	return func(s *LinkService) {
+		s.geo = geo
+	}
+}
+
+// WithQRCache -.
+func WithQRCache(cache QRCache) Option {
+	return func(s *LinkService) {
+		s.qrCodes = cache
+	}
+}
+
+// WithPageQueue -.
+func WithPageQueue(pages PageQueue) Option {
+	return func(s *LinkService) {
+		s.pages = pages
+	}
+}
+
+// WithEventBus -.
+func WithEventBus(events EventBus) Option {
+	return func(s *LinkService) {
+		s.events = events
+	}
+}
+
+// WithWebhooks -.
+func WithWebhooks(webhooks WebhookNotifier) Option {
+	return func(s *LinkService) {
+		s.webhooks = webhooks
+	}
+}
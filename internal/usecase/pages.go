@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+)
+
+type pageJob struct {
+	domain string
+	token  string
+	target string
+}
+
+// PagePool fetches the destination pages of new links in the background
+// and stores what they advertise with the link. Fetching is best effort:
+// links are dropped when the queue is full and failures are not retried.
+type PagePool struct {
+	fetcher    PageFetcher
+	repository LinkRepository
+	timeout    time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	jobs   chan pageJob
+	wg     sync.WaitGroup
+}
+
+// NewPagePool starts workers fetching pages of up to queueSize queued links.
+func NewPagePool(fetcher PageFetcher, repo LinkRepository, workers, queueSize int, timeout time.Duration) *PagePool {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	pool := &PagePool{
+		fetcher:    fetcher,
+		repository: repo,
+		timeout:    timeout,
+		jobs:       make(chan pageJob, queueSize),
+	}
+
+	pool.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go pool.work()
+	}
+
+	return pool
+}
+
+// Enqueue queues the destination of link and reports whether there was room for it.
+func (p *PagePool) Enqueue(link *model.Link) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return false
+	}
+
+	select {
+	case p.jobs <- pageJob{domain: link.Domain, token: link.Token, target: link.OriginalLink}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close stops taking links and waits for the queued ones to be fetched.
+func (p *PagePool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+func (p *PagePool) work() {
+	defer p.wg.Done()
+
+	for job := range p.jobs {
+		p.fetch(job)
+	}
+}
+
+func (p *PagePool) fetch(job pageJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	page, err := p.fetcher.Fetch(ctx, job.target)
+	if err != nil {
+		return
+	}
+
+	_ = p.repository.StorePage(ctx, job.domain, job.token, model.Page{
+		Title:       page.Title,
+		Description: page.Description,
+		Image:       page.Image,
+		Favicon:     page.Favicon,
+	})
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	mock_usecase "github.com/CodeMaster482/ShortLinkAPI/internal/usecase/mocks"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/unfurl"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagePool(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprintf(w, `<head><title>Page %s</title><meta property="og:image" content="/card.png"></head>`, r.URL.Path)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().StorePage(gomock.Any(), "", "first_____", model.Page{
+		Title:   "Page /first",
+		Image:   server.URL + "/card.png",
+		Favicon: server.URL + "/favicon.ico",
+	}).Return(nil)
+	mockRepo.EXPECT().StorePage(gomock.Any(), "go.example.com", "second____", gomock.Any()).Return(apierror.ErrLinkNotFound)
+
+	pool := NewPagePool(unfurl.New(unfurl.AllowPrivate()), mockRepo, 2, 4, time.Second)
+
+	require.True(t, pool.Enqueue(&model.Link{Token: "first_____", OriginalLink: server.URL + "/first"}))
+	require.True(t, pool.Enqueue(&model.Link{Token: "second____", Domain: "go.example.com", OriginalLink: server.URL + "/second"}))
+
+	pool.Close()
+
+	require.False(t, pool.Enqueue(&model.Link{Token: "late______", OriginalLink: server.URL}), "closed pools take no links")
+}
+
+func TestPagePool_Full(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pool := NewPagePool(unfurl.New(unfurl.AllowPrivate()), mock_usecase.NewMockLinkRepository(ctrl), 1, 1, time.Second)
+
+	link := &model.Link{Token: "busy______", OriginalLink: server.URL}
+
+	// One link keeps the worker busy, one waits in the queue, the next is dropped.
+	require.True(t, pool.Enqueue(link))
+	require.Eventually(t, func() bool { return pool.Enqueue(link) }, time.Second, 10*time.Millisecond)
+	require.False(t, pool.Enqueue(link))
+
+	close(release)
+	pool.Close()
+}
+
+func TestLinkService_CreateShortLink_Page(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockGen := mock_usecase.NewMockGenerator(ctrl)
+	mockPages := mock_usecase.NewMockPageQueue(ctrl)
+
+	mockGen.EXPECT().GenerateShortURL(gomock.Any()).Return("token_____").Times(2)
+	gomock.InOrder(
+		mockRepo.EXPECT().GetLink(gomock.Any(), "", "token_____").Return(nil, apierror.ErrLinkNotFound),
+		mockRepo.EXPECT().StoreLink(gomock.Any(), gomock.Any()).Return(nil),
+		mockPages.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(link *model.Link) bool {
+			require.Equal(t, "https://example.com/landing", link.OriginalLink)
+			return true
+		}),
+		// Links handed out again are not fetched twice.
+		mockRepo.EXPECT().GetLink(gomock.Any(), "", "token_____").Return(&model.Link{Token: "token_____"}, nil),
+	)
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		generator:       mockGen,
+		shortlinkPrefix: prefix,
+		pages:           mockPages,
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{Link: "https://example.com/landing"})
+		require.NoError(t, err)
+	}
+}
+
+func TestLinkService_CreateShortLink_ProtectedPage(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockGen := mock_usecase.NewMockGenerator(ctrl)
+	mockPages := mock_usecase.NewMockPageQueue(ctrl)
+
+	mockGen.EXPECT().GenerateShortURL(gomock.Any()).Return("token_____")
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "token_____").Return(nil, apierror.ErrLinkNotFound)
+	mockRepo.EXPECT().StoreLink(gomock.Any(), gomock.Any()).Return(nil)
+	mockPages.EXPECT().Enqueue(gomock.Any()).Times(0)
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		generator:       mockGen,
+		shortlinkPrefix: prefix,
+		pages:           mockPages,
+	}
+
+	_, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{Link: "https://example.com/landing", Password: "secret-password"})
+	require.NoError(t, err)
+}
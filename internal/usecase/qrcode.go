@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image/color"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/qr"
+)
+
+const (
+	defaultQRSize   = 256
+	minQRSize       = 64
+	maxQRSize       = 2048
+	defaultQRMargin = 4
+	maxQRMargin     = 16
+	defaultQRFront  = "#000000"
+	defaultQRBack   = "#ffffff"
+)
+
+// GetQRCode renders the short link of token as a QR code image. Rendered
+// images are cached per link and render parameters.
+func (service *LinkService) GetQRCode(ctx context.Context, domain, token string, request *dto.QRCodeRequest) (*model.QRCode, error) {
+	opts, err := qrOptions(request)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := service.lookup(ctx, service.domain(domain), token)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s/%s|%s|%d|%s|%d|%x|%x", link.Domain, link.Token,
+		opts.Format, opts.Size, opts.Level, opts.Margin, opts.Foreground, opts.Background)
+
+	if service.qrCodes != nil {
+		if image, ok := service.qrCodes.Get(key); ok {
+			return &model.QRCode{Image: image, ContentType: opts.Format.ContentType()}, nil
+		}
+	}
+
+	image, err := qr.Encode(service.shortLink(link), opts)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	if service.qrCodes != nil {
+		service.qrCodes.Add(key, image)
+	}
+
+	return &model.QRCode{Image: image, ContentType: opts.Format.ContentType()}, nil
+}
+
+// qrOptions checks the render parameters of request and fills in the defaults.
+func qrOptions(request *dto.QRCodeRequest) (qr.Options, error) {
+	opts := qr.Options{
+		Format: qr.PNG,
+		Size:   defaultQRSize,
+		Level:  qr.M,
+		Margin: defaultQRMargin,
+	}
+
+	if request.Format != "" {
+		opts.Format = qr.Format(request.Format)
+	}
+
+	if request.Size != 0 {
+		opts.Size = request.Size
+	}
+
+	if request.ECC != "" {
+		opts.Level = qr.Level(request.ECC)
+	}
+
+	if request.Margin != nil {
+		opts.Margin = *request.Margin
+	}
+
+	switch {
+	case !opts.Format.Valid():
+		return opts, apierror.InvalidFieldError("format", fmt.Errorf("unknown format %q", request.Format))
+	case opts.Size < minQRSize || opts.Size > maxQRSize:
+		return opts, apierror.InvalidFieldError("size", fmt.Errorf("size must be between %d and %d", minQRSize, maxQRSize))
+	case !opts.Level.Valid():
+		return opts, apierror.InvalidFieldError("ecc", errors.New("ecc must be one of L, M, Q or H"))
+	case opts.Margin < 0 || opts.Margin > maxQRMargin:
+		return opts, apierror.InvalidFieldError("margin", fmt.Errorf("margin must be between 0 and %d", maxQRMargin))
+	}
+
+	var err error
+
+	if opts.Foreground, err = qrColor("fg", request.Foreground, defaultQRFront); err != nil {
+		return opts, err
+	}
+
+	if opts.Background, err = qrColor("bg", request.Background, defaultQRBack); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+// qrColor parses the colour given as field, or fallback if none was given.
+func qrColor(field, value, fallback string) (color.RGBA, error) {
+	if value == "" {
+		value = fallback
+	}
+
+	c, err := qr.ParseColor(value)
+	if err != nil {
+		return c, apierror.InvalidFieldError(field, err)
+	}
+
+	return c, nil
+}
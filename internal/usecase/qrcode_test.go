@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	mock_usecase "github.com/CodeMaster482/ShortLinkAPI/internal/usecase/mocks"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/qr"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkService_GetQRCode(t *testing.T) {
+	t.Parallel()
+
+	link := &model.Link{OriginalLink: "https://example.com", Token: "token_____"}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "token_____").Return(link, nil).Times(3)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "missing___").Return(nil, apierror.NotFoundError())
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		shortlinkPrefix: prefix,
+		qrCodes:         qr.NewCache(8),
+	}
+
+	code, err := usecase.GetQRCode(context.TODO(), "", "token_____", &dto.QRCodeRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "image/png", code.ContentType)
+
+	img, err := png.Decode(bytes.NewReader(code.Image))
+	require.NoError(t, err)
+	require.Equal(t, defaultQRSize, img.Bounds().Dx())
+
+	cached, err := usecase.GetQRCode(context.TODO(), "", "token_____", &dto.QRCodeRequest{})
+	require.NoError(t, err)
+	require.Equal(t, code.Image, cached.Image)
+
+	svg, err := usecase.GetQRCode(context.TODO(), "", "token_____", &dto.QRCodeRequest{Format: "svg", ECC: "H"})
+	require.NoError(t, err)
+	require.Equal(t, "image/svg+xml", svg.ContentType)
+	require.NotEqual(t, code.Image, svg.Image)
+
+	_, err = usecase.GetQRCode(context.TODO(), "", "missing___", &dto.QRCodeRequest{})
+	require.ErrorIs(t, err, apierror.ErrLinkNotFound)
+}
+
+func TestQROptions(t *testing.T) {
+	t.Parallel()
+
+	negative := -1
+
+	testCases := []struct {
+		name    string
+		request dto.QRCodeRequest
+		field   string
+	}{
+		{name: "Unknown format", request: dto.QRCodeRequest{Format: "gif"}, field: "format"},
+		{name: "Too small", request: dto.QRCodeRequest{Size: 16}, field: "size"},
+		{name: "Too large", request: dto.QRCodeRequest{Size: 4096}, field: "size"},
+		{name: "Unknown ecc", request: dto.QRCodeRequest{ECC: "X"}, field: "ecc"},
+		{name: "Negative margin", request: dto.QRCodeRequest{Margin: &negative}, field: "margin"},
+		{name: "Invalid foreground", request: dto.QRCodeRequest{Foreground: "red"}, field: "fg"},
+		{name: "Invalid background", request: dto.QRCodeRequest{Background: "#12345"}, field: "bg"},
+	}
+
+	for _, tc := range testCases {
+		_, err := qrOptions(&tc.request)
+		require.ErrorIs(t, err, apierror.ErrBadRequest, tc.name)
+
+		fieldErrs := apierror.FieldErrorsOf(err)
+		require.Len(t, fieldErrs, 1, tc.name)
+		require.Equal(t, tc.field, fieldErrs[0].Field, tc.name)
+	}
+}
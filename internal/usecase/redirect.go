@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+)
+
+const maxInterstitialDelay = 30
+
+// validRedirect checks the redirect settings of a link request.
+func validRedirect(r *dto.CreateLinkRequest) error {
+	switch r.RedirectCode {
+	case 0, http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return apierror.InvalidFieldError("redirect_code", fmt.Errorf("unsupported redirect code %d", r.RedirectCode))
+	}
+
+	if r.Interstitial && r.RedirectCode != 0 {
+		return apierror.InvalidFieldError("redirect_code", fmt.Errorf("interstitial links have no redirect code"))
+	}
+
+	if r.InterstitialDelay < 0 || r.InterstitialDelay > maxInterstitialDelay {
+		return apierror.InvalidFieldError("interstitial_delay", fmt.Errorf("interstitial delay must be between 0 and %d seconds", maxInterstitialDelay))
+	}
+
+	if r.InterstitialDelay > 0 && !r.Interstitial {
+		return apierror.InvalidFieldError("interstitial_delay", fmt.Errorf("delay without interstitial"))
+	}
+
+	return nil
+}
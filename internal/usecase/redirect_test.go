@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	mock_usecase "github.com/CodeMaster482/ShortLinkAPI/internal/usecase/mocks"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkService_GetFullLink_Redirect(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		link     *model.Link
+		expected *model.Redirect
+	}{
+		{
+			name: "Default",
+			link: &model.Link{OriginalLink: "https://example.com", ExpiresAt: expiresAt},
+			expected: &model.Redirect{
+				Target:    "https://example.com",
+				Code:      http.StatusFound,
+				ExpiresAt: expiresAt,
+				Cacheable: true,
+			},
+		},
+		{
+			name: "Permanent",
+			link: &model.Link{OriginalLink: "https://example.com", ExpiresAt: expiresAt, RedirectCode: http.StatusPermanentRedirect},
+			expected: &model.Redirect{
+				Target:    "https://example.com",
+				Code:      http.StatusPermanentRedirect,
+				ExpiresAt: expiresAt,
+				Cacheable: true,
+			},
+		},
+		{
+			name: "Interstitial",
+			link: &model.Link{OriginalLink: "https://example.com", ExpiresAt: expiresAt, Interstitial: true, InterstitialDelay: 5},
+			expected: &model.Redirect{
+				Target:       "https://example.com",
+				Code:         http.StatusFound,
+				Interstitial: true,
+				Delay:        5,
+				ExpiresAt:    expiresAt,
+				Cacheable:    true,
+			},
+		},
+		{
+			name: "Rules are not cacheable",
+			link: &model.Link{
+				OriginalLink: "https://example.com",
+				ExpiresAt:    expiresAt,
+				RedirectCode: http.StatusMovedPermanently,
+				Rules:        []model.Rule{{OS: "ios", Target: "https://apps.apple.com/app/id1"}},
+			},
+			expected: &model.Redirect{
+				Target:    "https://example.com",
+				Code:      http.StatusMovedPermanently,
+				ExpiresAt: expiresAt,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+			mockRepo.EXPECT().GetLink(gomock.Any(), "", "token").Return(test.link, nil)
+
+			usecase := LinkService{
+				repository: mockRepo,
+			}
+
+			redirect, err := usecase.GetFullLink(context.TODO(), "", "token", nil)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, redirect)
+		})
+	}
+}
+
+func TestValidRedirect(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		request dto.CreateLinkRequest
+		valid   bool
+	}{
+		{name: "Default", valid: true},
+		{name: "Moved permanently", request: dto.CreateLinkRequest{RedirectCode: http.StatusMovedPermanently}, valid: true},
+		{name: "Temporary redirect", request: dto.CreateLinkRequest{RedirectCode: http.StatusTemporaryRedirect}, valid: true},
+		{name: "Interstitial with delay", request: dto.CreateLinkRequest{Interstitial: true, InterstitialDelay: 3}, valid: true},
+		{name: "See other", request: dto.CreateLinkRequest{RedirectCode: http.StatusSeeOther}},
+		{name: "Interstitial with code", request: dto.CreateLinkRequest{Interstitial: true, RedirectCode: http.StatusFound}},
+		{name: "Delay without interstitial", request: dto.CreateLinkRequest{InterstitialDelay: 3}},
+		{name: "Delay too long", request: dto.CreateLinkRequest{Interstitial: true, InterstitialDelay: 31}},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validRedirect(&test.request)
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, apierror.ErrBadRequest)
+			}
+		})
+	}
+}
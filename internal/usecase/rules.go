@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/useragent"
+)
+
+const maxRules = 32
+
+// visitor holds the attributes of a visit that rules match on.
+type visitor struct {
+	agent    useragent.Agent
+	language string
+	country  string
+	located  bool
+}
+
+// target returns the destination of the first rule matching visit. Visits no rule
+// matches are split between the link's variants, if any, or go to the original link.
+func (service *LinkService) target(ctx context.Context, link *model.Link, visit *model.Visit) string {
+	if len(link.Rules) == 0 || visit == nil {
+		return service.split(ctx, link, visit)
+	}
+
+	v := &visitor{
+		agent:    useragent.Parse(visit.UserAgent),
+		language: preferredLanguage(visit.AcceptLanguage),
+	}
+
+	for i := range link.Rules {
+		rule := &link.Rules[i]
+
+		// The GeoIP lookup is only worth doing once a rule asks for the country.
+		if rule.Country != "" && !v.located {
+			v.country = service.country(visit)
+			v.located = true
+		}
+
+		if matches(rule, v, visit) {
+			return rule.Target
+		}
+	}
+
+	return service.split(ctx, link, visit)
+}
+
+func (service *LinkService) country(visit *model.Visit) string {
+	if service.geo == nil || visit.IP == nil {
+		return ""
+	}
+
+	country, err := service.geo.Country(visit.IP)
+	if err != nil {
+		return ""
+	}
+
+	return country
+}
+
+func matches(rule *model.Rule, v *visitor, visit *model.Visit) bool {
+	if rule.Browser != "" && rule.Browser != v.agent.Browser {
+		return false
+	}
+
+	if rule.OS != "" && rule.OS != v.agent.OS {
+		return false
+	}
+
+	if rule.Language != "" && rule.Language != v.language && !strings.HasPrefix(v.language, rule.Language+"-") {
+		return false
+	}
+
+	if rule.Country != "" && rule.Country != v.country {
+		return false
+	}
+
+	for key, value := range rule.Query {
+		if !visit.Query.Has(key) || (value != "" && visit.Query.Get(key) != value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// preferredLanguage returns the lowercased tag with the highest weight in an Accept-Language header.
+func preferredLanguage(header string) string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+
+			q = parsed
+		}
+
+		if q > 0 {
+			tags = append(tags, weighted{tag: tag, q: q})
+		}
+	}
+
+	if len(tags) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	return tags[0].tag
+}
+
+// rules validates redirect rules and normalizes them for matching.
+func (service *LinkService) rules(ctx context.Context, rules []model.Rule) ([]model.Rule, error) {
+	if len(rules) > maxRules {
+		return nil, apierror.InvalidFieldError("rules", fmt.Errorf("more than %d rules", maxRules))
+	}
+
+	normalized := make([]model.Rule, 0, len(rules))
+
+	for i, rule := range rules {
+		if rule.Empty() {
+			return nil, apierror.InvalidFieldError("rules", fmt.Errorf("rule %d has no conditions", i))
+		}
+
+		if _, err := service.destination(ctx, rule.Target); err != nil {
+			return nil, err
+		}
+
+		rule.Browser = strings.ToLower(strings.TrimSpace(rule.Browser))
+		rule.OS = strings.ToLower(strings.TrimSpace(rule.OS))
+		rule.Language = strings.ToLower(strings.TrimSpace(rule.Language))
+		rule.Country = strings.ToUpper(strings.TrimSpace(rule.Country))
+
+		normalized = append(normalized, rule)
+	}
+
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+
+	return normalized, nil
+}
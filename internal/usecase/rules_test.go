@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	mock_usecase "github.com/CodeMaster482/ShortLinkAPI/internal/usecase/mocks"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	iPhone  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1"
+	android = "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Mobile Safari/537.36"
+	desktop = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36"
+)
+
+func TestLinkService_GetFullLink_Rules(t *testing.T) {
+	t.Parallel()
+
+	link := &model.Link{
+		OriginalLink: "https://example.com/app",
+		Token:        "app_______",
+		Rules: []model.Rule{
+			{OS: "ios", Target: "https://apps.apple.com/app/id1"},
+			{OS: "android", Target: "https://play.google.com/store/apps/details?id=com.example"},
+			{Query: map[string]string{"ref": "newsletter"}, Target: "https://example.com/welcome"},
+			{Language: "de", Target: "https://example.com/de/app"},
+			{Country: "FR", Target: "https://example.com/fr/app"},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		visit    *model.Visit
+		expected string
+	}{
+		{
+			name:     "iOS",
+			visit:    &model.Visit{UserAgent: iPhone},
+			expected: "https://apps.apple.com/app/id1",
+		},
+		{
+			name:     "Android",
+			visit:    &model.Visit{UserAgent: android, AcceptLanguage: "de-DE"},
+			expected: "https://play.google.com/store/apps/details?id=com.example",
+		},
+		{
+			name:     "Query",
+			visit:    &model.Visit{UserAgent: desktop, Query: url.Values{"ref": {"newsletter"}}},
+			expected: "https://example.com/welcome",
+		},
+		{
+			name:     "Preferred language",
+			visit:    &model.Visit{UserAgent: desktop, AcceptLanguage: "en;q=0.5, de-AT;q=0.9"},
+			expected: "https://example.com/de/app",
+		},
+		{
+			name:     "Country",
+			visit:    &model.Visit{UserAgent: desktop, IP: net.ParseIP("192.0.2.1")},
+			expected: "https://example.com/fr/app",
+		},
+		{
+			name:     "No match",
+			visit:    &model.Visit{UserAgent: desktop, AcceptLanguage: "en-US", IP: net.ParseIP("198.51.100.1")},
+			expected: link.OriginalLink,
+		},
+		{
+			name:     "No visit",
+			expected: link.OriginalLink,
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+			mockRepo.EXPECT().GetLink(gomock.Any(), "", link.Token).Return(link, nil)
+
+			mockGeo := mock_usecase.NewMockGeoLocator(ctrl)
+			mockGeo.EXPECT().Country(net.ParseIP("192.0.2.1")).Return("FR", nil).AnyTimes()
+			mockGeo.EXPECT().Country(net.ParseIP("198.51.100.1")).Return("", errors.New("not found")).AnyTimes()
+
+			usecase := LinkService{
+				repository: mockRepo,
+				geo:        mockGeo,
+			}
+
+			redirect, err := usecase.GetFullLink(context.TODO(), "", link.Token, test.visit)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, redirect.Target)
+		})
+	}
+}
+
+func TestLinkService_CreateShortLink_Rules(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockGen := mock_usecase.NewMockGenerator(ctrl)
+
+	var stored *model.Link
+	mockGen.EXPECT().GenerateShortURL(gomock.Any()).Return("app_______")
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "app_______").Return(nil, apierror.ErrLinkNotFound)
+	mockRepo.EXPECT().StoreLink(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, link *model.Link) error {
+		stored = link
+		return nil
+	})
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		generator:       mockGen,
+		shortlinkPrefix: prefix,
+	}
+
+	_, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:  "https://example.com/app",
+		Rules: []model.Rule{{OS: " iOS ", Country: "fr", Target: "https://apps.apple.com/fr/app/id1"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []model.Rule{{OS: "ios", Country: "FR", Target: "https://apps.apple.com/fr/app/id1"}}, stored.Rules)
+
+	_, err = usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:  "https://example.com/app",
+		Rules: []model.Rule{{Target: "https://example.com/everyone"}},
+	})
+	require.ErrorIs(t, err, apierror.ErrBadRequest)
+
+	_, err = usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:  "https://example.com/app",
+		Rules: []model.Rule{{OS: "ios", Target: "apps"}},
+	})
+	require.ErrorIs(t, err, apierror.ErrURLNotValid)
+}
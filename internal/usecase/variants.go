@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+)
+
+const (
+	maxVariants = 16
+	// maxVariantWeight keeps the sum of the weights of a split far from overflowing.
+	maxVariantWeight = 10000
+)
+
+// split picks the variant visit is served and records the hit.
+func (service *LinkService) split(ctx context.Context, link *model.Link, visit *model.Visit) string {
+	if !link.Split() {
+		return link.OriginalLink
+	}
+
+	i := chooseVariant(link.Token, link.Variants, visitorKey(visit))
+
+	// A lost hit must not cost the visitor the redirect.
+	_ = service.repository.RecordVariant(ctx, link.Domain, link.Token, i)
+
+	return link.Variants[i].Target
+}
+
+// variantHits fills in the visits served each variant of a split link.
+func (service *LinkService) variantHits(ctx context.Context, link *model.Link) error {
+	if !link.Split() {
+		return nil
+	}
+
+	hits, err := service.repository.VariantHits(ctx, link.Domain, link.Token)
+	if err != nil {
+		return err
+	}
+
+	for i := range link.Variants {
+		link.Variants[i].Hits = hits[i]
+	}
+
+	return nil
+}
+
+// visitorKey identifies the visitor for sticky variant assignment.
+func visitorKey(visit *model.Visit) string {
+	switch {
+	case visit == nil:
+		return ""
+	case visit.VisitorID != "":
+		return visit.VisitorID
+	case visit.IP != nil:
+		return visit.IP.String()
+	default:
+		return ""
+	}
+}
+
+// chooseVariant maps key onto the cumulative weights of variants. The token is
+// part of the hash so a visitor does not land on the same index in every test.
+// Anonymous visits are assigned at random.
+func chooseVariant(token string, variants []model.Variant, key string) int {
+	var total int
+	for _, v := range variants {
+		total += v.Weight
+	}
+
+	// Links stored before weights were capped may not add up.
+	if total <= 0 {
+		return 0
+	}
+
+	var point int
+	if key == "" {
+		point = rand.Intn(total)
+	} else {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(token))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(key))
+		point = int(h.Sum64() % uint64(total))
+	}
+
+	for i, v := range variants {
+		if point < v.Weight {
+			return i
+		}
+
+		point -= v.Weight
+	}
+
+	return len(variants) - 1
+}
+
+// variants validates weighted destinations of a link.
+func (service *LinkService) variants(ctx context.Context, variants []model.Variant) ([]model.Variant, error) {
+	if len(variants) == 0 {
+		return nil, nil
+	}
+
+	if len(variants) < 2 || len(variants) > maxVariants {
+		return nil, apierror.InvalidFieldError("variants", fmt.Errorf("a split needs between 2 and %d variants", maxVariants))
+	}
+
+	for i, v := range variants {
+		if v.Weight <= 0 || v.Weight > maxVariantWeight {
+			return nil, apierror.InvalidFieldError("variants", fmt.Errorf("variant %d must weigh between 1 and %d", i, maxVariantWeight))
+		}
+
+		if _, err := service.destination(ctx, v.Target); err != nil {
+			return nil, err
+		}
+	}
+
+	return variants, nil
+}
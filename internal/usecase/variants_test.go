@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	mock_usecase "github.com/CodeMaster482/ShortLinkAPI/internal/usecase/mocks"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChooseVariant(t *testing.T) {
+	t.Parallel()
+
+	variants := []model.Variant{
+		{Target: "https://example.com/a", Weight: 3},
+		{Target: "https://example.com/b", Weight: 1},
+	}
+
+	counts := make([]int, len(variants))
+
+	for i := 0; i < 4000; i++ {
+		key := fmt.Sprintf("visitor-%d", i)
+
+		chosen := chooseVariant("token", variants, key)
+		require.Equal(t, chosen, chooseVariant("token", variants, key), "assignment must be sticky")
+
+		counts[chosen]++
+	}
+
+	// 3:1 split within a few percent.
+	require.InDelta(t, 3000, counts[0], 200)
+	require.InDelta(t, 1000, counts[1], 200)
+}
+
+func TestChooseVariant_HugeWeights(t *testing.T) {
+	t.Parallel()
+
+	// Weights stored before they were capped overflow their sum.
+	variants := []model.Variant{
+		{Target: "https://example.com/a", Weight: math.MaxInt64},
+		{Target: "https://example.com/b", Weight: math.MaxInt64},
+	}
+
+	require.NotPanics(t, func() {
+		chooseVariant("token", variants, "")
+		chooseVariant("token", variants, "visitor")
+	})
+}
+
+func TestLinkService_GetFullLink_Variants(t *testing.T) {
+	t.Parallel()
+
+	link := &model.Link{
+		OriginalLink: "https://example.com/landing",
+		Token:        "split_____",
+		Variants: []model.Variant{
+			{Target: "https://example.com/a", Weight: 1},
+			{Target: "https://example.com/b", Weight: 1},
+		},
+	}
+
+	visit := &model.Visit{VisitorID: "visitor", IP: net.ParseIP("192.0.2.1")}
+	expected := chooseVariant(link.Token, link.Variants, visit.VisitorID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", link.Token).Return(link, nil).Times(2)
+	mockRepo.EXPECT().RecordVariant(gomock.Any(), "", link.Token, expected).Return(nil)
+	mockRepo.EXPECT().RecordVariant(gomock.Any(), "", link.Token, expected).Return(errors.New("redis down"))
+
+	usecase := LinkService{
+		repository: mockRepo,
+	}
+
+	// A failed hit counter does not break the redirect.
+	for i := 0; i < 2; i++ {
+		redirect, err := usecase.GetFullLink(context.TODO(), "", link.Token, visit)
+		require.NoError(t, err)
+		require.Equal(t, link.Variants[expected].Target, redirect.Target)
+		require.True(t, redirect.Sticky)
+	}
+}
+
+func TestLinkService_CreateShortLink_Variants(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockGen := mock_usecase.NewMockGenerator(ctrl)
+
+	var stored *model.Link
+	mockGen.EXPECT().GenerateShortURL(gomock.Any()).Return("split_____")
+	mockRepo.EXPECT().GetLink(gomock.Any(), "", "split_____").Return(nil, apierror.ErrLinkNotFound)
+	mockRepo.EXPECT().StoreLink(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, link *model.Link) error {
+		stored = link
+		return nil
+	})
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		generator:       mockGen,
+		shortlinkPrefix: prefix,
+	}
+
+	variants := []model.Variant{
+		{Target: "https://example.com/a", Weight: 70},
+		{Target: "https://example.com/b", Weight: 30},
+	}
+
+	_, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+		Link:     "https://example.com/landing",
+		Variants: variants,
+	})
+	require.NoError(t, err)
+	require.Equal(t, variants, stored.Variants)
+
+	testCases := []struct {
+		name     string
+		variants []model.Variant
+		expected error
+	}{
+		{
+			name:     "Single variant",
+			variants: variants[:1],
+			expected: apierror.ErrBadRequest,
+		},
+		{
+			name:     "Zero weight",
+			variants: []model.Variant{variants[0], {Target: "https://example.com/b"}},
+			expected: apierror.ErrBadRequest,
+		},
+		{
+			name:     "Huge weights",
+			variants: []model.Variant{{Target: "https://example.com/a", Weight: math.MaxInt64}, {Target: "https://example.com/b", Weight: math.MaxInt64}},
+			expected: apierror.ErrBadRequest,
+		},
+		{
+			name:     "Weight above the cap",
+			variants: []model.Variant{variants[0], {Target: "https://example.com/b", Weight: maxVariantWeight + 1}},
+			expected: apierror.ErrBadRequest,
+		},
+		{
+			name:     "Invalid target",
+			variants: []model.Variant{variants[0], {Target: "b", Weight: 1}},
+			expected: apierror.ErrURLNotValid,
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := usecase.CreateShortLink(context.TODO(), &dto.CreateLinkRequest{
+			Link:     "https://example.com/landing",
+			Variants: tc.variants,
+		})
+		require.ErrorIs(t, err, tc.expected, tc.name)
+	}
+}
@@ -0,0 +1,652 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/urlcheck"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/webhook"
+)
+
+const (
+	_defaultWebhookAttempts   = 8
+	_defaultWebhookBackoff    = 30 * time.Second
+	_defaultWebhookMaxBackoff = 6 * time.Hour
+	_defaultWebhookTimeout    = 10 * time.Second
+	_defaultWebhookPoll       = 5 * time.Second
+	_defaultWebhookBatchSize  = 32
+
+	// webhookRefresh is how long the list of webhooks is cached, so webhooks
+	// registered on other instances are notified after at most this long.
+	webhookRefresh = 30 * time.Second
+
+	webhookUserAgent      = "ShortLinkAPI-Webhook/1.0"
+	minWebhookSecret      = 16
+	maxClickThresholds    = 16
+	maxDeadDeliveries     = 100
+	maxWebhookResponseLen = 64 << 10
+)
+
+// webhookEvents are the webhook events of link events.
+var webhookEvents = map[model.LinkEventType]model.WebhookEvent{
+	model.LinkCreated: model.WebhookLinkCreated,
+	model.LinkUpdated: model.WebhookLinkUpdated,
+	model.LinkExpired: model.WebhookLinkExpired,
+	model.LinkDeleted: model.WebhookLinkDeleted,
+}
+
+// WebhookOption -.
+type WebhookOption func(*WebhookService)
+
+// WebhookAttempts -.
+func WebhookAttempts(attempts int) WebhookOption {
+	return func(s *WebhookService) {
+		if attempts > 0 {
+			s.attempts = attempts
+		}
+	}
+}
+
+// WebhookBackoff -.
+func WebhookBackoff(backoff, maxBackoff time.Duration) WebhookOption {
+	return func(s *WebhookService) {
+		if backoff > 0 {
+			s.backoff = backoff
+		}
+
+		if maxBackoff > 0 {
+			s.maxBackoff = maxBackoff
+		}
+	}
+}
+
+// WebhookTimeout -.
+func WebhookTimeout(timeout time.Duration) WebhookOption {
+	return func(s *WebhookService) {
+		if timeout > 0 {
+			s.timeout = timeout
+		}
+	}
+}
+
+// WebhookPollInterval -.
+func WebhookPollInterval(interval time.Duration) WebhookOption {
+	return func(s *WebhookService) {
+		if interval > 0 {
+			s.pollInterval = interval
+		}
+	}
+}
+
+// WebhookBatchSize -.
+func WebhookBatchSize(size int) WebhookOption {
+	return func(s *WebhookService) {
+		if size > 0 {
+			s.batchSize = size
+		}
+	}
+}
+
+// WebhookURLChecker -.
+func WebhookURLChecker(checker URLChecker) WebhookOption {
+	return func(s *WebhookService) {
+		s.checker = checker
+	}
+}
+
+// WebhookAllowPrivate lets webhooks be sent to private addresses. Tests only.
+func WebhookAllowPrivate() WebhookOption {
+	return func(s *WebhookService) {
+		s.allowPrivate = true
+	}
+}
+
+// WebhookService registers webhooks and sends them signed JSON requests for
+// the link events they subscribed to. Requests wait in a queue kept by the
+// repository, so they survive restarts, and are retried with exponential
+// backoff until the webhook answers with a 2xx status. Requests failing too
+// often are kept as dead letters.
+type WebhookService struct {
+	repository   WebhookRepository
+	checker      URLChecker
+	allowPrivate bool
+	client       *http.Client
+	attempts     int
+	backoff      time.Duration
+	maxBackoff   time.Duration
+	timeout      time.Duration
+	pollInterval time.Duration
+	batchSize    int
+
+	mu     sync.Mutex
+	hooks  []*model.Webhook
+	loaded time.Time
+
+	done chan struct{}
+	stop sync.Once
+	wg   sync.WaitGroup
+}
+
+// NewWebhookService returns a WebhookService; Start sends the queued requests.
+func NewWebhookService(repo WebhookRepository, opts ...WebhookOption) *WebhookService {
+	s := &WebhookService{
+		repository:   repo,
+		attempts:     _defaultWebhookAttempts,
+		backoff:      _defaultWebhookBackoff,
+		maxBackoff:   _defaultWebhookMaxBackoff,
+		timeout:      _defaultWebhookTimeout,
+		pollInterval: _defaultWebhookPoll,
+		batchSize:    _defaultWebhookBatchSize,
+		done:         make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	// The URL of a webhook is only checked when it is registered, so its host
+	// may resolve to a private address by the time requests are sent. Every
+	// connection is checked when it is dialled, and no proxy from the
+	// environment gets to dial it instead.
+	dialer := &net.Dialer{Timeout: s.timeout}
+	if !s.allowPrivate {
+		dialer.Control = urlcheck.DenyPrivate
+	}
+
+	s.client = &http.Client{
+		Timeout: s.timeout,
+		Transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   s.timeout,
+			ResponseHeaderTimeout: s.timeout,
+			MaxIdleConns:          16,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		// A redirect is not an answer from the webhook.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return s
+}
+
+// CreateWebhook registers a webhook. The secret of the returned webhook is
+// generated when the request has none.
+func (s *WebhookService) CreateWebhook(ctx context.Context, request *dto.CreateWebhookRequest) (*model.Webhook, error) {
+	if err := s.validURL(ctx, request.URL); err != nil {
+		return nil, err
+	}
+
+	events, err := webhookSubscriptions(request.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	thresholds, err := clickThresholds(request.ClickThresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 && len(thresholds) == 0 {
+		return nil, apierror.InvalidFieldError("events", fmt.Errorf("webhook subscribes to no events or click thresholds"))
+	}
+
+	secret := request.Secret
+	switch {
+	case secret == "":
+		if secret, err = randomID(); err != nil {
+			return nil, apierror.InternalError(err)
+		}
+	case len(secret) < minWebhookSecret:
+		return nil, apierror.InvalidFieldError("secret", fmt.Errorf("secret is shorter than %d characters", minWebhookSecret))
+	}
+
+	id, err := randomID()
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	hook := &model.Webhook{
+		ID:              id,
+		URL:             request.URL,
+		Secret:          secret,
+		Events:          events,
+		ClickThresholds: thresholds,
+		CreatedAt:       time.Now(),
+	}
+
+	if err := s.repository.StoreWebhook(ctx, hook); err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	s.invalidate()
+
+	return hook, nil
+}
+
+// ListWebhooks returns every webhook, oldest first.
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	hooks, err := s.repository.ListWebhooks(ctx)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	return hooks, nil
+}
+
+// DeleteWebhook deletes a webhook; its queued requests are not sent.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string) error {
+	err := s.repository.DeleteWebhook(ctx, id)
+	switch {
+	case errors.Is(err, apierror.ErrWebhookNotFound):
+		return apierror.NewAPIError(apierror.ErrWebhookNotFound, nil)
+	case err != nil:
+		return apierror.InternalError(err)
+	}
+
+	s.invalidate()
+
+	return nil
+}
+
+// ListDeadDeliveries returns the latest requests to a webhook that were given up on.
+func (s *WebhookService) ListDeadDeliveries(ctx context.Context, id string) ([]*model.WebhookDelivery, error) {
+	if _, err := s.webhook(ctx, id); err != nil {
+		if errors.Is(err, apierror.ErrWebhookNotFound) {
+			return nil, apierror.NewAPIError(apierror.ErrWebhookNotFound, nil)
+		}
+
+		return nil, apierror.InternalError(err)
+	}
+
+	deliveries, err := s.repository.ListDeadDeliveries(ctx, id, maxDeadDeliveries)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	return deliveries, nil
+}
+
+// validURL checks that webhooks may be sent to rawURL.
+func (s *WebhookService) validURL(ctx context.Context, rawURL string) error {
+	if rawURL == "" {
+		return apierror.InvalidFieldError("url", errors.New("is required"))
+	}
+
+	u, err := url.ParseRequestURI(rawURL)
+	if err != nil {
+		return apierror.InvalidFieldError("url", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apierror.InvalidFieldError("url", fmt.Errorf("must be an http(s) URL with a host"))
+	}
+
+	if s.checker != nil {
+		if err := s.checker.Check(ctx, u); err != nil {
+			return apierror.NewAPIError(apierror.ErrURLForbidden, err)
+		}
+	}
+
+	return nil
+}
+
+// webhookSubscriptions checks the link events a webhook subscribes to.
+// Clicks are subscribed to with click thresholds.
+func webhookSubscriptions(names []string) ([]model.WebhookEvent, error) {
+	var events []model.WebhookEvent
+
+	seen := make(map[model.WebhookEvent]bool, len(names))
+
+	for _, name := range names {
+		event := model.WebhookEvent(name)
+
+		switch event {
+		case model.WebhookLinkCreated, model.WebhookLinkUpdated, model.WebhookLinkExpired, model.WebhookLinkDeleted:
+		default:
+			return nil, apierror.InvalidFieldError("events", fmt.Errorf("unknown event %q", name))
+		}
+
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// clickThresholds checks the click counts a webhook waits for and returns them in order.
+func clickThresholds(thresholds []int64) ([]int64, error) {
+	if len(thresholds) > maxClickThresholds {
+		return nil, apierror.InvalidFieldError("click_thresholds", fmt.Errorf("more than %d thresholds", maxClickThresholds))
+	}
+
+	var sorted []int64
+
+	seen := make(map[int64]bool, len(thresholds))
+
+	for _, threshold := range thresholds {
+		if threshold <= 0 {
+			return nil, apierror.InvalidFieldError("click_thresholds", fmt.Errorf("thresholds must be positive"))
+		}
+
+		if !seen[threshold] {
+			seen[threshold] = true
+			sorted = append(sorted, threshold)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return sorted, nil
+}
+
+// Notify queues a request to each webhook subscribed to payload. Each request
+// gets its own ID. Failures to queue are dropped.
+func (s *WebhookService) Notify(payload *model.WebhookPayload) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	hooks, err := s.webhooks(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, hook := range hooks {
+		if hook.Subscribed(payload) {
+			_ = s.enqueue(ctx, hook, payload)
+		}
+	}
+}
+
+// CountsClicks reports whether any webhook waits for a number of clicks.
+func (s *WebhookService) CountsClicks() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	hooks, err := s.webhooks(ctx)
+	if err != nil {
+		return false
+	}
+
+	for _, hook := range hooks {
+		if len(hook.ClickThresholds) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *WebhookService) enqueue(ctx context.Context, hook *model.Webhook, payload *model.WebhookPayload) error {
+	id, err := randomID()
+	if err != nil {
+		return err
+	}
+
+	request := *payload
+	request.ID = id
+
+	body, err := request.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	return s.repository.EnqueueDelivery(ctx, &model.WebhookDelivery{
+		ID:          id,
+		WebhookID:   hook.ID,
+		Event:       payload.Event,
+		Payload:     body,
+		NextAttempt: now,
+		CreatedAt:   now,
+	})
+}
+
+// webhooks returns the cached list of webhooks, loading it when it is stale.
+func (s *WebhookService) webhooks(ctx context.Context) ([]*model.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loaded.IsZero() && time.Since(s.loaded) < webhookRefresh {
+		return s.hooks, nil
+	}
+
+	hooks, err := s.repository.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.hooks, s.loaded = hooks, time.Now()
+
+	return hooks, nil
+}
+
+// webhook returns the webhook with id, reloading the webhooks once in case
+// it was registered since they were cached.
+func (s *WebhookService) webhook(ctx context.Context, id string) (*model.Webhook, error) {
+	for reload := false; ; reload = true {
+		if reload {
+			s.invalidate()
+		}
+
+		hooks, err := s.webhooks(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hook := range hooks {
+			if hook.ID == id {
+				return hook, nil
+			}
+		}
+
+		if reload {
+			return nil, apierror.ErrWebhookNotFound
+		}
+	}
+}
+
+func (s *WebhookService) invalidate() {
+	s.mu.Lock()
+	s.loaded = time.Time{}
+	s.mu.Unlock()
+}
+
+// Start sends the queued requests in the background until Close.
+func (s *WebhookService) Start() {
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-ticker.C:
+			}
+
+			// Full batches suggest more requests are due.
+			for s.deliverDue(context.Background()) == s.batchSize {
+				select {
+				case <-s.done:
+					return
+				default:
+				}
+			}
+		}
+	}()
+}
+
+// Close stops sending requests and waits for those being sent.
+func (s *WebhookService) Close() {
+	s.stop.Do(func() { close(s.done) })
+	s.wg.Wait()
+}
+
+// deliverDue sends a batch of due requests and returns how many it claimed.
+// Claimed requests are held back while they are sent, so they are retried
+// should the process stop before they are settled.
+func (s *WebhookService) deliverDue(ctx context.Context) int {
+	now := time.Now()
+
+	deliveries, err := s.repository.ClaimDeliveries(ctx, now, now.Add(2*s.timeout), s.batchSize)
+	if err != nil {
+		return 0
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(len(deliveries))
+
+	for _, delivery := range deliveries {
+		go func(delivery *model.WebhookDelivery) {
+			defer wg.Done()
+
+			s.deliver(ctx, delivery)
+		}(delivery)
+	}
+
+	wg.Wait()
+
+	return len(deliveries)
+}
+
+// deliver sends a request and settles it: delivered requests and those of
+// deleted webhooks leave the queue, failed ones are retried later or become
+// dead letters once they used up their attempts.
+func (s *WebhookService) deliver(ctx context.Context, delivery *model.WebhookDelivery) {
+	hook, err := s.webhook(ctx, delivery.WebhookID)
+	if errors.Is(err, apierror.ErrWebhookNotFound) {
+		_ = s.repository.DeleteDelivery(ctx, delivery.ID)
+		return
+	}
+
+	if err != nil {
+		return
+	}
+
+	err = s.send(ctx, hook, delivery)
+	if err == nil {
+		_ = s.repository.DeleteDelivery(ctx, delivery.ID)
+		return
+	}
+
+	delivery.Attempts++
+	delivery.LastError = err.Error()
+
+	if delivery.Attempts >= s.attempts {
+		delivery.Dead = true
+	} else {
+		delivery.NextAttempt = time.Now().Add(s.retryAfter(delivery.Attempts))
+	}
+
+	_ = s.repository.UpdateDelivery(ctx, delivery)
+}
+
+// retryAfter doubles the backoff with each failed attempt, up to the maximum.
+func (s *WebhookService) retryAfter(attempts int) time.Duration {
+	backoff := s.backoff
+
+	for i := 1; i < attempts && backoff < s.maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > s.maxBackoff {
+		return s.maxBackoff
+	}
+
+	return backoff
+}
+
+// send posts the payload of delivery to hook, signed with its secret.
+func (s *WebhookService) send(ctx context.Context, hook *model.Webhook, delivery *model.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set(webhook.EventHeader, string(delivery.Event))
+	req.Header.Set(webhook.DeliveryHeader, delivery.ID)
+	req.Header.Set(webhook.TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(hook.Secret, now, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookResponseLen))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+
+	return nil
+}
+
+// notify queues requests to the webhooks subscribed to event of link.
+// The destination of protected links is left out.
+func (service *LinkService) notify(event model.WebhookEvent, link *model.Link, clicks int64) {
+	if service.webhooks == nil {
+		return
+	}
+
+	payload := &model.WebhookPayload{
+		Event:  event,
+		Time:   time.Now(),
+		Domain: link.Domain,
+		Token:  link.Token,
+		Clicks: clicks,
+	}
+
+	if event != model.WebhookLinkExpired && event != model.WebhookLinkDeleted {
+		payload.ShortLink = service.shortLink(link)
+
+		if !link.Protected() {
+			payload.Link = link.OriginalLink
+		}
+	}
+
+	service.webhooks.Notify(payload)
+}
+
+// countClick counts a visit of link and notifies the webhooks waiting for
+// the number of clicks it reached. Counting is best effort.
+func (service *LinkService) countClick(ctx context.Context, link *model.Link) {
+	clicks, err := service.repository.CountClick(ctx, link.Domain, link.Token)
+	if err != nil {
+		return
+	}
+
+	service.notify(model.WebhookLinkClicks, link, clicks)
+}
@@ -0,0 +1,392 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/internal/delivery/http/dto"
+	"github.com/CodeMaster482/ShortLinkAPI/internal/model"
+	mock_usecase "github.com/CodeMaster482/ShortLinkAPI/internal/usecase/mocks"
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/urlcheck"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/webhook"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef"
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		request dto.CreateWebhookRequest
+		field   string
+		err     error
+	}{
+		{
+			name:    "events",
+			request: dto.CreateWebhookRequest{URL: "https://example.com/hook", Events: []string{"link.created", "link.expired", "link.deleted", "link.created"}},
+		},
+		{
+			name:    "click thresholds",
+			request: dto.CreateWebhookRequest{URL: "https://example.com/hook", Secret: testSecret, ClickThresholds: []int64{1000, 10, 1000}},
+		},
+		{
+			name:    "no url",
+			request: dto.CreateWebhookRequest{Events: []string{"link.created"}},
+			field:   "url",
+		},
+		{
+			name:    "not http",
+			request: dto.CreateWebhookRequest{URL: "ftp://example.com/hook", Events: []string{"link.created"}},
+			field:   "url",
+		},
+		{
+			name:    "forbidden url",
+			request: dto.CreateWebhookRequest{URL: "https://forbidden.example.com/hook", Events: []string{"link.created"}},
+			err:     apierror.ErrURLForbidden,
+		},
+		{
+			name:    "unknown event",
+			request: dto.CreateWebhookRequest{URL: "https://example.com/hook", Events: []string{"link.renamed"}},
+			field:   "events",
+		},
+		{
+			name:    "clicks as event",
+			request: dto.CreateWebhookRequest{URL: "https://example.com/hook", Events: []string{"link.clicks"}},
+			field:   "events",
+		},
+		{
+			name:    "nothing subscribed",
+			request: dto.CreateWebhookRequest{URL: "https://example.com/hook"},
+			field:   "events",
+		},
+		{
+			name:    "zero threshold",
+			request: dto.CreateWebhookRequest{URL: "https://example.com/hook", ClickThresholds: []int64{0}},
+			field:   "click_thresholds",
+		},
+		{
+			name:    "short secret",
+			request: dto.CreateWebhookRequest{URL: "https://example.com/hook", Secret: "short", Events: []string{"link.created"}},
+			field:   "secret",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_usecase.NewMockWebhookRepository(ctrl)
+			mockChecker := mock_usecase.NewMockURLChecker(ctrl)
+			mockChecker.EXPECT().Check(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *url.URL) error {
+				if u.Hostname() == "forbidden.example.com" {
+					return errors.New("denied")
+				}
+
+				return nil
+			}).AnyTimes()
+
+			service := NewWebhookService(mockRepo, WebhookURLChecker(mockChecker))
+
+			if tc.field != "" || tc.err != nil {
+				_, err := service.CreateWebhook(context.TODO(), &tc.request)
+
+				var (
+					apiErr   *apierror.APIError
+					fieldErr *apierror.FieldError
+				)
+
+				if tc.field != "" {
+					require.ErrorIs(t, err, apierror.ErrBadRequest)
+					require.ErrorAs(t, err, &apiErr)
+					require.ErrorAs(t, apiErr.Internal(), &fieldErr)
+					assert.Equal(t, tc.field, fieldErr.Field)
+				} else {
+					assert.ErrorIs(t, err, tc.err)
+				}
+
+				return
+			}
+
+			var stored *model.Webhook
+			mockRepo.EXPECT().StoreWebhook(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, hook *model.Webhook) error {
+				stored = hook
+				return nil
+			})
+
+			hook, err := service.CreateWebhook(context.TODO(), &tc.request)
+			require.NoError(t, err)
+			assert.Same(t, stored, hook)
+			assert.Len(t, hook.ID, 32)
+			assert.GreaterOrEqual(t, len(hook.Secret), minWebhookSecret)
+
+			if tc.request.Secret != "" {
+				assert.Equal(t, tc.request.Secret, hook.Secret)
+			}
+
+			if len(tc.request.Events) > 0 {
+				assert.Equal(t, []model.WebhookEvent{model.WebhookLinkCreated, model.WebhookLinkExpired, model.WebhookLinkDeleted}, hook.Events)
+			} else {
+				assert.Equal(t, []int64{10, 1000}, hook.ClickThresholds)
+			}
+		})
+	}
+}
+
+func TestWebhookService_Notify(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockWebhookRepository(ctrl)
+	service := NewWebhookService(mockRepo)
+
+	mockRepo.EXPECT().ListWebhooks(gomock.Any()).Return([]*model.Webhook{
+		{ID: "created", Events: []model.WebhookEvent{model.WebhookLinkCreated}},
+		{ID: "expired", Events: []model.WebhookEvent{model.WebhookLinkExpired}},
+		{ID: "clicks", ClickThresholds: []int64{10, 100}},
+	}, nil).Times(1)
+
+	var queued []*model.WebhookDelivery
+	mockRepo.EXPECT().EnqueueDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, delivery *model.WebhookDelivery) error {
+		queued = append(queued, delivery)
+		return nil
+	}).Times(2)
+
+	require.True(t, service.CountsClicks())
+
+	service.Notify(&model.WebhookPayload{Event: model.WebhookLinkCreated, Token: "created___"})
+	service.Notify(&model.WebhookPayload{Event: model.WebhookLinkClicks, Token: "clicked___", Clicks: 11})
+	service.Notify(&model.WebhookPayload{Event: model.WebhookLinkClicks, Token: "clicked___", Clicks: 100})
+
+	require.Len(t, queued, 2)
+
+	assert.Equal(t, "created", queued[0].WebhookID)
+	assert.Equal(t, model.WebhookLinkCreated, queued[0].Event)
+	assert.JSONEq(t, `{"id":"`+queued[0].ID+`","event":"link.created","time":"0001-01-01T00:00:00Z","token":"created___"}`, string(queued[0].Payload))
+
+	assert.Equal(t, "clicks", queued[1].WebhookID)
+	assert.JSONEq(t, `{"id":"`+queued[1].ID+`","event":"link.clicks","time":"0001-01-01T00:00:00Z","token":"clicked___","clicks":100}`, string(queued[1].Payload))
+	assert.NotEqual(t, queued[0].ID, queued[1].ID)
+}
+
+func TestWebhookService_Deliver(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		status   int
+		attempts int
+		settle   func(t *testing.T, repo *mock_usecase.MockWebhookRepository, check func(*model.WebhookDelivery))
+	}{
+		{
+			name:   "delivered",
+			status: http.StatusNoContent,
+			settle: func(_ *testing.T, repo *mock_usecase.MockWebhookRepository, _ func(*model.WebhookDelivery)) {
+				repo.EXPECT().DeleteDelivery(gomock.Any(), "delivery").Return(nil)
+			},
+		},
+		{
+			name:     "retried",
+			status:   http.StatusServiceUnavailable,
+			attempts: 2,
+			settle: func(t *testing.T, repo *mock_usecase.MockWebhookRepository, check func(*model.WebhookDelivery)) {
+				repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, delivery *model.WebhookDelivery) error {
+					check(delivery)
+
+					assert.Equal(t, 3, delivery.Attempts)
+					assert.False(t, delivery.Dead)
+					assert.Equal(t, "webhook answered 503 Service Unavailable", delivery.LastError)
+					assert.WithinDuration(t, time.Now().Add(4*time.Minute), delivery.NextAttempt, 5*time.Second)
+
+					return nil
+				})
+			},
+		},
+		{
+			name:     "dead",
+			status:   http.StatusFound,
+			attempts: 4,
+			settle: func(t *testing.T, repo *mock_usecase.MockWebhookRepository, check func(*model.WebhookDelivery)) {
+				repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, delivery *model.WebhookDelivery) error {
+					check(delivery)
+
+					assert.Equal(t, 5, delivery.Attempts)
+					assert.True(t, delivery.Dead)
+
+					return nil
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			payload := []byte(`{"id":"delivery","event":"link.created","token":"abc"}`)
+			received := make(chan *http.Request, 1)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				assert.Equal(t, payload, body)
+				assert.NoError(t, webhook.Verify(testSecret, r.Header.Get(webhook.TimestampHeader), r.Header.Get(webhook.SignatureHeader), body, time.Now(), time.Minute))
+
+				received <- r
+
+				if tc.status == http.StatusFound {
+					http.Redirect(w, r, "/elsewhere", tc.status)
+					return
+				}
+
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_usecase.NewMockWebhookRepository(ctrl)
+			service := NewWebhookService(mockRepo,
+				WebhookAttempts(5),
+				WebhookBackoff(time.Minute, time.Hour),
+				WebhookTimeout(time.Second),
+				WebhookBatchSize(10),
+				WebhookAllowPrivate(),
+			)
+
+			delivery := &model.WebhookDelivery{
+				ID:        "delivery",
+				WebhookID: "hook",
+				Event:     model.WebhookLinkCreated,
+				Payload:   payload,
+				Attempts:  tc.attempts,
+			}
+
+			mockRepo.EXPECT().ClaimDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+				DoAndReturn(func(_ context.Context, now, until time.Time, _ int) ([]*model.WebhookDelivery, error) {
+					assert.Equal(t, 2*time.Second, until.Sub(now), "deliveries are held back while they are sent")
+					return []*model.WebhookDelivery{delivery}, nil
+				})
+			mockRepo.EXPECT().ListWebhooks(gomock.Any()).Return([]*model.Webhook{{ID: "hook", URL: server.URL, Secret: testSecret}}, nil)
+			tc.settle(t, mockRepo, func(settled *model.WebhookDelivery) {
+				assert.Same(t, delivery, settled)
+			})
+
+			require.Equal(t, 1, service.deliverDue(context.TODO()))
+
+			r := <-received
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "link.created", r.Header.Get(webhook.EventHeader))
+			assert.Equal(t, "delivery", r.Header.Get(webhook.DeliveryHeader))
+		})
+	}
+}
+
+func TestWebhookService_SendPrivateAddress(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("webhooks must not reach a loopback server")
+	}))
+	defer server.Close()
+
+	service := NewWebhookService(nil, WebhookTimeout(time.Second))
+
+	hook := &model.Webhook{ID: "hook", URL: server.URL, Secret: testSecret}
+	delivery := &model.WebhookDelivery{ID: "delivery", Event: model.WebhookLinkCreated, Payload: []byte(`{}`)}
+
+	err := service.send(context.TODO(), hook, delivery)
+	require.ErrorIs(t, err, urlcheck.ErrPrivateAddress)
+}
+
+func TestWebhookService_DeliverDeletedWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockWebhookRepository(ctrl)
+	service := NewWebhookService(mockRepo)
+
+	mockRepo.EXPECT().ClaimDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]*model.WebhookDelivery{{ID: "orphan", WebhookID: "deleted"}}, nil)
+	// The cached webhooks are reloaded once before giving up.
+	mockRepo.EXPECT().ListWebhooks(gomock.Any()).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().DeleteDelivery(gomock.Any(), "orphan").Return(nil)
+
+	require.Equal(t, 1, service.deliverDue(context.TODO()))
+}
+
+func TestWebhookService_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	service := NewWebhookService(nil, WebhookBackoff(30*time.Second, time.Hour))
+
+	for attempts, want := range map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		7:  32 * time.Minute,
+		8:  time.Hour,
+		60: time.Hour,
+	} {
+		assert.Equal(t, want, service.retryAfter(attempts), "after %d attempts", attempts)
+	}
+}
+
+func TestLinkService_ClickThresholds(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_usecase.NewMockLinkRepository(ctrl)
+	mockWebhooks := mock_usecase.NewMockWebhookNotifier(ctrl)
+
+	usecase := LinkService{
+		repository:      mockRepo,
+		shortlinkPrefix: prefix,
+		webhooks:        mockWebhooks,
+	}
+
+	link := &model.Link{Token: "protected_", OriginalLink: "https://example.com", ExpiresAt: time.Now().Add(time.Hour), PasswordHash: "hash"}
+
+	mockWebhooks.EXPECT().CountsClicks().Return(true)
+	mockRepo.EXPECT().CountClick(gomock.Any(), "", "protected_").Return(int64(100), nil)
+	mockWebhooks.EXPECT().Notify(gomock.Any()).Do(func(payload *model.WebhookPayload) {
+		assert.Equal(t, model.WebhookLinkClicks, payload.Event)
+		assert.Equal(t, int64(100), payload.Clicks)
+		assert.Equal(t, prefix+"protected_", payload.ShortLink)
+		assert.Empty(t, payload.Link, "destinations of protected links stay hidden")
+	})
+
+	_, err := usecase.redirect(context.TODO(), link, &model.Visit{})
+	require.NoError(t, err)
+
+	mockWebhooks.EXPECT().CountsClicks().Return(false)
+
+	_, err = usecase.redirect(context.TODO(), link, &model.Visit{})
+	require.NoError(t, err)
+}
@@ -4,32 +4,116 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
 )
 
+// Definition describes an error of the API alike to clients of every transport.
+type Definition struct {
+	// Code is the HTTP status of the error.
+	Code int
+	// Message is the summary clients are shown.
+	Message string
+	// Reason identifies the error to machines.
+	Reason string
+	// GRPCCode is the status code of the error for gRPC clients.
+	GRPCCode codes.Code
+}
+
 var (
-	Errors = map[error]struct {
-		Code    int
-		Message string
-	}{
+	// Errors is the catalogue of the errors clients may see. Any other error
+	// is reported as ErrInternalServer.
+	Errors = map[error]Definition{
 		ErrInternalServer: {
 			http.StatusInternalServerError,
 			ErrInternalServer.Error(),
+			"INTERNAL",
+			codes.Internal,
 		},
 		ErrBadRequest: {
 			http.StatusBadRequest,
 			ErrBadRequest.Error(),
+			"BAD_REQUEST",
+			codes.InvalidArgument,
 		},
 		ErrUnableToCreateLink: {
 			http.StatusConflict,
 			ErrUnableToCreateLink.Error(),
+			"UNABLE_TO_CREATE_LINK",
+			codes.AlreadyExists,
+		},
+		ErrAliasTaken: {
+			http.StatusConflict,
+			ErrAliasTaken.Error(),
+			"ALIAS_TAKEN",
+			codes.AlreadyExists,
 		},
 		ErrLinkNotFound: {
 			http.StatusNotFound,
 			ErrLinkNotFound.Error(),
+			"LINK_NOT_FOUND",
+			codes.NotFound,
+		},
+		ErrLinkExhausted: {
+			http.StatusGone,
+			ErrLinkExhausted.Error(),
+			"LINK_EXHAUSTED",
+			codes.NotFound,
+		},
+		ErrLinkNotActive: {
+			http.StatusForbidden,
+			ErrLinkNotActive.Error(),
+			"LINK_NOT_ACTIVE",
+			codes.FailedPrecondition,
 		},
 		ErrURLNotValid: {
 			http.StatusBadRequest,
 			ErrURLNotValid.Error(),
+			"URL_NOT_VALID",
+			codes.InvalidArgument,
+		},
+		ErrURLForbidden: {
+			http.StatusUnprocessableEntity,
+			ErrURLForbidden.Error(),
+			"URL_FORBIDDEN",
+			codes.PermissionDenied,
+		},
+		ErrPasswordRequired: {
+			http.StatusUnauthorized,
+			ErrPasswordRequired.Error(),
+			"PASSWORD_REQUIRED",
+			codes.Unauthenticated,
+		},
+		ErrPasswordInvalid: {
+			http.StatusForbidden,
+			ErrPasswordInvalid.Error(),
+			"PASSWORD_INVALID",
+			codes.PermissionDenied,
+		},
+		ErrTooManyAttempts: {
+			http.StatusTooManyRequests,
+			ErrTooManyAttempts.Error(),
+			"TOO_MANY_ATTEMPTS",
+			codes.ResourceExhausted,
+		},
+		ErrCertificateRequired: {
+			http.StatusUnauthorized,
+			ErrCertificateRequired.Error(),
+			"CERTIFICATE_REQUIRED",
+			codes.Unauthenticated,
+		},
+		ErrCursorExpired: {
+			http.StatusGone,
+			ErrCursorExpired.Error(),
+			"CURSOR_EXPIRED",
+			codes.OutOfRange,
+		},
+		ErrWebhookNotFound: {
+			http.StatusNotFound,
+			ErrWebhookNotFound.Error(),
+			"WEBHOOK_NOT_FOUND",
+			codes.NotFound,
 		},
 	}
 )
@@ -38,9 +122,23 @@ var (
 	ErrInternalServer     = errors.New("internal server error")
 	ErrBadRequest         = errors.New("bad request")
 	ErrUnableToCreateLink = errors.New("unable to create link")
+	ErrAliasTaken         = errors.New("alias is taken")
 
-	ErrLinkNotFound = errors.New("link not found")
-	ErrURLNotValid  = errors.New("url is not valid")
+	ErrLinkNotFound  = errors.New("link not found")
+	ErrLinkExhausted = errors.New("link is no longer available")
+	ErrLinkNotActive = errors.New("link is not active yet")
+	ErrURLNotValid   = errors.New("url is not valid")
+	ErrURLForbidden  = errors.New("url is forbidden")
+
+	ErrPasswordRequired = errors.New("password required")
+	ErrPasswordInvalid  = errors.New("invalid password")
+	ErrTooManyAttempts  = errors.New("too many attempts")
+
+	ErrCertificateRequired = errors.New("client certificate required")
+
+	ErrCursorExpired = errors.New("cursor expired")
+
+	ErrWebhookNotFound = errors.New("webhook not found")
 )
 
 type APIError struct {
@@ -78,3 +176,103 @@ func InternalError(err error) *APIError {
 func NotFoundError() *APIError {
 	return NewAPIError(ErrLinkNotFound, nil)
 }
+
+// Sentinel returns the error of the catalogue err stands for. Errors
+// outside the catalogue are ErrInternalServer, so their causes never
+// reach clients.
+func Sentinel(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		err = apiErr.Unwrap()
+	}
+
+	if _, ok := Errors[err]; ok {
+		return err
+	}
+
+	// Repositories return some of the sentinel errors bare or wrapped.
+	for sentinel := range Errors {
+		if errors.Is(err, sentinel) {
+			return sentinel
+		}
+	}
+
+	return ErrInternalServer
+}
+
+// Internal returns the underlying cause of the error, if any.
+// It is meant for logs and must not reach clients verbatim.
+func (ae APIError) Internal() error {
+	return ae.internalError
+}
+
+// InvalidCode is the code of field errors without a more specific one.
+const InvalidCode = "invalid"
+
+// FieldError tells which field of a request failed validation.
+// Code names the violated rule for machines.
+type FieldError struct {
+	Field string
+	Code  string
+	Err   error
+}
+
+func (fe *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", fe.Field, fe.Err)
+}
+
+func (fe *FieldError) Unwrap() error {
+	return fe.Err
+}
+
+// FieldErrors lists every invalid field of a request.
+type FieldErrors []*FieldError
+
+func (fe FieldErrors) Error() string {
+	messages := make([]string, 0, len(fe))
+	for _, err := range fe {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap lets errors.As find each FieldError.
+func (fe FieldErrors) Unwrap() []error {
+	errs := make([]error, 0, len(fe))
+	for _, err := range fe {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// InvalidFieldError reports a bad request caused by field.
+func InvalidFieldError(field string, err error) *APIError {
+	return NewAPIError(ErrBadRequest, &FieldError{Field: field, Code: InvalidCode, Err: err})
+}
+
+// InvalidFieldsError reports a bad request caused by several fields at once.
+func InvalidFieldsError(errs FieldErrors) *APIError {
+	return NewAPIError(ErrBadRequest, errs)
+}
+
+// FieldErrorsOf returns the invalid fields behind err, if any.
+func FieldErrorsOf(err error) FieldErrors {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+
+	var errs FieldErrors
+	if errors.As(apiErr.Internal(), &errs) {
+		return errs
+	}
+
+	var fieldErr *FieldError
+	if errors.As(apiErr.Internal(), &fieldErr) {
+		return FieldErrors{fieldErr}
+	}
+
+	return nil
+}
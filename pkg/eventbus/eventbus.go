@@ -0,0 +1,181 @@
+// Package eventbus fans events out to in-process watchers. The most recent
+// events are kept, so watchers that lost their connection can resume from
+// the cursor of the last event they saw.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const _defaultHistory = 1024
+
+var (
+	// ErrCursorExpired is returned for cursors whose successors are no longer
+	// kept, including cursors handed out before a restart.
+	ErrCursorExpired = errors.New("eventbus: cursor expired")
+	// ErrInvalidCursor is returned for cursors the bus did not hand out.
+	ErrInvalidCursor = errors.New("eventbus: invalid cursor")
+)
+
+type entry[T any] struct {
+	seq   uint64
+	value T
+}
+
+// Bus -.
+type Bus[T any] struct {
+	// epoch tells cursors of this bus from the ones of earlier processes.
+	epoch string
+
+	mu      sync.Mutex
+	history []entry[T]
+	start   int
+	seq     uint64
+	// published is closed and replaced whenever an event is published.
+	published chan struct{}
+}
+
+// New -.
+func New[T any](opts ...Option) *Bus[T] {
+	o := &options{history: _defaultHistory}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Bus[T]{
+		epoch:     strconv.FormatInt(time.Now().UnixNano(), 36),
+		history:   make([]entry[T], 0, o.history),
+		published: make(chan struct{}),
+	}
+}
+
+// Publish hands value to the watchers and returns its cursor.
+func (b *Bus[T]) Publish(value T) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+
+	e := entry[T]{seq: b.seq, value: value}
+	if len(b.history) < cap(b.history) {
+		b.history = append(b.history, e)
+	} else {
+		b.history[b.start] = e
+		b.start = (b.start + 1) % len(b.history)
+	}
+
+	close(b.published)
+	b.published = make(chan struct{})
+
+	return b.cursor(e.seq)
+}
+
+// Event is a published value and the cursor to resume watching after it.
+type Event[T any] struct {
+	Cursor string
+	Value  T
+}
+
+// Watch returns the events published after cursor, in order. An empty cursor
+// starts with the next event published. The channel is closed when ctx is
+// done or the watcher falls so far behind that the events it missed are no
+// longer kept; it may resume from the cursor of the last event received.
+func (b *Bus[T]) Watch(ctx context.Context, cursor string) (<-chan Event[T], error) {
+	seq, err := b.parse(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := b.since(seq); err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event[T])
+
+	go func() {
+		defer close(events)
+
+		for {
+			entries, published, err := b.since(seq)
+			if err != nil {
+				return
+			}
+
+			for _, e := range entries {
+				select {
+				case events <- Event[T]{Cursor: b.cursor(e.seq), Value: e.value}:
+					seq = e.seq
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-published:
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// since returns the events after seq and a channel closed on the next Publish.
+func (b *Bus[T]) since(seq uint64) ([]entry[T], <-chan struct{}, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if seq > b.seq {
+		return nil, nil, ErrCursorExpired
+	}
+
+	missed := int(b.seq - seq)
+	if missed > len(b.history) {
+		return nil, nil, ErrCursorExpired
+	}
+
+	entries := make([]entry[T], 0, missed)
+	for i := len(b.history) - missed; i < len(b.history); i++ {
+		entries = append(entries, b.history[(b.start+i)%len(b.history)])
+	}
+
+	return entries, b.published, nil
+}
+
+// parse returns the sequence number cursor points at; the latest one for an
+// empty cursor.
+func (b *Bus[T]) parse(cursor string) (uint64, error) {
+	if cursor == "" {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		return b.seq, nil
+	}
+
+	epoch, rawSeq, ok := strings.Cut(cursor, "-")
+	if !ok {
+		return 0, ErrInvalidCursor
+	}
+
+	seq, err := strconv.ParseUint(rawSeq, 36, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	if epoch != b.epoch {
+		return 0, ErrCursorExpired
+	}
+
+	return seq, nil
+}
+
+func (b *Bus[T]) cursor(seq uint64) string {
+	return b.epoch + "-" + strconv.FormatUint(seq, 36)
+}
@@ -0,0 +1,166 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// collect watches b from cursor until n events were received.
+func collect(t *testing.T, b *Bus[int], cursor string, n int) []Event[int] {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	events, err := b.Watch(ctx, cursor)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	var received []Event[int]
+	for e := range events {
+		received = append(received, e)
+		if len(received) == n {
+			return received
+		}
+	}
+
+	t.Fatalf("expected %d events; got %d", n, len(received))
+
+	return nil
+}
+
+func values(events []Event[int]) []int {
+	v := make([]int, 0, len(events))
+	for _, e := range events {
+		v = append(v, e.Value)
+	}
+
+	return v
+}
+
+func TestBus_Resume(t *testing.T) {
+	t.Parallel()
+
+	b := New[int](History(3))
+
+	first := b.Publish(1)
+	for i := 2; i <= 4; i++ {
+		b.Publish(i)
+	}
+
+	received := collect(t, b, first, 3)
+	if v := values(received); v[0] != 2 || v[2] != 4 {
+		t.Errorf("expected events after the cursor; got %v", v)
+	}
+
+	if v := values(collect(t, b, received[0].Cursor, 2)); v[0] != 3 || v[1] != 4 {
+		t.Errorf("expected events after the second cursor; got %v", v)
+	}
+
+	b.Publish(5)
+
+	if _, err := b.Watch(context.Background(), first); !errors.Is(err, ErrCursorExpired) {
+		t.Errorf("expected ErrCursorExpired for a dropped cursor; got %v", err)
+	}
+}
+
+func TestBus_Live(t *testing.T) {
+	t.Parallel()
+
+	b := New[int]()
+	b.Publish(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	events, err := b.Watch(ctx, "")
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	b.Publish(2)
+	b.Publish(3)
+
+	if e := <-events; e.Value != 2 {
+		t.Errorf("expected only events published after Watch; got %d", e.Value)
+	}
+
+	if e := <-events; e.Value != 3 {
+		t.Errorf("expected 3; got %d", e.Value)
+	}
+
+	cancel()
+
+	if _, ok := <-events; ok {
+		t.Error("expected the channel to be closed once ctx is done")
+	}
+}
+
+func TestBus_FallBehind(t *testing.T) {
+	t.Parallel()
+
+	b := New[int](History(1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	events, err := b.Watch(ctx, "")
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	b.Publish(1)
+	first := <-events
+
+	// Nobody receives while 2, 3 and 4 are published, more than the history keeps.
+	for i := 2; i <= 4; i++ {
+		b.Publish(i)
+	}
+
+	for e := range events {
+		if e.Value > 2 {
+			t.Errorf("expected no events after the watcher fell behind; got %d", e.Value)
+		}
+	}
+
+	if ctx.Err() != nil {
+		t.Error("expected the channel to close before ctx is done")
+	}
+
+	if _, err := b.Watch(ctx, first.Cursor); !errors.Is(err, ErrCursorExpired) {
+		t.Errorf("expected ErrCursorExpired; got %v", err)
+	}
+}
+
+func TestBus_Cursors(t *testing.T) {
+	t.Parallel()
+
+	b := New[int]()
+	other := New[int]()
+	other.epoch = "other"
+
+	testCases := []struct {
+		name        string
+		cursor      string
+		expectedErr error
+	}{
+		{name: "Garbage", cursor: "garbage", expectedErr: ErrInvalidCursor},
+		{name: "Bad sequence", cursor: b.epoch + "-!", expectedErr: ErrInvalidCursor},
+		{name: "Other bus", cursor: other.Publish(1), expectedErr: ErrCursorExpired},
+		{name: "Ahead", cursor: b.cursor(10), expectedErr: ErrCursorExpired},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := b.Watch(context.Background(), tc.cursor); !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected %v; got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
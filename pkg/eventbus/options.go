@@ -0,0 +1,18 @@
+package eventbus
+
+// Option -.
+type Option func(*options)
+
+type options struct {
+	history int
+}
+
+// History sets how many of the most recent events are kept for watchers
+// resuming from a cursor.
+func History(size int) Option {
+	return func(o *options) {
+		if size > 0 {
+			o.history = size
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Package geoip looks up the country of IP addresses in a local MaxMind database.
+package geoip
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/oschwald/geoip2-golang"
+)
+
+// Locator -.
+type Locator struct {
+	reader *geoip2.Reader
+}
+
+// Open loads a GeoIP2 or GeoLite2 Country (or City) database file.
+func Open(path string) (*Locator, error) {
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("geoip - Open - geoip2.Open: %w", err)
+	}
+
+	return &Locator{reader: reader}, nil
+}
+
+// Country returns the ISO 3166-1 alpha-2 code of ip, or an empty string if it is unknown.
+func (l *Locator) Country(ip net.IP) (string, error) {
+	record, err := l.reader.Country(ip)
+	if err != nil {
+		return "", fmt.Errorf("geoip - Country - reader.Country: %w", err)
+	}
+
+	return record.Country.IsoCode, nil
+}
+
+// Close -.
+func (l *Locator) Close() error {
+	return l.reader.Close()
+}
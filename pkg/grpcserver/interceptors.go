@@ -0,0 +1,95 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryRecovery turns panics in handlers into Internal errors instead of
+// letting them crash the process.
+func UnaryRecovery(l logger.Interface) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(l, info.FullMethod, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery is UnaryRecovery for streaming calls.
+func StreamRecovery(l logger.Interface) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(l, info.FullMethod, r)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+func recovered(l logger.Interface, method string, r interface{}) error {
+	l.Error(fmt.Errorf("grpcserver - %s - panic: %v\n%s", method, r, debug.Stack()))
+
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// UnaryLogger logs the method, status code and duration of every call.
+func UnaryLogger(l logger.Interface) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		logCall(l, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamLogger is UnaryLogger for streaming calls.
+func StreamLogger(l logger.Interface) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+
+		logCall(l, info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func logCall(l logger.Interface, method string, start time.Time, err error) {
+	l.WithFields(map[string]interface{}{
+		"method":   method,
+		"code":     status.Code(err).String(),
+		"duration": time.Since(start).String(),
+	}).Info("grpc call")
+}
+
+// UnaryTimeout bounds every call to timeout. Deadlines set by clients
+// are kept when they are shorter. Streams are not bounded, as they may
+// be meant to stay open.
+func UnaryTimeout(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if timeout <= 0 {
+			return handler(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
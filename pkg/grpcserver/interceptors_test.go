@@ -0,0 +1,88 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/logger"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var unaryInfo = &grpc.UnaryServerInfo{FullMethod: "/test.Service/Call"}
+
+func TestUnaryRecovery(t *testing.T) {
+	t.Parallel()
+
+	interceptor := UnaryRecovery(logger.New("error"))
+
+	resp, err := interceptor(context.Background(), nil, unaryInfo, func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	require.Nil(t, resp)
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.NotContains(t, err.Error(), "boom")
+
+	resp, err = interceptor(context.Background(), nil, unaryInfo, func(context.Context, interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp)
+}
+
+func TestStreamRecovery(t *testing.T) {
+	t.Parallel()
+
+	interceptor := StreamRecovery(logger.New("error"))
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Watch"}
+
+	err := interceptor(nil, nil, info, func(interface{}, grpc.ServerStream) error {
+		panic(errors.New("boom"))
+	})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestUnaryLogger(t *testing.T) {
+	t.Parallel()
+
+	interceptor := UnaryLogger(logger.New("error"))
+	expected := status.Error(codes.NotFound, "link not found")
+
+	_, err := interceptor(context.Background(), nil, unaryInfo, func(context.Context, interface{}) (interface{}, error) {
+		return nil, expected
+	})
+	require.Equal(t, expected, err)
+}
+
+func TestUnaryTimeout(t *testing.T) {
+	t.Parallel()
+
+	deadline := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		d, ok := ctx.Deadline()
+		if !ok {
+			return time.Duration(0), nil
+		}
+
+		return time.Until(d), nil
+	}
+
+	left, err := UnaryTimeout(time.Minute)(context.Background(), nil, unaryInfo, deadline)
+	require.NoError(t, err)
+	require.InDelta(t, time.Minute, left, float64(time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	left, err = UnaryTimeout(time.Minute)(ctx, nil, unaryInfo, deadline)
+	require.NoError(t, err)
+	require.LessOrEqual(t, left, time.Second)
+
+	left, err = UnaryTimeout(0)(context.Background(), nil, unaryInfo, deadline)
+	require.NoError(t, err)
+	require.Zero(t, left)
+}
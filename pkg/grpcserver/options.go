@@ -0,0 +1,82 @@
+package grpcserver
+
+import (
+	"crypto/tls"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Option -.
+type Option func(*Server)
+
+// Address -.
+func Address(host, port string) Option {
+	return func(s *Server) {
+		s.addr = net.JoinHostPort(host, port)
+	}
+}
+
+// MaxRecvMsgSize -.
+func MaxRecvMsgSize(size int) Option {
+	return func(s *Server) {
+		if size > 0 {
+			s.opts = append(s.opts, grpc.MaxRecvMsgSize(size))
+		}
+	}
+}
+
+// MaxSendMsgSize -.
+func MaxSendMsgSize(size int) Option {
+	return func(s *Server) {
+		if size > 0 {
+			s.opts = append(s.opts, grpc.MaxSendMsgSize(size))
+		}
+	}
+}
+
+// Keepalive pings idle clients and closes connections of clients
+// that ping more often than the policy allows.
+func Keepalive(params keepalive.ServerParameters, policy keepalive.EnforcementPolicy) Option {
+	return func(s *Server) {
+		s.opts = append(s.opts, grpc.KeepaliveParams(params), grpc.KeepaliveEnforcementPolicy(policy))
+	}
+}
+
+// UnaryInterceptors are chained in the order given.
+func UnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) Option {
+	return func(s *Server) {
+		s.opts = append(s.opts, grpc.ChainUnaryInterceptor(interceptors...))
+	}
+}
+
+// StreamInterceptors are chained in the order given.
+func StreamInterceptors(interceptors ...grpc.StreamServerInterceptor) Option {
+	return func(s *Server) {
+		s.opts = append(s.opts, grpc.ChainStreamInterceptor(interceptors...))
+	}
+}
+
+// Reflection registers the server reflection service used by tools like grpcurl.
+func Reflection(enabled bool) Option {
+	return func(s *Server) {
+		s.reflection = enabled
+	}
+}
+
+// ShutdownTimeout -.
+func ShutdownTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.shutdownTimeout = timeout
+	}
+}
+
+// TLS serves gRPC over TLS with cfg.
+func TLS(cfg *tls.Config) Option {
+	return func(s *Server) {
+		s.opts = append(s.opts, grpc.Creds(credentials.NewTLS(cfg)))
+	}
+}
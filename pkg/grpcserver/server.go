@@ -0,0 +1,85 @@
+package grpcserver
+
+import (
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+const (
+	_defaultAddr            = ":8081"
+	_defaultShutdownTimeout = 3 * time.Second
+)
+
+// Server -.
+type Server struct {
+	server          *grpc.Server
+	opts            []grpc.ServerOption
+	addr            string
+	reflection      bool
+	notify          chan error
+	shutdownTimeout time.Duration
+}
+
+// New creates the server, lets register add the services to it and starts serving.
+func New(register func(grpc.ServiceRegistrar), opts ...Option) *Server {
+	s := &Server{
+		addr:            _defaultAddr,
+		notify:          make(chan error, 1),
+		shutdownTimeout: _defaultShutdownTimeout,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.server = grpc.NewServer(s.opts...)
+	register(s.server)
+
+	if s.reflection {
+		reflection.Register(s.server)
+	}
+
+	s.start()
+
+	return s
+}
+
+func (s *Server) start() {
+	go func() {
+		listener, err := net.Listen("tcp", s.addr)
+		if err == nil {
+			err = s.server.Serve(listener)
+		}
+
+		s.notify <- err
+		close(s.notify)
+	}()
+}
+
+// Notify -.
+func (s *Server) Notify() <-chan error {
+	return s.notify
+}
+
+// Shutdown lets in-flight calls finish, cancelling those still running
+// after the shutdown timeout.
+func (s *Server) Shutdown() error {
+	stopped := make(chan struct{})
+
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(s.shutdownTimeout):
+		s.server.Stop()
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package httpserver
 
 import (
+	"crypto/tls"
 	"net"
 	"time"
 )
@@ -35,3 +36,11 @@ func ShutdownTimeout(timeout time.Duration) Option {
 		s.shutdownTimeout = timeout
 	}
 }
+
+// TLS serves HTTPS with cfg. The certificate comes from cfg itself,
+// e.g. through GetCertificate.
+func TLS(cfg *tls.Config) Option {
+	return func(s *Server) {
+		s.server.TLSConfig = cfg
+	}
+}
@@ -0,0 +1,28 @@
+package httpserver
+
+import (
+	"net"
+	"net/http"
+)
+
+// RedirectHTTPS sends plain HTTP requests to the same URL over HTTPS
+// served on httpsPort. Port 443 is left out of the redirect target.
+func RedirectHTTPS(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		if httpsPort != "" && httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		target := *r.URL
+		target.Scheme = "https"
+		target.Host = host
+
+		// 308 keeps the method and body of API calls.
+		http.Redirect(w, r, target.String(), http.StatusPermanentRedirect)
+	})
+}
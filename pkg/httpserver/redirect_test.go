@@ -0,0 +1,59 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRedirectHTTPS(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		port      string
+		method    string
+		url       string
+		expectURL string
+	}{
+		{
+			name:      "Default port",
+			port:      "443",
+			method:    http.MethodGet,
+			url:       "http://sho.rt/abc123?ref=mail",
+			expectURL: "https://sho.rt/abc123?ref=mail",
+		},
+		{
+			name:      "Custom port",
+			port:      "8443",
+			method:    http.MethodPost,
+			url:       "http://localhost:8080/api/v1/url",
+			expectURL: "https://localhost:8443/api/v1/url",
+		},
+		{
+			name:      "IPv6 host",
+			port:      "8443",
+			method:    http.MethodGet,
+			url:       "http://[::1]:8080/abc123",
+			expectURL: "https://[::1]:8443/abc123",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			RedirectHTTPS(tc.port).ServeHTTP(w, httptest.NewRequest(tc.method, tc.url, http.NoBody))
+
+			if w.Code != http.StatusPermanentRedirect {
+				t.Errorf("expected status %d; got %d", http.StatusPermanentRedirect, w.Code)
+			}
+
+			if location := w.Header().Get("Location"); location != tc.expectURL {
+				t.Errorf("expected location %q; got %q", tc.expectURL, location)
+			}
+		})
+	}
+}
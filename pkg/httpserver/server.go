@@ -47,7 +47,12 @@ func New(handler http.Handler, opts ...Option) *Server {
 
 func (s *Server) start() {
 	go func() {
-		s.notify <- s.server.ListenAndServe()
+		if s.server.TLSConfig != nil {
+			s.notify <- s.server.ListenAndServeTLS("", "")
+		} else {
+			s.notify <- s.server.ListenAndServe()
+		}
+
 		close(s.notify)
 	}()
 }
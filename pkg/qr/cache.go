@@ -0,0 +1,71 @@
+package qr
+
+import (
+	"container/list"
+	"sync"
+)
+
+const _defaultCacheSize = 256
+
+type cached struct {
+	key   string
+	image []byte
+}
+
+// Cache keeps the most recently used rendered images.
+type Cache struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// NewCache returns a cache holding up to size images.
+func NewCache(size int) *Cache {
+	if size <= 0 {
+		size = _defaultCacheSize
+	}
+
+	return &Cache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+// Get -.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+
+	return e.Value.(*cached).image, true
+}
+
+// Add stores image under key, evicting the least recently used image when full.
+func (c *Cache) Add(key string, image []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*cached).image = image
+		c.order.MoveToFront(e)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cached{key: key, image: image})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cached).key)
+	}
+}
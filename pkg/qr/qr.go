@@ -0,0 +1,193 @@
+// Package qr renders QR codes as PNG or SVG images.
+package qr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// Format -.
+type Format string
+
+const (
+	PNG Format = "png"
+	SVG Format = "svg"
+)
+
+// Level of error correction, from L (about 7% of the code may be damaged) to H (about 30%).
+type Level string
+
+const (
+	L Level = "L"
+	M Level = "M"
+	Q Level = "Q"
+	H Level = "H"
+)
+
+var levels = map[Level]qrcode.RecoveryLevel{
+	L: qrcode.Low,
+	M: qrcode.Medium,
+	Q: qrcode.High,
+	H: qrcode.Highest,
+}
+
+// ErrInvalidColor -.
+var ErrInvalidColor = errors.New("colour must be a #rrggbb hex value")
+
+// Options -.
+type Options struct {
+	Format Format
+	// Size is the width and height of the image in pixels.
+	Size  int
+	Level Level
+	// Margin is the width of the quiet zone around the code in modules.
+	Margin     int
+	Foreground color.RGBA
+	Background color.RGBA
+}
+
+// ContentType of images in format f.
+func (f Format) ContentType() string {
+	if f == SVG {
+		return "image/svg+xml"
+	}
+
+	return "image/png"
+}
+
+// Valid -.
+func (f Format) Valid() bool {
+	return f == PNG || f == SVG
+}
+
+// Valid -.
+func (l Level) Valid() bool {
+	_, ok := levels[l]
+	return ok
+}
+
+// ParseColor parses a #rrggbb hex colour; the leading # is optional.
+func ParseColor(s string) (color.RGBA, error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return color.RGBA{}, ErrInvalidColor
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return color.RGBA{}, ErrInvalidColor
+	}
+
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, nil
+}
+
+// Encode renders content as a QR code image.
+func Encode(content string, opts Options) ([]byte, error) {
+	level, ok := levels[opts.Level]
+	if !ok {
+		return nil, fmt.Errorf("unknown error correction level %q", opts.Level)
+	}
+
+	code, err := qrcode.New(content, level)
+	if err != nil {
+		return nil, err
+	}
+
+	code.DisableBorder = true
+	modules := withMargin(code.Bitmap(), opts.Margin)
+
+	switch opts.Format {
+	case PNG:
+		return encodePNG(modules, opts)
+	case SVG:
+		return encodeSVG(modules, opts), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", opts.Format)
+	}
+}
+
+func withMargin(bitmap [][]bool, margin int) [][]bool {
+	n := len(bitmap) + 2*margin
+
+	modules := make([][]bool, n)
+	for y := range modules {
+		modules[y] = make([]bool, n)
+	}
+
+	for y, row := range bitmap {
+		copy(modules[y+margin][margin:], row)
+	}
+
+	return modules
+}
+
+func encodePNG(modules [][]bool, opts Options) ([]byte, error) {
+	n := len(modules)
+
+	size := opts.Size
+	if size < n {
+		size = n
+	}
+
+	// Whole pixels per module keep the edges sharp; the remainder pads the margin.
+	scale := size / n
+	offset := (size - scale*n) / 2
+
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{opts.Background, opts.Foreground})
+
+	for y, row := range modules {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex(offset+x*scale+dx, offset+y*scale+dy, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodeSVG(modules [][]bool, opts Options) []byte {
+	n := len(modules)
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		opts.Size, opts.Size, n, n)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="%s"/>`, n, n, hex(opts.Background))
+	fmt.Fprintf(&buf, `<path fill="%s" d="`, hex(opts.Foreground))
+
+	for y, row := range modules {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&buf, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	buf.WriteString(`"/></svg>`)
+
+	return buf.Bytes()
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
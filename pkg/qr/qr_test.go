@@ -0,0 +1,101 @@
+package qr
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+var (
+	black = color.RGBA{A: 0xff}
+	white = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+func TestEncode_PNG(t *testing.T) {
+	t.Parallel()
+
+	data, err := Encode("http://localhost:8080/token", Options{
+		Format: PNG, Size: 256, Level: M, Margin: 4, Foreground: black, Background: white,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b := img.Bounds(); b.Dx() != 256 || b.Dy() != 256 {
+		t.Fatalf("expected 256x256 image; got %dx%d", b.Dx(), b.Dy())
+	}
+
+	if r, g, b, _ := img.At(0, 0).RGBA(); r != 0xffff || g != 0xffff || b != 0xffff {
+		t.Error("expected the quiet zone to use the background colour")
+	}
+}
+
+func TestEncode_SVG(t *testing.T) {
+	t.Parallel()
+
+	red, err := ParseColor("#FF0000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := Encode("http://localhost:8080/token", Options{
+		Format: SVG, Size: 128, Level: H, Margin: 0, Foreground: red, Background: white,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svg := string(data)
+	for _, want := range []string{`width="128"`, `fill="#ff0000"`, `fill="#ffffff"`, "M0 0h1v1h-1z"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("expected svg to contain %s", want)
+		}
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "#fff", "#gggggg", "#0000000"} {
+		if _, err := ParseColor(s); err == nil {
+			t.Errorf("expected %q to be rejected", s)
+		}
+	}
+
+	c, err := ParseColor("1a2b3c")
+	if err != nil || c != (color.RGBA{R: 0x1a, G: 0x2b, B: 0x3c, A: 0xff}) {
+		t.Errorf("unexpected colour %v, %v", c, err)
+	}
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	c := NewCache(2)
+	c.Add("a", []byte("a"))
+	c.Add("b", []byte("b"))
+
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+
+	// b is now the least recently used image.
+	c.Add("c", []byte("c"))
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+
+	for _, key := range []string{"a", "c"} {
+		if image, ok := c.Get(key); !ok || string(image) != key {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package throttle
+
+import "time"
+
+// Option -.
+type Option func(*Throttle)
+
+// MaxFailures -.
+func MaxFailures(n int) Option {
+	return func(t *Throttle) {
+		t.maxFailures = n
+	}
+}
+
+// Window -.
+func Window(window time.Duration) Option {
+	return func(t *Throttle) {
+		t.window = window
+	}
+}
@@ -0,0 +1,104 @@
+// Package throttle counts failed attempts per key and blocks keys that fail too often.
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	_defaultMaxFailures = 5
+	_defaultWindow      = 15 * time.Minute
+	_pruneThreshold     = 1024
+)
+
+type entry struct {
+	failures int
+	start    time.Time
+}
+
+// Throttle -.
+type Throttle struct {
+	maxFailures int
+	window      time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// New -.
+func New(opts ...Option) *Throttle {
+	t := &Throttle{
+		maxFailures: _defaultMaxFailures,
+		window:      _defaultWindow,
+		now:         time.Now,
+		entries:     make(map[string]*entry),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// Blocked reports whether key used up its failures in the current window.
+func (t *Throttle) Blocked(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e := t.get(key)
+
+	return e != nil && e.failures >= t.maxFailures
+}
+
+// Fail records a failed attempt for key.
+func (t *Throttle) Fail(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e := t.get(key)
+	if e == nil {
+		if len(t.entries) >= _pruneThreshold {
+			t.prune()
+		}
+
+		e = &entry{start: t.now()}
+		t.entries[key] = e
+	}
+
+	e.failures++
+}
+
+// Reset forgets the failures of key.
+func (t *Throttle) Reset(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.entries, key)
+}
+
+func (t *Throttle) get(key string) *entry {
+	e, ok := t.entries[key]
+	if !ok {
+		return nil
+	}
+
+	if t.now().Sub(e.start) >= t.window {
+		delete(t.entries, key)
+		return nil
+	}
+
+	return e
+}
+
+func (t *Throttle) prune() {
+	now := t.now()
+
+	for key, e := range t.entries {
+		if now.Sub(e.start) >= t.window {
+			delete(t.entries, key)
+		}
+	}
+}
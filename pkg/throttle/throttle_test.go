@@ -0,0 +1,64 @@
+package throttle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThrottle(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	th := New(MaxFailures(3), Window(time.Minute))
+	th.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if th.Blocked("token") {
+			t.Fatalf("blocked after %d failures", i)
+		}
+
+		th.Fail("token")
+	}
+
+	if !th.Blocked("token") {
+		t.Error("expected token to be blocked after 3 failures")
+	}
+
+	if th.Blocked("other") {
+		t.Error("failures of one key must not block another")
+	}
+
+	now = now.Add(time.Minute)
+
+	if th.Blocked("token") {
+		t.Error("expected token to be unblocked after the window passed")
+	}
+
+	th.Fail("token")
+	th.Reset("token")
+
+	if th.Blocked("token") || len(th.entries) != 0 {
+		t.Error("expected Reset to forget failures")
+	}
+}
+
+func TestThrottle_Prune(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	th := New(Window(time.Minute))
+	th.now = func() time.Time { return now }
+
+	for i := 0; i < _pruneThreshold; i++ {
+		th.Fail(string(rune('a' + i)))
+	}
+
+	now = now.Add(time.Hour)
+	th.Fail("fresh")
+
+	if len(th.entries) != 1 {
+		t.Errorf("expected stale entries to be pruned, got %d entries", len(th.entries))
+	}
+}
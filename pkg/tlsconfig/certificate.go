@@ -0,0 +1,108 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Certificate is a key pair read from PEM files that can be reloaded
+// while servers keep using it, e.g. after the certificate was renewed.
+type Certificate struct {
+	certFile string
+	keyFile  string
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	modTimes [2]time.Time
+
+	done chan struct{}
+	once sync.Once
+}
+
+// NewCertificate loads the key pair from certFile and keyFile.
+func NewCertificate(certFile, keyFile string) (*Certificate, error) {
+	c := &Certificate{
+		certFile: certFile,
+		keyFile:  keyFile,
+		done:     make(chan struct{}),
+	}
+
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Reload re-reads the key pair if either file changed since the last load.
+func (c *Certificate) Reload() error {
+	var modTimes [2]time.Time
+
+	for i, path := range []string{c.certFile, c.keyFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("tlsconfig - Certificate - os.Stat: %w", err)
+		}
+
+		modTimes[i] = info.ModTime()
+	}
+
+	c.mu.RLock()
+	unchanged := c.cert != nil && modTimes == c.modTimes
+	c.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("tlsconfig - Certificate - tls.LoadX509KeyPair: %w", err)
+	}
+
+	c.mu.Lock()
+	c.cert = &cert
+	c.modTimes = modTimes
+	c.mu.Unlock()
+
+	return nil
+}
+
+// Watch reloads the key pair every interval until Close is called.
+// Reload errors keep the previously loaded pair in place.
+func (c *Certificate) Watch(interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.done:
+				return
+			case <-ticker.C:
+				if err := c.Reload(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+}
+
+// GetCertificate hands the current key pair to the TLS handshake.
+func (c *Certificate) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.cert, nil
+}
+
+// Close stops watching the files.
+func (c *Certificate) Close() {
+	c.once.Do(func() {
+		close(c.done)
+	})
+}
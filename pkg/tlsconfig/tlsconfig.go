@@ -0,0 +1,65 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrNoCertificates is returned for CA files without PEM certificates.
+var ErrNoCertificates = errors.New("no certificates found")
+
+// ClientAuth says whether clients have to present a certificate.
+type ClientAuth int
+
+const (
+	// NoClientCert does not ask clients for certificates.
+	NoClientCert ClientAuth = iota
+	// VerifyClientCertIfGiven verifies certificates clients present but lets
+	// clients without one through, so handlers can decide per route.
+	VerifyClientCertIfGiven
+	// RequireClientCert rejects clients without a valid certificate.
+	RequireClientCert
+)
+
+// Server returns the TLS configuration of a server presenting cert.
+// Client certificates are verified against clientCAs.
+func Server(cert *Certificate, clientCAs *x509.CertPool, auth ClientAuth) *tls.Config {
+	cfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: cert.GetCertificate,
+	}
+
+	if clientCAs == nil {
+		return cfg
+	}
+
+	cfg.ClientCAs = clientCAs
+
+	switch auth {
+	case NoClientCert:
+	case VerifyClientCertIfGiven:
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+	case RequireClientCert:
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return cfg
+}
+
+// LoadCertPool reads the PEM certificates in path.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("tlsconfig - LoadCertPool - os.ReadFile: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("tlsconfig - LoadCertPool: %w in %s", ErrNoCertificates, path)
+	}
+
+	return pool, nil
+}
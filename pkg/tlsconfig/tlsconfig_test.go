@@ -0,0 +1,216 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+// issue creates a certificate for name signed by parent, or a self-signed CA without one.
+func issue(t *testing.T, name string, parent *testCert) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+func (c *testCert) write(t *testing.T, dir, name string) (certFile, keyFile string) {
+	t.Helper()
+
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func (c *testCert) tls() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+func TestCertificate_Reload(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ca := issue(t, "ca", nil)
+
+	certFile, keyFile := issue(t, "localhost", ca).write(t, dir, "server")
+
+	cert, err := NewCertificate(certFile, keyFile)
+	require.NoError(t, err)
+	defer cert.Close()
+
+	first, err := cert.GetCertificate(nil)
+	require.NoError(t, err)
+
+	// Unchanged files are not read again.
+	require.NoError(t, cert.Reload())
+	same, _ := cert.GetCertificate(nil)
+	require.Same(t, first, same)
+
+	renewed := issue(t, "localhost", ca)
+	renewed.write(t, dir, "server")
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+
+	cert.Watch(10*time.Millisecond, func(err error) { t.Error(err) })
+
+	require.Eventually(t, func() bool {
+		current, _ := cert.GetCertificate(nil)
+		return string(current.Certificate[0]) == string(renewed.der)
+	}, time.Second, 10*time.Millisecond)
+
+	// A broken pair keeps the last good one in place.
+	require.NoError(t, os.WriteFile(keyFile, []byte("garbage"), 0o600))
+	require.NoError(t, os.Chtimes(keyFile, later.Add(time.Minute), later.Add(time.Minute)))
+	require.Error(t, cert.Reload())
+
+	current, _ := cert.GetCertificate(nil)
+	require.Equal(t, renewed.der, current.Certificate[0])
+}
+
+func TestServer_ClientCertificates(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ca := issue(t, "ca", nil)
+	other := issue(t, "other-ca", nil)
+
+	certFile, keyFile := issue(t, "localhost", ca).write(t, dir, "server")
+	caFile, _ := ca.write(t, dir, "ca")
+
+	cert, err := NewCertificate(certFile, keyFile)
+	require.NoError(t, err)
+
+	clientCAs, err := LoadCertPool(caFile)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	testCases := []struct {
+		name        string
+		auth        ClientAuth
+		clientCert  *testCert
+		expectError bool
+	}{
+		{name: "Required and given", auth: RequireClientCert, clientCert: issue(t, "client", ca)},
+		{name: "Required and missing", auth: RequireClientCert, expectError: true},
+		{name: "Required and untrusted", auth: RequireClientCert, clientCert: issue(t, "client", other), expectError: true},
+		{name: "Optional and missing", auth: VerifyClientCertIfGiven},
+		{name: "Optional and untrusted", auth: VerifyClientCertIfGiven, clientCert: issue(t, "client", other), expectError: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientCfg := &tls.Config{RootCAs: roots, ServerName: "localhost", MinVersion: tls.VersionTLS12}
+			if tc.clientCert != nil {
+				clientCert := tc.clientCert.tls()
+
+				// Present the certificate even if the server does not name its issuer.
+				clientCfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+					return &clientCert, nil
+				}
+			}
+
+			listener, err := tls.Listen("tcp", "127.0.0.1:0", Server(cert, clientCAs, tc.auth))
+			require.NoError(t, err)
+			defer listener.Close()
+
+			serverErr := make(chan error, 1)
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					serverErr <- err
+					return
+				}
+				defer conn.Close()
+
+				serverErr <- conn.(*tls.Conn).Handshake()
+			}()
+
+			client, err := tls.Dial("tcp", listener.Addr().String(), clientCfg)
+			if err == nil {
+				defer client.Close()
+			}
+
+			if tc.expectError {
+				require.Error(t, <-serverErr)
+			} else {
+				require.NoError(t, err)
+				require.NoError(t, <-serverErr)
+			}
+		})
+	}
+}
+
+func TestLoadCertPool(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "empty.pem")
+	require.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0o600))
+
+	_, err := LoadCertPool(path)
+	require.ErrorIs(t, err, ErrNoCertificates)
+
+	_, err = LoadCertPool(filepath.Join(dir, "missing.pem"))
+	require.Error(t, err)
+}
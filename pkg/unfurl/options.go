@@ -0,0 +1,34 @@
+package unfurl
+
+import "time"
+
+// Option -.
+type Option func(*Fetcher)
+
+// Timeout bounds a whole fetch, redirects and body included.
+func Timeout(timeout time.Duration) Option {
+	return func(f *Fetcher) {
+		f.timeout = timeout
+	}
+}
+
+// MaxBodySize caps the bytes of a page that are read.
+func MaxBodySize(n int64) Option {
+	return func(f *Fetcher) {
+		f.maxBodySize = n
+	}
+}
+
+// UserAgent -.
+func UserAgent(userAgent string) Option {
+	return func(f *Fetcher) {
+		f.userAgent = userAgent
+	}
+}
+
+// AllowPrivate lets the fetcher connect to private addresses. Tests only.
+func AllowPrivate() Option {
+	return func(f *Fetcher) {
+		f.allowPrivate = true
+	}
+}
@@ -0,0 +1,258 @@
+// Package unfurl fetches web pages and extracts the title, description,
+// image and favicon they advertise for link previews.
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/urlcheck"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	_defaultTimeout     = 5 * time.Second
+	_defaultMaxBodySize = 1 << 20
+	_defaultUserAgent   = "ShortLinkAPI-Unfurl/1.0"
+	_maxRedirects       = 5
+)
+
+var (
+	ErrNotHTML        = errors.New("page is not html")
+	ErrBadStatus      = errors.New("page responded with an error status")
+	ErrTooManyHops    = errors.New("page redirects too often")
+	ErrUnsupportedURL = errors.New("only http and https pages are fetched")
+)
+
+// Page is what a page advertises about itself. Image and Favicon are absolute URLs.
+type Page struct {
+	Title       string
+	Description string
+	Image       string
+	Favicon     string
+}
+
+// Fetcher -.
+type Fetcher struct {
+	timeout      time.Duration
+	maxBodySize  int64
+	userAgent    string
+	allowPrivate bool
+
+	client *http.Client
+}
+
+// New -.
+func New(opts ...Option) *Fetcher {
+	f := &Fetcher{
+		timeout:     _defaultTimeout,
+		maxBodySize: _defaultMaxBodySize,
+		userAgent:   _defaultUserAgent,
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	dialer := &net.Dialer{Timeout: f.timeout}
+	if !f.allowPrivate {
+		dialer.Control = urlcheck.DenyPrivate
+	}
+
+	f.client = &http.Client{
+		Timeout: f.timeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   f.timeout,
+			ResponseHeaderTimeout: f.timeout,
+			MaxIdleConns:          16,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= _maxRedirects {
+				return ErrTooManyHops
+			}
+
+			return checkScheme(req.URL)
+		},
+	}
+
+	return f
+}
+
+func checkScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ErrUnsupportedURL
+	}
+
+	return nil
+}
+
+// Fetch downloads the page at rawURL and reads its metadata.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Page, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkScheme(u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", f.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.1")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("%w: %d", ErrBadStatus, resp.StatusCode)
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("%w: %q", ErrNotHTML, mediaType)
+	}
+
+	// The final URL after redirects is what relative links resolve against.
+	return parse(io.LimitReader(resp.Body, f.maxBodySize), resp.Request.URL)
+}
+
+// parse reads the head of an HTML document; the body is never needed.
+func parse(r io.Reader, base *url.URL) (*Page, error) {
+	page := &Page{}
+
+	var (
+		ogTitle, ogDescription, description string
+		inTitle                             bool
+	)
+
+	tokenizer := html.NewTokenizer(r)
+
+loop:
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+
+			break loop
+		case html.TextToken:
+			if inTitle && page.Title == "" {
+				page.Title = strings.TrimSpace(string(tokenizer.Text()))
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+
+			switch atom.Lookup(name) {
+			case atom.Title:
+				inTitle = false
+			case atom.Head:
+				break loop
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			attrs := attributes(tokenizer, hasAttr)
+
+			switch atom.Lookup(name) {
+			case atom.Title:
+				inTitle = true
+			case atom.Body:
+				break loop
+			case atom.Meta:
+				key := strings.ToLower(attrs["property"])
+				if key == "" {
+					key = strings.ToLower(attrs["name"])
+				}
+
+				content := strings.TrimSpace(attrs["content"])
+
+				switch key {
+				case "og:title":
+					ogTitle = content
+				case "og:description":
+					ogDescription = content
+				case "description":
+					description = content
+				case "og:image", "og:image:url", "og:image:secure_url":
+					if page.Image == "" {
+						page.Image = resolve(base, content)
+					}
+				}
+			case atom.Link:
+				if page.Favicon == "" && isIcon(attrs["rel"]) {
+					page.Favicon = resolve(base, attrs["href"])
+				}
+			}
+		}
+	}
+
+	if ogTitle != "" {
+		page.Title = ogTitle
+	}
+
+	page.Description = ogDescription
+	if page.Description == "" {
+		page.Description = description
+	}
+
+	if page.Favicon == "" {
+		page.Favicon = resolve(base, "/favicon.ico")
+	}
+
+	return page, nil
+}
+
+func attributes(tokenizer *html.Tokenizer, more bool) map[string]string {
+	attrs := make(map[string]string)
+
+	for more {
+		var key, value []byte
+		key, value, more = tokenizer.TagAttr()
+		attrs[string(key)] = string(value)
+	}
+
+	return attrs
+}
+
+func isIcon(rel string) bool {
+	for _, r := range strings.Fields(strings.ToLower(rel)) {
+		if r == "icon" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolve makes ref absolute against base, dropping anything that is not http(s).
+func resolve(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+
+	u, err := base.Parse(ref)
+	if err != nil || checkScheme(u) != nil {
+		return ""
+	}
+
+	return u.String()
+}
@@ -0,0 +1,136 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/urlcheck"
+)
+
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<title> Plain title </title>
+<meta name="description" content="Plain description">
+<meta property="og:title" content="Open Graph title">
+<meta property="og:image" content="/images/card.png">
+<link rel="shortcut icon" href="/static/icon.png">
+</head>
+<body><meta property="og:description" content="not in the head"></body>
+</html>`
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/page", http.StatusFound)
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, page)
+		case "/bare":
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, "<title>Bare</title>")
+		case "/data":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, "{}")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := New(AllowPrivate())
+
+	got, err := fetcher.Fetch(context.Background(), server.URL+"/moved")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Page{
+		Title:       "Open Graph title",
+		Description: "Plain description",
+		Image:       server.URL + "/images/card.png",
+		Favicon:     server.URL + "/static/icon.png",
+	}
+	if *got != *want {
+		t.Errorf("expected %+v; got %+v", want, got)
+	}
+
+	got, err = fetcher.Fetch(context.Background(), server.URL+"/bare")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Title != "Bare" || got.Favicon != server.URL+"/favicon.ico" {
+		t.Errorf("unexpected page %+v", got)
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/data"); !errors.Is(err, ErrNotHTML) {
+		t.Errorf("expected ErrNotHTML; got %v", err)
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/missing"); !errors.Is(err, ErrBadStatus) {
+		t.Errorf("expected ErrBadStatus; got %v", err)
+	}
+}
+
+func TestFetch_PrivateAddress(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the fetcher must not reach a loopback server")
+	}))
+	defer server.Close()
+
+	_, err := New().Fetch(context.Background(), server.URL)
+	if !errors.Is(err, urlcheck.ErrPrivateAddress) {
+		t.Errorf("expected ErrPrivateAddress; got %v", err)
+	}
+}
+
+func TestFetch_Limits(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		case "/large":
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, "<head>"+strings.Repeat("<!-- padding -->", 1024)+"<title>Too late</title></head>")
+		case "/ftp":
+			http.Redirect(w, r, "ftp://example.com/file", http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := New(AllowPrivate(), Timeout(100*time.Millisecond), MaxBodySize(1024))
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/slow"); err == nil {
+		t.Error("expected the slow page to time out")
+	}
+
+	got, err := fetcher.Fetch(context.Background(), server.URL+"/large")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Title != "" {
+		t.Errorf("expected the title past the size limit to be ignored; got %q", got.Title)
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/ftp"); !errors.Is(err, ErrUnsupportedURL) {
+		t.Errorf("expected ErrUnsupportedURL; got %v", err)
+	}
+}
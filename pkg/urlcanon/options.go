@@ -0,0 +1,35 @@
+package urlcanon
+
+import "strings"
+
+// Option -.
+type Option func(*Canonicalizer)
+
+// StripParams removes the named query parameters. A trailing '*' matches by prefix, e.g. "utm_*".
+func StripParams(params ...string) Option {
+	return func(c *Canonicalizer) {
+		for _, p := range params {
+			p = strings.ToLower(p)
+			if prefix, ok := strings.CutSuffix(p, "*"); ok {
+				c.stripPrefixes = append(c.stripPrefixes, prefix)
+				continue
+			}
+
+			c.stripParams[p] = struct{}{}
+		}
+	}
+}
+
+// TrimTrailingSlash -.
+func TrimTrailingSlash(trim bool) Option {
+	return func(c *Canonicalizer) {
+		c.trimSlash = trim
+	}
+}
+
+// SortQuery -.
+func SortQuery(sortQuery bool) Option {
+	return func(c *Canonicalizer) {
+		c.sortQuery = sortQuery
+	}
+}
@@ -0,0 +1,198 @@
+// Package urlcanon reduces equivalent URLs to a single canonical form.
+package urlcanon
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+var _defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// Canonicalizer -.
+type Canonicalizer struct {
+	stripParams   map[string]struct{}
+	stripPrefixes []string
+	trimSlash     bool
+	sortQuery     bool
+}
+
+// New -.
+func New(opts ...Option) *Canonicalizer {
+	c := &Canonicalizer{
+		stripParams: make(map[string]struct{}),
+		trimSlash:   true,
+		sortQuery:   true,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Canonicalize returns a normalized copy of u: lowercase scheme and host, IDN hosts in punycode,
+// no default port, normalized percent-encoding, tracking parameters removed and the query sorted.
+func (c *Canonicalizer) Canonicalize(u *url.URL) (*url.URL, error) {
+	out := *u
+	out.Scheme = strings.ToLower(u.Scheme)
+
+	if u.Opaque != "" || u.Host == "" {
+		return &out, nil
+	}
+
+	host, err := canonicalHost(u.Hostname())
+	if err != nil {
+		return nil, err
+	}
+
+	if port := u.Port(); port != "" && port != _defaultPorts[out.Scheme] {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), port)
+	}
+
+	out.Host = host
+
+	path := normalizeEscapes(u.EscapedPath())
+	if c.trimSlash && len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+
+	if path == "" {
+		path = "/"
+	}
+
+	if out.Path, err = url.PathUnescape(path); err != nil {
+		return nil, fmt.Errorf("urlcanon - Canonicalize - url.PathUnescape: %w", err)
+	}
+
+	out.RawPath = path
+	out.RawQuery = c.query(u.RawQuery)
+	out.ForceQuery = false
+
+	return &out, nil
+}
+
+func canonicalHost(hostname string) (string, error) {
+	if ip := net.ParseIP(hostname); ip != nil {
+		if ip.To4() == nil {
+			return "[" + ip.String() + "]", nil
+		}
+
+		return ip.String(), nil
+	}
+
+	host, err := idna.Lookup.ToASCII(strings.TrimSuffix(hostname, "."))
+	if err != nil {
+		return "", fmt.Errorf("urlcanon - Canonicalize - idna.ToASCII: %w", err)
+	}
+
+	return strings.ToLower(host), nil
+}
+
+func (c *Canonicalizer) query(raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	params := strings.Split(raw, "&")
+	kept := params[:0]
+
+	for _, param := range params {
+		if param == "" {
+			continue
+		}
+
+		param = normalizeEscapes(param)
+
+		key, _, _ := strings.Cut(param, "=")
+		if name, err := url.QueryUnescape(key); err == nil && c.stripped(name) {
+			continue
+		}
+
+		kept = append(kept, param)
+	}
+
+	if c.sortQuery {
+		sort.SliceStable(kept, func(i, j int) bool {
+			ki, _, _ := strings.Cut(kept[i], "=")
+			kj, _, _ := strings.Cut(kept[j], "=")
+
+			return ki < kj
+		})
+	}
+
+	return strings.Join(kept, "&")
+}
+
+func (c *Canonicalizer) stripped(name string) bool {
+	name = strings.ToLower(name)
+	if _, ok := c.stripParams[name]; ok {
+		return true
+	}
+
+	for _, prefix := range c.stripPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeEscapes decodes percent-encoded unreserved characters and uppercases the remaining escapes.
+func normalizeEscapes(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+
+	var b strings.Builder
+
+	b.Grow(len(s))
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' || i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		ch := unhex(s[i+1])<<4 | unhex(s[i+2])
+		if isUnreserved(ch) {
+			b.WriteByte(ch)
+		} else {
+			b.WriteByte('%')
+			b.WriteString(strings.ToUpper(s[i+1 : i+3]))
+		}
+
+		i += 2
+	}
+
+	return b.String()
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
@@ -0,0 +1,92 @@
+package urlcanon
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	t.Parallel()
+
+	c := New(StripParams("utm_*", "fbclid", "GCLID"))
+
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://Example.com/a", "https://example.com/a"},
+		{"https://example.com/a/", "https://example.com/a"},
+		{"https://example.com/a?utm_source=x&utm_medium=y", "https://example.com/a"},
+		{"HTTPS://EXAMPLE.COM:443", "https://example.com/"},
+		{"http://example.com:80/", "http://example.com/"},
+		{"http://example.com:8080/", "http://example.com:8080/"},
+		{"https://example.com/%7euser/%e2%82%ac", "https://example.com/~user/%E2%82%AC"},
+		{"https://example.com/?b=2&a=1&fbclid=abc&a=0", "https://example.com/?a=1&a=0&b=2"},
+		{"https://example.com/search?q=a%20b&gclid=1", "https://example.com/search?q=a%20b"},
+		{"https://пример.рф/путь", "https://xn--e1afmkfd.xn--p1ai/%D0%BF%D1%83%D1%82%D1%8C"},
+		{"https://example.com./a#Section", "https://example.com/a#Section"},
+		{"http://[::1]:80/", "http://[::1]/"},
+		{"https://user@example.com/", "https://user@example.com/"},
+	}
+
+	for _, tc := range tests {
+		u, err := url.Parse(tc.url)
+		if err != nil {
+			t.Fatalf("could not parse %q: %v", tc.url, err)
+		}
+
+		canonical, err := c.Canonicalize(u)
+		if err != nil {
+			t.Errorf("Canonicalize(%q) returned error: %v", tc.url, err)
+			continue
+		}
+
+		if canonical.String() != tc.expected {
+			t.Errorf("Canonicalize(%q) = %q, expected %q", tc.url, canonical.String(), tc.expected)
+		}
+	}
+}
+
+func TestCanonicalize_Options(t *testing.T) {
+	t.Parallel()
+
+	c := New(TrimTrailingSlash(false), SortQuery(false))
+
+	u, _ := url.Parse("https://Example.com/a/?utm_source=x&b=1&a=2")
+
+	canonical, err := c.Canonicalize(u)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := "https://example.com/a/?utm_source=x&b=1&a=2"; canonical.String() != expected {
+		t.Errorf("expected %q, got %q", expected, canonical.String())
+	}
+}
+
+func TestCanonicalize_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	c := New(StripParams("utm_*"))
+
+	for _, raw := range []string{
+		"https://Example.com/A%2fb/?z=1&utm_campaign=c&y=%41",
+		"https://пример.рф/?q=привет",
+	} {
+		u, _ := url.Parse(raw)
+
+		first, err := c.Canonicalize(u)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		second, err := c.Canonicalize(first)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if first.String() != second.String() {
+			t.Errorf("canonical form of %q is not stable: %q != %q", raw, first, second)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package urlcheck
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DenyList rejects links to listed domains and their subdomains.
+// The list is read from a file with one domain per line, '#' starts a comment.
+type DenyList struct {
+	path string
+
+	mu      sync.RWMutex
+	domains map[string]struct{}
+	modTime time.Time
+
+	done chan struct{}
+	once sync.Once
+	wg   sync.WaitGroup
+}
+
+// NewDenyList loads the list from path.
+func NewDenyList(path string) (*DenyList, error) {
+	dl := &DenyList{
+		path: path,
+		done: make(chan struct{}),
+	}
+
+	if err := dl.Reload(); err != nil {
+		return nil, err
+	}
+
+	return dl, nil
+}
+
+// Check -.
+func (dl *DenyList) Check(_ context.Context, u *url.URL) error {
+	host := normalizeHost(u.Hostname())
+
+	dl.mu.RLock()
+	defer dl.mu.RUnlock()
+
+	for domain := host; domain != ""; {
+		if _, ok := dl.domains[domain]; ok {
+			return fmt.Errorf("%w: %s", ErrDomainDenied, domain)
+		}
+
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			break
+		}
+
+		domain = domain[i+1:]
+	}
+
+	return nil
+}
+
+// Reload re-reads the file if it changed since the last load.
+func (dl *DenyList) Reload() error {
+	info, err := os.Stat(dl.path)
+	if err != nil {
+		return fmt.Errorf("urlcheck - DenyList - os.Stat: %w", err)
+	}
+
+	dl.mu.RLock()
+	unchanged := !dl.modTime.IsZero() && info.ModTime().Equal(dl.modTime)
+	dl.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	f, err := os.Open(dl.path)
+	if err != nil {
+		return fmt.Errorf("urlcheck - DenyList - os.Open: %w", err)
+	}
+	defer f.Close()
+
+	domains, err := parseDenyList(f)
+	if err != nil {
+		return fmt.Errorf("urlcheck - DenyList - parse: %w", err)
+	}
+
+	dl.mu.Lock()
+	dl.domains = domains
+	dl.modTime = info.ModTime()
+	dl.mu.Unlock()
+
+	return nil
+}
+
+// Watch reloads the list every interval until Close is called.
+// Reload errors keep the previously loaded list in place.
+func (dl *DenyList) Watch(interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+
+	dl.wg.Add(1)
+
+	go func() {
+		defer dl.wg.Done()
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-dl.done:
+				return
+			case <-ticker.C:
+				if err := dl.Reload(); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+}
+
+// Len -.
+func (dl *DenyList) Len() int {
+	dl.mu.RLock()
+	defer dl.mu.RUnlock()
+
+	return len(dl.domains)
+}
+
+// Close stops watching the file and waits for a reload in progress.
+func (dl *DenyList) Close() {
+	dl.once.Do(func() {
+		close(dl.done)
+	})
+	dl.wg.Wait()
+}
+
+func parseDenyList(r io.Reader) (map[string]struct{}, error) {
+	domains := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		line = normalizeHost(strings.TrimSpace(line))
+		if line == "" {
+			continue
+		}
+
+		domains[line] = struct{}{}
+	}
+
+	return domains, scanner.Err()
+}
@@ -0,0 +1,165 @@
+// Package urlcheck implements screening of destination URLs before they are shortened.
+package urlcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+var (
+	ErrSchemeNotAllowed = errors.New("scheme is not allowed")
+	ErrSelfReference    = errors.New("url points to the shortener itself")
+	ErrPrivateAddress   = errors.New("url points to a private address")
+	ErrDomainDenied     = errors.New("domain is on the deny list")
+)
+
+// Checker vetoes a destination URL by returning a non-nil error.
+type Checker interface {
+	Check(ctx context.Context, u *url.URL) error
+}
+
+// CheckerFunc -.
+type CheckerFunc func(ctx context.Context, u *url.URL) error
+
+// Check -.
+func (f CheckerFunc) Check(ctx context.Context, u *url.URL) error {
+	return f(ctx, u)
+}
+
+// Resolver -.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// Pipeline runs checkers in order and stops at the first veto.
+type Pipeline struct {
+	checkers []Checker
+}
+
+// NewPipeline -.
+func NewPipeline(checkers ...Checker) *Pipeline {
+	return &Pipeline{checkers: checkers}
+}
+
+// Use -.
+func (p *Pipeline) Use(checkers ...Checker) {
+	p.checkers = append(p.checkers, checkers...)
+}
+
+// Check -.
+func (p *Pipeline) Check(ctx context.Context, u *url.URL) error {
+	for _, c := range p.checkers {
+		if err := c.Check(ctx, u); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Schemes allows only the listed schemes.
+func Schemes(allowed ...string) Checker {
+	set := make(map[string]struct{}, len(allowed))
+	for _, s := range allowed {
+		set[strings.ToLower(s)] = struct{}{}
+	}
+
+	return CheckerFunc(func(_ context.Context, u *url.URL) error {
+		if _, ok := set[strings.ToLower(u.Scheme)]; !ok {
+			return fmt.Errorf("%w: %q", ErrSchemeNotAllowed, u.Scheme)
+		}
+
+		return nil
+	})
+}
+
+// SelfDomain rejects links to the given hosts so short links cannot redirect to each other in a loop.
+func SelfDomain(hosts ...string) Checker {
+	set := make(map[string]struct{}, len(hosts))
+	for _, h := range hosts {
+		set[normalizeHost(h)] = struct{}{}
+	}
+
+	return CheckerFunc(func(_ context.Context, u *url.URL) error {
+		if _, ok := set[normalizeHost(u.Hostname())]; ok {
+			return fmt.Errorf("%w: %s", ErrSelfReference, u.Hostname())
+		}
+
+		return nil
+	})
+}
+
+// PrivateNetwork rejects loopback, private, link-local and unspecified addresses.
+// When resolver is not nil host names are resolved and every address is checked.
+func PrivateNetwork(resolver Resolver) Checker {
+	return CheckerFunc(func(ctx context.Context, u *url.URL) error {
+		host := normalizeHost(u.Hostname())
+		if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+			return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+		}
+
+		if addr, err := netip.ParseAddr(host); err == nil {
+			if IsPrivateAddr(addr) {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+			}
+
+			return nil
+		}
+
+		if resolver == nil {
+			return nil
+		}
+
+		// Unresolvable hosts are not our concern here: the link simply won't work.
+		addrs, err := resolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return nil
+		}
+
+		for _, addr := range addrs {
+			if IsPrivateAddr(addr) {
+				return fmt.Errorf("%w: %s resolves to %s", ErrPrivateAddress, host, addr)
+			}
+		}
+
+		return nil
+	})
+}
+
+// DenyPrivate is a net.Dialer Control refusing connections to private addresses.
+// Checking the address actually dialled, rather than the host name, also
+// catches names that resolve to a private address only later.
+func DenyPrivate(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if IsPrivateAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, addrPort.Addr())
+	}
+
+	return nil
+}
+
+// IsPrivateAddr reports whether addr must not be reachable through a short link.
+func IsPrivateAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified()
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
@@ -0,0 +1,187 @@
+package urlcheck
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type staticResolver map[string][]netip.Addr
+
+func (r staticResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+
+	return addrs, nil
+}
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	resolver := staticResolver{
+		"intranet.example.com": {netip.MustParseAddr("10.0.0.7")},
+		"example.com":          {netip.MustParseAddr("93.184.216.34")},
+	}
+
+	pipeline := NewPipeline(
+		Schemes("http", "https"),
+		SelfDomain("short.ly"),
+		PrivateNetwork(resolver),
+	)
+
+	tests := []struct {
+		url         string
+		expectedErr error
+	}{
+		{"https://example.com/page", nil},
+		{"HTTP://example.com", nil},
+		{"https://unknown.example.org", nil},
+		{"javascript:alert(1)", ErrSchemeNotAllowed},
+		{"file:///etc/passwd", ErrSchemeNotAllowed},
+		{"data:text/html;base64,PHNjcmlwdD4=", ErrSchemeNotAllowed},
+		{"https://short.ly/url/abc", ErrSelfReference},
+		{"https://SHORT.LY.:8080/url/abc", ErrSelfReference},
+		{"http://127.0.0.1/admin", ErrPrivateAddress},
+		{"http://[::1]:8080", ErrPrivateAddress},
+		{"http://[::ffff:192.168.0.1]", ErrPrivateAddress},
+		{"http://169.254.169.254/latest/meta-data", ErrPrivateAddress},
+		{"http://0.0.0.0", ErrPrivateAddress},
+		{"http://localhost:6379", ErrPrivateAddress},
+		{"http://intranet.example.com", ErrPrivateAddress},
+	}
+
+	for _, tc := range tests {
+		u, err := url.Parse(tc.url)
+		if err != nil {
+			t.Fatalf("could not parse %q: %v", tc.url, err)
+		}
+
+		err = pipeline.Check(context.Background(), u)
+		if !errors.Is(err, tc.expectedErr) {
+			t.Errorf("Check(%q) = %v, expected %v", tc.url, err, tc.expectedErr)
+		}
+	}
+}
+
+func TestPipeline_CustomChecker(t *testing.T) {
+	t.Parallel()
+
+	errBlocked := errors.New("blocked by safe browsing")
+	calls := 0
+
+	pipeline := NewPipeline(Schemes("https"))
+	pipeline.Use(CheckerFunc(func(_ context.Context, u *url.URL) error {
+		calls++
+		if u.Host == "malware.test" {
+			return errBlocked
+		}
+
+		return nil
+	}))
+
+	if err := pipeline.Check(context.Background(), &url.URL{Scheme: "https", Host: "malware.test"}); !errors.Is(err, errBlocked) {
+		t.Errorf("expected %v, got %v", errBlocked, err)
+	}
+
+	if err := pipeline.Check(context.Background(), &url.URL{Scheme: "ftp", Host: "malware.test"}); !errors.Is(err, ErrSchemeNotAllowed) {
+		t.Errorf("expected %v, got %v", ErrSchemeNotAllowed, err)
+	}
+
+	if calls != 1 {
+		t.Errorf("custom checker must not run after a veto, calls = %d", calls)
+	}
+}
+
+func TestDenyList(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "denylist.txt")
+	writeFile(t, path, "# comment\nevil.com\n\n  Phishing.ORG  # inline comment\n", time.Now().Add(-time.Minute))
+
+	dl, err := NewDenyList(path)
+	if err != nil {
+		t.Fatalf("NewDenyList: %v", err)
+	}
+	defer dl.Close()
+
+	tests := []struct {
+		url    string
+		denied bool
+	}{
+		{"https://evil.com", true},
+		{"https://login.evil.com/path", true},
+		{"https://phishing.org", true},
+		{"https://notevil.com", false},
+		{"https://evil.com.example.net", false},
+	}
+
+	for _, tc := range tests {
+		u, _ := url.Parse(tc.url)
+
+		err := dl.Check(context.Background(), u)
+		if denied := errors.Is(err, ErrDomainDenied); denied != tc.denied {
+			t.Errorf("Check(%q) = %v, expected denied = %v", tc.url, err, tc.denied)
+		}
+	}
+
+	if dl.Len() != 2 {
+		t.Errorf("expected 2 domains, got %d", dl.Len())
+	}
+}
+
+func TestDenyList_Watch(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "denylist.txt")
+	writeFile(t, path, "evil.com\n", time.Now().Add(-time.Minute))
+
+	dl, err := NewDenyList(path)
+	if err != nil {
+		t.Fatalf("NewDenyList: %v", err)
+	}
+	defer dl.Close()
+
+	dl.Watch(10*time.Millisecond, func(err error) {
+		t.Errorf("unexpected reload error: %v", err)
+	})
+
+	writeFile(t, path, "evil.com\nbad.net\n", time.Now())
+
+	u, _ := url.Parse("https://bad.net")
+	deadline := time.Now().Add(2 * time.Second)
+
+	for dl.Check(context.Background(), u) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("deny list was not reloaded")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNewDenyList_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewDenyList(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func writeFile(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("could not write %s: %v", path, err)
+	}
+
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("could not touch %s: %v", path, err)
+	}
+}
@@ -0,0 +1,109 @@
+// Package useragent extracts the browser family and operating system from User-Agent headers.
+package useragent
+
+import "strings"
+
+// Browser families.
+const (
+	Chrome  = "chrome"
+	Edge    = "edge"
+	Firefox = "firefox"
+	Opera   = "opera"
+	Safari  = "safari"
+	Bot     = "bot"
+)
+
+// Operating systems.
+const (
+	IOS     = "ios"
+	Android = "android"
+	Windows = "windows"
+	MacOS   = "macos"
+	Linux   = "linux"
+)
+
+// Other is reported for anything that is not recognized.
+const Other = "other"
+
+// Agent -.
+type Agent struct {
+	Browser string
+	OS      string
+}
+
+// Order matters: Edge and Opera announce Chrome, Chrome announces Safari,
+// and iOS browsers carry their own tokens in front of Safari.
+var browsers = []struct {
+	family string
+	tokens []string
+}{
+	{Bot, []string{"bot", "crawler", "spider", "slurp", "facebookexternalhit"}},
+	{Edge, []string{"edg/", "edge/", "edga/", "edgios/"}},
+	{Opera, []string{"opr/", "opera", "opt/"}},
+	{Firefox, []string{"firefox/", "fxios/"}},
+	{Chrome, []string{"chrome/", "crios/", "chromium/"}},
+	{Safari, []string{"safari/"}},
+}
+
+var systems = []struct {
+	os     string
+	tokens []string
+}{
+	{IOS, []string{"iphone", "ipad", "ipod"}},
+	{Android, []string{"android"}},
+	{Windows, []string{"windows"}},
+	{MacOS, []string{"macintosh", "mac os x"}},
+	{Linux, []string{"linux", "x11"}},
+}
+
+// Parse -.
+func Parse(ua string) Agent {
+	ua = strings.ToLower(ua)
+
+	agent := Agent{
+		Browser: Other,
+		OS:      Other,
+	}
+
+	for _, b := range browsers {
+		if containsAny(ua, b.tokens) {
+			agent.Browser = b.family
+
+			break
+		}
+	}
+
+	for _, s := range systems {
+		if containsAny(ua, s.tokens) {
+			agent.OS = s.os
+
+			break
+		}
+	}
+
+	return agent
+}
+
+// previewers fetch links to show a preview card in chats and social feeds.
+// Search engine crawlers are left out on purpose: they should see the redirect.
+var previewers = []string{
+	"slackbot", "slack-imgproxy", "twitterbot", "facebookexternalhit", "facebot",
+	"linkedinbot", "discordbot", "telegrambot", "whatsapp", "skypeuripreview",
+	"pinterestbot", "redditbot", "embedly", "iframely", "vkshare", "mastodon/",
+	"bluesky cardyb", "snap url preview", "google-pagerenderer", "applebot",
+}
+
+// LinkPreviewer reports whether ua belongs to a service unfurling links into preview cards.
+func LinkPreviewer(ua string) bool {
+	return containsAny(strings.ToLower(ua), previewers)
+}
+
+func containsAny(s string, tokens []string) bool {
+	for _, token := range tokens {
+		if strings.Contains(s, token) {
+			return true
+		}
+	}
+
+	return false
+}
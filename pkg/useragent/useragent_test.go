@@ -0,0 +1,94 @@
+package useragent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		ua       string
+		expected Agent
+	}{
+		{
+			name:     "Safari on iPhone",
+			ua:       "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+			expected: Agent{Browser: Safari, OS: IOS},
+		},
+		{
+			name:     "Chrome on iPad",
+			ua:       "Mozilla/5.0 (iPad; CPU OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/118.0.5993.69 Mobile/15E148 Safari/604.1",
+			expected: Agent{Browser: Chrome, OS: IOS},
+		},
+		{
+			name:     "Chrome on Android",
+			ua:       "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Mobile Safari/537.36",
+			expected: Agent{Browser: Chrome, OS: Android},
+		},
+		{
+			name:     "Edge on Windows",
+			ua:       "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36 Edg/118.0.2088.46",
+			expected: Agent{Browser: Edge, OS: Windows},
+		},
+		{
+			name:     "Firefox on macOS",
+			ua:       "Mozilla/5.0 (Macintosh; Intel Mac OS X 14.0; rv:118.0) Gecko/20100101 Firefox/118.0",
+			expected: Agent{Browser: Firefox, OS: MacOS},
+		},
+		{
+			name:     "Opera on Linux",
+			ua:       "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/117.0.0.0 Safari/537.36 OPR/103.0.0.0",
+			expected: Agent{Browser: Opera, OS: Linux},
+		},
+		{
+			name:     "Crawler",
+			ua:       "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			expected: Agent{Browser: Bot, OS: Other},
+		},
+		{
+			name:     "Empty",
+			expected: Agent{Browser: Other, OS: Other},
+		},
+	}
+
+	for _, tc := range testCases {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, test.expected, Parse(test.ua))
+		})
+	}
+}
+
+func TestLinkPreviewer(t *testing.T) {
+	t.Parallel()
+
+	previewers := []string{
+		"Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)",
+		"Twitterbot/1.0",
+		"facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)",
+		"Mozilla/5.0 (compatible; Discordbot/2.0; +https://discordapp.com)",
+		"TelegramBot (like TwitterBot)",
+		"WhatsApp/2.23.20.0",
+		"LinkedInBot/1.0 (compatible; Mozilla/5.0; Apache-HttpClient +http://www.linkedin.com)",
+	}
+
+	for _, ua := range previewers {
+		require.True(t, LinkPreviewer(ua), ua)
+	}
+
+	others := []string{
+		"",
+		"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 14.0; rv:118.0) Gecko/20100101 Firefox/118.0",
+	}
+
+	for _, ua := range others {
+		require.False(t, LinkPreviewer(ua), ua)
+	}
+}
@@ -0,0 +1,14 @@
+package validator
+
+import "regexp"
+
+// Option -.
+type Option func(*Validator)
+
+// Pattern declares the tag that matches strings against re, as in
+// `validate:"alias"` after Pattern("alias", ...).
+func Pattern(tag string, re *regexp.Regexp) Option {
+	return func(v *Validator) {
+		v.patterns[tag] = re
+	}
+}
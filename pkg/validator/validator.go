@@ -0,0 +1,183 @@
+// Package validator checks structs against the rules declared in their
+// `validate` tags and reports every violated rule at once.
+//
+// Besides the rules of github.com/go-playground/validator, fields may use
+// weburl (an absolute URL with a host), scheme (the URL scheme is one of the
+// space-separated parameters) and the tags declared with Pattern.
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Codes of the violated rules reported in apierror.FieldError.
+const (
+	CodeRequired         = "required"
+	CodeTooShort         = "too_short"
+	CodeTooLong          = "too_long"
+	CodeTooFew           = "too_few"
+	CodeTooMany          = "too_many"
+	CodeTooSmall         = "too_small"
+	CodeTooLarge         = "too_large"
+	CodeNotAllowed       = "not_allowed"
+	CodeInvalidURL       = "invalid_url"
+	CodeSchemeNotAllowed = "scheme_not_allowed"
+	CodePatternMismatch  = "pattern_mismatch"
+)
+
+// Validator -.
+type Validator struct {
+	validate *validator.Validate
+	patterns map[string]*regexp.Regexp
+}
+
+// New -.
+func New(opts ...Option) *Validator {
+	v := &Validator{
+		validate: validator.New(validator.WithRequiredStructEnabled()),
+		patterns: make(map[string]*regexp.Regexp),
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	// Fields are reported by the names clients send.
+	v.validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
+
+	_ = v.validate.RegisterValidation("weburl", webURL)
+	_ = v.validate.RegisterValidation("scheme", scheme)
+
+	for tag, re := range v.patterns {
+		re := re
+		_ = v.validate.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+			return re.MatchString(fl.Field().String())
+		})
+	}
+
+	return v
+}
+
+// Struct checks s and returns a bad request listing every invalid field.
+func (v *Validator) Struct(s interface{}) error {
+	err := v.validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var violations validator.ValidationErrors
+	if !errors.As(err, &violations) {
+		return apierror.InternalError(err)
+	}
+
+	errs := make(apierror.FieldErrors, 0, len(violations))
+	for _, violation := range violations {
+		code, message := v.describe(violation)
+		errs = append(errs, &apierror.FieldError{
+			Field: field(violation.Namespace()),
+			Code:  code,
+			Err:   errors.New(message),
+		})
+	}
+
+	return apierror.InvalidFieldsError(errs)
+}
+
+// describe returns the code and the message of a violated rule.
+func (v *Validator) describe(violation validator.FieldError) (string, string) {
+	param := violation.Param()
+
+	switch violation.Tag() {
+	case "required":
+		return CodeRequired, "is required"
+	case "min", "gte":
+		return lowerBound(violation.Kind(), "at least "+param)
+	case "gt":
+		return lowerBound(violation.Kind(), "more than "+param)
+	case "max", "lte":
+		return upperBound(violation.Kind(), "at most "+param)
+	case "lt":
+		return upperBound(violation.Kind(), "less than "+param)
+	case "oneof":
+		return CodeNotAllowed, "must be one of " + strings.Join(strings.Fields(param), ", ")
+	case "weburl":
+		return CodeInvalidURL, "must be an absolute URL"
+	case "scheme":
+		return CodeSchemeNotAllowed, "must use the " + strings.Join(strings.Fields(param), " or ") + " scheme"
+	}
+
+	if re, ok := v.patterns[violation.Tag()]; ok {
+		return CodePatternMismatch, fmt.Sprintf("must match %s", re)
+	}
+
+	return apierror.InvalidCode, "is invalid"
+}
+
+func lowerBound(kind reflect.Kind, bound string) (string, string) {
+	switch kind {
+	case reflect.String:
+		return CodeTooShort, "must have " + bound + " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return CodeTooFew, "must have " + bound + " items"
+	default:
+		return CodeTooSmall, "must be " + bound
+	}
+}
+
+func upperBound(kind reflect.Kind, bound string) (string, string) {
+	switch kind {
+	case reflect.String:
+		return CodeTooLong, "must have " + bound + " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return CodeTooMany, "must have " + bound + " items"
+	default:
+		return CodeTooLarge, "must be " + bound
+	}
+}
+
+// field drops the name of the validated struct from namespace.
+func field(namespace string) string {
+	_, name, found := strings.Cut(namespace, ".")
+	if !found {
+		return namespace
+	}
+
+	return name
+}
+
+func webURL(fl validator.FieldLevel) bool {
+	u, err := url.ParseRequestURI(fl.Field().String())
+
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+func scheme(fl validator.FieldLevel) bool {
+	u, err := url.Parse(fl.Field().String())
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range strings.Fields(fl.Param()) {
+		if strings.EqualFold(u.Scheme, allowed) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,115 @@
+package validator_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	apierror "github.com/CodeMaster482/ShortLinkAPI/pkg/errors"
+	"github.com/CodeMaster482/ShortLinkAPI/pkg/validator"
+
+	"github.com/stretchr/testify/require"
+)
+
+type target struct {
+	URL string `json:"url" validate:"required,weburl,scheme=https"`
+}
+
+type request struct {
+	URL     string        `json:"url" validate:"required,max=32,weburl,scheme=http https"`
+	Alias   string        `json:"alias,omitempty" validate:"omitempty,alias"`
+	TTL     time.Duration `json:"ttl,omitempty" validate:"omitempty,min=1m,max=720h"`
+	Clicks  int64         `json:"clicks,omitempty" validate:"min=0"`
+	Tags    []string      `json:"tags,omitempty" validate:"max=2,dive,min=1"`
+	Mode    string        `json:"mode,omitempty" validate:"omitempty,oneof=drop append"`
+	Targets []target      `json:"targets,omitempty" validate:"dive"`
+	Ignored string        `json:"-" validate:"max=1"`
+}
+
+func TestValidator(t *testing.T) {
+	t.Parallel()
+
+	v := validator.New(validator.Pattern("alias", regexp.MustCompile(`^[a-zA-Z0-9_-]{3,32}$`)))
+
+	testCases := []struct {
+		name     string
+		request  *request
+		expected []apierror.FieldError
+	}{
+		{
+			name:    "Valid",
+			request: &request{URL: "https://example.com", Alias: "docs", TTL: time.Hour, Tags: []string{"a"}, Mode: "drop"},
+		},
+		{
+			name:     "Missing URL",
+			request:  &request{},
+			expected: []apierror.FieldError{{Field: "url", Code: validator.CodeRequired, Err: errors.New("is required")}},
+		},
+		{
+			name:     "Relative URL",
+			request:  &request{URL: "/docs"},
+			expected: []apierror.FieldError{{Field: "url", Code: validator.CodeInvalidURL, Err: errors.New("must be an absolute URL")}},
+		},
+		{
+			name:     "Scheme",
+			request:  &request{URL: "ftp://example.com"},
+			expected: []apierror.FieldError{{Field: "url", Code: validator.CodeSchemeNotAllowed, Err: errors.New("must use the http or https scheme")}},
+		},
+		{
+			name:     "Long URL",
+			request:  &request{URL: "https://example.com/a-rather-long-path"},
+			expected: []apierror.FieldError{{Field: "url", Code: validator.CodeTooLong, Err: errors.New("must have at most 32 characters")}},
+		},
+		{
+			name:    "Every violation",
+			request: &request{URL: "https://example.com", Alias: "a b", TTL: time.Second, Clicks: -1, Tags: []string{"a", "", "c"}, Mode: "keep"},
+			expected: []apierror.FieldError{
+				{Field: "alias", Code: validator.CodePatternMismatch, Err: errors.New("must match ^[a-zA-Z0-9_-]{3,32}$")},
+				{Field: "ttl", Code: validator.CodeTooSmall, Err: errors.New("must be at least 1m")},
+				{Field: "clicks", Code: validator.CodeTooSmall, Err: errors.New("must be at least 0")},
+				{Field: "tags", Code: validator.CodeTooMany, Err: errors.New("must have at most 2 items")},
+				{Field: "mode", Code: validator.CodeNotAllowed, Err: errors.New("must be one of drop, append")},
+			},
+		},
+		{
+			name:    "Nested",
+			request: &request{URL: "https://example.com", Tags: []string{""}, Targets: []target{{URL: "https://example.com"}, {URL: "http://example.com"}}},
+			expected: []apierror.FieldError{
+				{Field: "tags[0]", Code: validator.CodeTooShort, Err: errors.New("must have at least 1 characters")},
+				{Field: "targets[1].url", Code: validator.CodeSchemeNotAllowed, Err: errors.New("must use the https scheme")},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v.Struct(tc.request)
+			if tc.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, apierror.ErrBadRequest)
+
+			fieldErrs := apierror.FieldErrorsOf(err)
+			require.Len(t, fieldErrs, len(tc.expected))
+
+			for i, expected := range tc.expected {
+				require.Equal(t, expected.Field, fieldErrs[i].Field)
+				require.Equal(t, expected.Code, fieldErrs[i].Code)
+				require.EqualError(t, fieldErrs[i].Err, expected.Err.Error())
+			}
+		})
+	}
+}
+
+func TestValidator_NotStruct(t *testing.T) {
+	t.Parallel()
+
+	err := validator.New().Struct("url")
+	require.ErrorIs(t, err, apierror.ErrInternalServer)
+}
@@ -0,0 +1,76 @@
+// Package webhook signs webhook requests and lets receivers verify them.
+//
+// A request is signed with HMAC-SHA256 over its timestamp and body, joined
+// by a dot, using the secret shared with the receiver. The timestamp and the
+// signature travel in the TimestampHeader and SignatureHeader headers.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// TimestampHeader holds the Unix time the request was signed at.
+	TimestampHeader = "X-Webhook-Timestamp"
+	// SignatureHeader holds the signature of the request.
+	SignatureHeader = "X-Webhook-Signature"
+	// EventHeader names the event the request notifies of.
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader identifies the delivery, which keeps its ID across retries.
+	DeliveryHeader = "X-Webhook-Delivery"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	ErrTimestampExpired = errors.New("webhook: timestamp outside tolerance")
+)
+
+// Sign returns the signature of body sent at timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	return signaturePrefix + hex.EncodeToString(mac(secret, strconv.FormatInt(timestamp.Unix(), 10), body))
+}
+
+// Verify checks signature and timestamp, the values of the signature and
+// timestamp headers, against body. Requests signed more than tolerance away
+// from now are rejected to make replaying them harder; a zero tolerance
+// accepts any timestamp.
+func Verify(secret, timestamp, signature string, body []byte, now time.Time, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	given, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil || !strings.HasPrefix(signature, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal(given, mac(secret, timestamp, body)) {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 {
+		if skew := now.Sub(time.Unix(unix, 0)); skew > tolerance || skew < -tolerance {
+			return ErrTimestampExpired
+		}
+	}
+
+	return nil
+}
+
+func mac(secret, timestamp string, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp))
+	h.Write([]byte("."))
+	h.Write(body)
+
+	return h.Sum(nil)
+}
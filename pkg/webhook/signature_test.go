@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	body := []byte(`{"event":"link.created"}`)
+	signature := Sign("secret", now, body)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		tolerance time.Duration
+		want      error
+	}{
+		{
+			name:      "valid",
+			secret:    "secret",
+			timestamp: timestamp,
+			signature: signature,
+			body:      body,
+			now:       now.Add(time.Minute),
+			tolerance: 5 * time.Minute,
+		},
+		{
+			name:      "no tolerance",
+			secret:    "secret",
+			timestamp: timestamp,
+			signature: signature,
+			body:      body,
+			now:       now.Add(24 * time.Hour),
+		},
+		{
+			name:      "wrong secret",
+			secret:    "other",
+			timestamp: timestamp,
+			signature: signature,
+			body:      body,
+			now:       now,
+			want:      ErrInvalidSignature,
+		},
+		{
+			name:      "modified body",
+			secret:    "secret",
+			timestamp: timestamp,
+			signature: signature,
+			body:      []byte(`{"event":"link.expired"}`),
+			now:       now,
+			want:      ErrInvalidSignature,
+		},
+		{
+			name:      "modified timestamp",
+			secret:    "secret",
+			timestamp: strconv.FormatInt(now.Unix()+1, 10),
+			signature: signature,
+			body:      body,
+			now:       now,
+			want:      ErrInvalidSignature,
+		},
+		{
+			name:      "no prefix",
+			secret:    "secret",
+			timestamp: timestamp,
+			signature: signature[len(signaturePrefix):],
+			body:      body,
+			now:       now,
+			want:      ErrInvalidSignature,
+		},
+		{
+			name:      "bad timestamp",
+			secret:    "secret",
+			timestamp: "yesterday",
+			signature: signature,
+			body:      body,
+			now:       now,
+			want:      ErrInvalidSignature,
+		},
+		{
+			name:      "expired",
+			secret:    "secret",
+			timestamp: timestamp,
+			signature: signature,
+			body:      body,
+			now:       now.Add(10 * time.Minute),
+			tolerance: 5 * time.Minute,
+			want:      ErrTimestampExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Verify(tt.secret, tt.timestamp, tt.signature, tt.body, tt.now, tt.tolerance)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
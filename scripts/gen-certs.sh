@@ -0,0 +1,27 @@
+#!/bin/sh
+# Generates a throwaway CA with server and client certificates for trying out
+# TLS and client certificate verification locally:
+#
+#   http.tls / grpc.tls:  cert_file: certs/server.crt, key_file: certs/server.key, client_ca: certs/ca.crt
+#   grpcurl -cacert certs/ca.crt -cert certs/client.crt -key certs/client.key localhost:8081 list
+set -e
+
+dir=${1:-certs}
+host=${2:-localhost}
+mkdir -p "$dir"
+cd "$dir"
+
+openssl req -x509 -newkey ec -pkeyopt ec_paramgen_curve:P-256 -nodes -days 30 \
+	-subj "/CN=ShortLinkAPI test CA" -keyout ca.key -out ca.crt
+
+issue() {
+	openssl req -newkey ec -pkeyopt ec_paramgen_curve:P-256 -nodes \
+		-subj "/CN=$1" -keyout "$2.key" -out "$2.csr"
+	printf 'subjectAltName=DNS:%s,IP:127.0.0.1\nextendedKeyUsage=%s\n' "$1" "$3" > "$2.ext"
+	openssl x509 -req -in "$2.csr" -CA ca.crt -CAkey ca.key -CAcreateserial -days 30 \
+		-extfile "$2.ext" -out "$2.crt"
+	rm "$2.csr" "$2.ext"
+}
+
+issue "$host" server serverAuth
+issue client client clientAuth